@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
@@ -290,3 +291,95 @@ func TestParseFlagRateLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadConfigFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "sebak-config")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`
+network-id: sebak-test-network
+validators:
+  - self
+  - https://localhost:12346?address=GDPQ2LBYP3RL3O675H2N5IEYM6PRJNUA5QFMKXIHGTKEB5KS5T3KHFA2
+rate-limit-api:
+  - 10-S
+  - 1.2.3.4=8-S
+base-fee: 20000
+inflation-ratio: 0.0000002
+`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	var networkID, validators, baseFee, inflationRatio string
+	var rateLimitAPI cmdcommon.ListFlags
+	newFlags := func() *pflag.FlagSet {
+		flags := pflag.NewFlagSet(os.Args[0], pflag.ContinueOnError)
+		flags.StringVar(&networkID, "network-id", "", "")
+		flags.StringVar(&validators, "validators", "", "")
+		flags.StringVar(&baseFee, "base-fee", common.GetENVValue("SEBAK_BASE_FEE", ""), "")
+		flags.StringVar(&inflationRatio, "inflation-ratio", "", "")
+		flags.Var(&rateLimitAPI, "rate-limit-api", "")
+		return flags
+	}
+
+	{ // load from file
+		require.NoError(t, loadConfigFile(newFlags(), f.Name()))
+		require.Equal(t, "sebak-test-network", networkID)
+		require.Equal(t, 2, len(strings.Fields(validators)))
+		require.Equal(t, cmdcommon.ListFlags{"10-S", "1.2.3.4=8-S"}, rateLimitAPI)
+		require.Equal(t, "20000", baseFee)
+
+		ratio, err := common.String2InflationRatio(inflationRatio)
+		require.NoError(t, err)
+		require.Equal(t, 0.0000002, ratio)
+	}
+
+	{ // the flag of command line is not overridden
+		rateLimitAPI = nil
+		flags := newFlags()
+		require.NoError(t, flags.Parse([]string{"--base-fee=30000"}))
+		require.NoError(t, loadConfigFile(flags, f.Name()))
+		require.Equal(t, "30000", baseFee)
+		require.Equal(t, "sebak-test-network", networkID)
+	}
+
+	{ // the flag set by environment variable is not overridden
+		rateLimitAPI = nil
+		os.Setenv("SEBAK_BASE_FEE", "40000")
+		defer os.Unsetenv("SEBAK_BASE_FEE")
+
+		require.NoError(t, loadConfigFile(newFlags(), f.Name()))
+		require.Equal(t, "40000", baseFee)
+		require.Equal(t, "sebak-test-network", networkID)
+	}
+
+	{ // unknown key
+		flags := pflag.NewFlagSet(os.Args[0], pflag.ContinueOnError)
+		flags.StringVar(&networkID, "network-id", "", "")
+		require.Error(t, loadConfigFile(flags, f.Name()))
+	}
+}
+
+func TestParseFlagNetworkParams(t *testing.T) {
+	defer func(baseFee, inflationRatio string) {
+		flagBaseFee = baseFee
+		flagInflationRatio = inflationRatio
+	}(flagBaseFee, flagInflationRatio)
+
+	flagNetworkID = "sebak-test-network"
+	flagValidators = "self"
+	flagKPSecretSeed = "SCN4NSV5SVHIZWUDJFT4Z5FFVHO3TFRTOIBQLHMNPAZJ37K5A2YFSCBM"
+	flagBindURL = "http://0.0.0.0:12345"
+
+	parseFlagsNode()
+	require.Equal(t, common.NewNetworkParams(), networkParams)
+
+	flagBaseFee = "20000"
+	flagInflationRatio = "0.0000002"
+
+	parseFlagsNode()
+	require.Equal(t, common.Amount(20000), networkParams.BaseFee)
+	require.Equal(t, 0.0000002, networkParams.InflationRatio)
+	require.Equal(t, common.BaseReserve, networkParams.BaseReserve)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// loadConfigFile sets the flags by the yaml config file; the keys of file are
+// the flag names without '--', for example,
+//
+//	network-id: sebak-test-network
+//	bind: https://0.0.0.0:12345
+//	validators:
+//	  - self
+//	  - https://localhost:12346?address=GDPQ2LBYP3RL3O675H2N5IEYM6PRJNUA5QFMKXIHGTKEB5KS5T3KHFA2
+//	base-fee: 10000
+//
+// The config file has the lowest priority; the flags given by command line and
+// the flags set by the environment variables, like `SEBAK_NETWORK_ID` for
+// 'network-id', are not overridden by the config file. The values are
+// validated with the flags.
+func loadConfigFile(flags *pflag.FlagSet, path string) (err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+
+	values := map[string]interface{}{}
+	if err = yaml.Unmarshal(b, &values); err != nil {
+		return
+	}
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f := flags.Lookup(key)
+		if f == nil || key == "config" {
+			return fmt.Errorf("unknown key, '%s'", key)
+		}
+		if f.Changed {
+			continue
+		}
+		if _, found := os.LookupEnv(flagEnvName(key)); found {
+			continue
+		}
+
+		var items []string
+		switch v := values[key].(type) {
+		case nil:
+			return fmt.Errorf("empty value of '%s'", key)
+		case []interface{}:
+			for _, i := range v {
+				items = append(items, fmt.Sprint(i))
+			}
+		case map[interface{}]interface{}:
+			return fmt.Errorf("invalid value of '%s'", key)
+		default:
+			items = append(items, fmt.Sprint(v))
+		}
+
+		// the list flag is set by each item; the other flags get the items
+		// separated by space, like `--validators`
+		if f.Value.Type() != "list" {
+			items = []string{strings.Join(items, " ")}
+		}
+		for _, i := range items {
+			if err = flags.Set(key, i); err != nil {
+				return fmt.Errorf("invalid value of '%s'; %v", key, err)
+			}
+		}
+	}
+
+	return
+}
+
+// flagEnvName returns the name of environment variable of the flag, for
+// example, `SEBAK_BLOCK_TIME` for 'block-time'.
+func flagEnvName(name string) string {
+	return "SEBAK_" + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
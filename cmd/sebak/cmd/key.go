@@ -22,5 +22,8 @@ func init() {
 	}
 
 	keyCmd.AddCommand(key.GenerateCmd)
+	keyCmd.AddCommand(key.ImportCmd)
+	keyCmd.AddCommand(key.ExportCmd)
+	keyCmd.AddCommand(key.ListCmd)
 	rootCmd.AddCommand(keyCmd)
 }
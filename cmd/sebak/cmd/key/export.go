@@ -0,0 +1,47 @@
+package key
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"boscoin.io/sebak/cmd/sebak/common"
+)
+
+var (
+	ExportCmd *cobra.Command
+)
+
+func init() {
+	ExportCmd = &cobra.Command{
+		Use:   "export <keystore file>",
+		Short: "Print the secret seed of the encrypted keystore",
+		Args:  cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			kp, err := common.LoadKeystore(args[0], flagPassphraseFile)
+			if err != nil {
+				common.PrintFlagsError(c, "<keystore file>", err)
+			}
+
+			encoders := map[string]common.Encode{
+				"json":       common.DefaultEncodes["json"],
+				"prettyjson": common.DefaultEncodes["prettyjson"],
+				"default":    defaultEncode,
+				"oneline":    onelineEncode,
+			}
+
+			encode, ok := encoders[flagFormat]
+			if !ok {
+				common.PrintFlagsError(c, "format", fmt.Errorf(`"%s" not recognized`, flagFormat))
+			}
+
+			if err = encode(keyPair{Seed: kp.Seed(), Address: kp.Address()}, os.Stdout); err != nil {
+				common.PrintError(c, err)
+			}
+		},
+	}
+
+	ExportCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase; the passphrase is asked without it")
+	ExportCmd.Flags().StringVar(&flagFormat, "format", "default", "format={default, json, oneline, prettyjson}")
+}
@@ -0,0 +1,53 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"boscoin.io/sebak/cmd/sebak/common"
+	"boscoin.io/sebak/lib/keystore"
+)
+
+var (
+	ImportCmd *cobra.Command
+)
+
+func init() {
+	ImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import the secret seed into the encrypted keystore",
+		Long: `Import the secret seed into the encrypted keystore.
+
+The secret seed is asked on the terminal without echo, or read from the first
+line of stdin, and it is saved as '<address>.json' in --keystore-dir after
+encrypted by the passphrase.`,
+		Args: cobra.NoArgs,
+		Run: func(c *cobra.Command, args []string) {
+			if flagKDF != keystore.KDFScrypt && flagKDF != keystore.KDFArgon2id {
+				common.PrintFlagsError(c, "--kdf", fmt.Errorf(`"%s" not recognized`, flagKDF))
+			}
+
+			seed, err := common.ReadSecret("Secret seed: ")
+			if err != nil {
+				common.PrintFlagsError(c, "<secret seed>", err)
+			}
+
+			passphrase, err := common.ReadPassphrase(flagPassphraseFile, true)
+			if err != nil {
+				common.PrintFlagsError(c, "--passphrase-file", err)
+			}
+
+			path, err := importKey(flagKeystoreDir, seed, passphrase, flagKDF)
+			if err != nil {
+				common.PrintError(c, err)
+			}
+
+			fmt.Println(path)
+		},
+	}
+
+	ImportCmd.Flags().StringVar(&flagKeystoreDir, "keystore-dir", flagKeystoreDir, "directory of the keystore files")
+	ImportCmd.Flags().StringVar(&flagKDF, "kdf", flagKDF, "key derivation function, {scrypt, argon2id}")
+	ImportCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase; the passphrase is asked without it")
+}
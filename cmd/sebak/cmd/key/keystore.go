@@ -0,0 +1,60 @@
+package key
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/keystore"
+)
+
+var (
+	flagKeystoreDir    string = common.GetENVValue("SEBAK_KEYSTORE_DIR", defaultKeystoreDir())
+	flagKDF            string = keystore.KDFScrypt
+	flagPassphraseFile string
+)
+
+func defaultKeystoreDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "keystore"
+	}
+
+	return filepath.Join(home, ".sebak", "keystore")
+}
+
+// importKey encrypts the secret seed by `passphrase` and saves it as
+// '<address>.json' in `dir`.
+func importKey(dir, seed string, passphrase []byte, kdf string) (path string, err error) {
+	var parsed keypair.KP
+	if parsed, err = keypair.Parse(seed); err != nil {
+		return
+	}
+	kp, ok := parsed.(*keypair.Full)
+	if !ok {
+		err = fmt.Errorf("Provided key is an address, not a secret seed")
+		return
+	}
+
+	var params keystore.KDFParams
+	if params, err = keystore.DefaultKDFParams(kdf); err != nil {
+		return
+	}
+
+	var k keystore.Keystore
+	if k, err = keystore.Encrypt(kp, passphrase, kdf, params); err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+
+	path = filepath.Join(dir, keystore.FileName(kp.Address()))
+	err = k.Save(path)
+
+	return
+}
@@ -0,0 +1,63 @@
+package key
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/cmd/sebak/common"
+	"boscoin.io/sebak/lib/keystore"
+)
+
+func TestImportKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kp, _ := keypair.Random()
+	keystoreDir := filepath.Join(dir, "keystore")
+
+	path, err := importKey(keystoreDir, kp.Seed(), []byte("sebak"), keystore.KDFArgon2id)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(keystoreDir, kp.Address()+".json"), path)
+
+	// already imported
+	_, err = importKey(keystoreDir, kp.Seed(), []byte("sebak"), keystore.KDFArgon2id)
+	require.Error(t, err)
+
+	// address is not secret seed
+	_, err = importKey(keystoreDir, kp.Address(), []byte("sebak"), keystore.KDFArgon2id)
+	require.Error(t, err)
+
+	files, err := keystore.List(keystoreDir)
+	require.NoError(t, err)
+	require.Equal(t, []keystore.File{{Path: path, Address: kp.Address()}}, files)
+
+	// the passphrase is read from the first line of file
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("sebak\n"), 0600))
+
+	loaded, err := common.LoadKeystore(path, passphraseFile)
+	require.NoError(t, err)
+	require.Equal(t, kp.Seed(), loaded.Seed())
+
+	loaded, err = common.ParseSecretSeed("", path, passphraseFile)
+	require.NoError(t, err)
+	require.Equal(t, kp.Seed(), loaded.Seed())
+
+	_, err = common.ParseSecretSeed(kp.Seed(), path, passphraseFile)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("wrong\n"), 0600))
+	_, err = common.LoadKeystore(path, passphraseFile)
+	require.Error(t, err)
+
+	// the empty passphrase is not allowed in the file either
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("\r\nsebak\n"), 0600))
+	_, err = common.ReadPassphrase(passphraseFile, false)
+	require.EqualError(t, err, "empty passphrase")
+}
@@ -0,0 +1,34 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"boscoin.io/sebak/cmd/sebak/common"
+	"boscoin.io/sebak/lib/keystore"
+)
+
+var (
+	ListCmd *cobra.Command
+)
+
+func init() {
+	ListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the addresses of the encrypted keystore",
+		Args:  cobra.NoArgs,
+		Run: func(c *cobra.Command, args []string) {
+			files, err := keystore.List(flagKeystoreDir)
+			if err != nil {
+				common.PrintFlagsError(c, "--keystore-dir", err)
+			}
+
+			for _, f := range files {
+				fmt.Printf("%s %s\n", f.Address, f.Path)
+			}
+		},
+	}
+
+	ListCmd.Flags().StringVar(&flagKeystoreDir, "keystore-dir", flagKeystoreDir, "directory of the keystore files")
+}
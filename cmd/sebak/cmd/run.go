@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"os"
@@ -36,18 +38,26 @@ const (
 var (
 	flagBindURL           string = common.GetENVValue("SEBAK_BIND", defaultBindURL)
 	flagBlockTime         string = common.GetENVValue("SEBAK_BLOCK_TIME", "5")
+	flagConfig            string = common.GetENVValue("SEBAK_CONFIG", "")
 	flagDebugPProf        bool   = common.GetENVValue("SEBAK_DEBUG_PPROF", "0") == "1"
 	flagKPSecretSeed      string = common.GetENVValue("SEBAK_SECRET_SEED", "")
+	flagKeystore          string = common.GetENVValue("SEBAK_KEYSTORE", "")
+	flagPassphraseFile    string = common.GetENVValue("SEBAK_PASSPHRASE_FILE", "")
+	flagGossipFanout      string = common.GetENVValue("SEBAK_GOSSIP_FANOUT", "0")
 	flagLog               string = common.GetENVValue("SEBAK_LOG", "")
 	flagLogLevel          string = common.GetENVValue("SEBAK_LOG_LEVEL", defaultLogLevel.String())
 	flagLogFormat         string = common.GetENVValue("SEBAK_LOG_FORMAT", defaultLogFormat)
+	flagMutualTLS         bool   = common.GetENVValue("SEBAK_MTLS", "0") == "1"
 	flagNetworkID         string = common.GetENVValue("SEBAK_NETWORK_ID", "")
 	flagOperationsLimit   string = common.GetENVValue("SEBAK_OPERATIONS_LIMIT", "1000")
+	flagProposerSelector  string = common.GetENVValue("SEBAK_PROPOSER_SELECTOR", consensus.ProposerSelectorSequential)
 	flagPublishURL        string = common.GetENVValue("SEBAK_PUBLISH", "")
+	flagSnapshotInterval  string = common.GetENVValue("SEBAK_SNAPSHOT_INTERVAL", "0")
 	flagSyncCheckInterval string = common.GetENVValue("SEBAK_SYNC_CHECK_INTERVAL", "30s")
 	flagSyncFetchTimeout  string = common.GetENVValue("SEBAK_SYNC_FETCH_TIMEOUT", "1m")
 	flagSyncPoolSize      string = common.GetENVValue("SEBAK_SYNC_POOL_SIZE", "300")
 	flagSyncRetryInterval string = common.GetENVValue("SEBAK_SYNC_RETRY_INTERVAL", "10s")
+	flagSyncSnapshot      bool   = common.GetENVValue("SEBAK_SYNC_SNAPSHOT", "0") == "1"
 	flagThreshold         string = common.GetENVValue("SEBAK_THRESHOLD", "67")
 	flagTimeoutACCEPT     string = common.GetENVValue("SEBAK_TIMEOUT_ACCEPT", "2")
 	flagTimeoutINIT       string = common.GetENVValue("SEBAK_TIMEOUT_INIT", "2")
@@ -55,9 +65,19 @@ var (
 	flagTLSCertFile       string = common.GetENVValue("SEBAK_TLS_CERT", "sebak.crt")
 	flagTLSKeyFile        string = common.GetENVValue("SEBAK_TLS_KEY", "sebak.key")
 	flagTransactionsLimit string = common.GetENVValue("SEBAK_TRANSACTIONS_LIMIT", "1000")
-	flagUnfreezingPeriod  string = common.GetENVValue("SEBAK_UNFREEZING_PERIOD", "241920")
+	flagTxPoolLimit       string = common.GetENVValue("SEBAK_TXPOOL_LIMIT", "10000")
+	flagUnfreezingPeriod  string = common.GetENVValue("SEBAK_UNFREEZING_PERIOD", strconv.FormatUint(common.UnfreezingPeriod, 10))
 	flagValidators        string = common.GetENVValue("SEBAK_VALIDATORS", "")
 	flagVerbose           bool   = common.GetENVValue("SEBAK_VERBOSE", "0") == "1"
+	flagWatcher           bool   = common.GetENVValue("SEBAK_WATCHER", "0") == "1"
+	flagSyncWatchInterval string = common.GetENVValue("SEBAK_SYNC_WATCH_INTERVAL", "5s")
+
+	// network parameters; see `common.NetworkParams`
+	flagBaseFee                   string = common.GetENVValue("SEBAK_BASE_FEE", common.BaseFee.String())
+	flagBaseReserve               string = common.GetENVValue("SEBAK_BASE_RESERVE", common.BaseReserve.String())
+	flagInflationRatio            string = common.GetENVValue("SEBAK_INFLATION_RATIO", common.InflationRatioString)
+	flagBlockHeightEndOfInflation string = common.GetENVValue("SEBAK_BLOCK_HEIGHT_END_OF_INFLATION", strconv.FormatUint(common.BlockHeightEndOfInflation, 10))
+	flagBallotTimeAllowDuration   string = common.GetENVValue("SEBAK_BALLOT_TIME_ALLOW_DURATION", common.BallotConfirmedTimeAllowDuration.String())
 
 	flagRateLimitAPI        cmdcommon.ListFlags // "SEBAK_RATE_LIMIT_API"
 	flagRateLimitNode       cmdcommon.ListFlags // "SEBAK_RATE_LIMIT_NODE"
@@ -69,22 +89,27 @@ var (
 
 	bindEndpoint      *common.Endpoint
 	blockTime         time.Duration
+	gossipFanout      uint64
 	kp                *keypair.Full
 	localNode         *node.LocalNode
+	networkParams     common.NetworkParams
 	operationsLimit   uint64
 	publishEndpoint   *common.Endpoint
 	rateLimitRuleAPI  common.RateLimitRule
 	rateLimitRuleNode common.RateLimitRule
+	snapshotInterval  uint64
 	storageConfig     *storage.Config
 	syncCheckInterval time.Duration
 	syncFetchTimeout  time.Duration
 	syncPoolSize      uint64
 	syncRetryInterval time.Duration
+	syncWatchInterval time.Duration
 	threshold         int
 	timeoutACCEPT     time.Duration
 	timeoutINIT       time.Duration
 	timeoutSIGN       time.Duration
 	transactionsLimit uint64
+	txPoolLimit       uint64
 	validators        []*node.Validator
 
 	logLevel logging.Lvl
@@ -99,6 +124,12 @@ func init() {
 		Use:   "node",
 		Short: "Run sebak node",
 		Run: func(c *cobra.Command, args []string) {
+			if len(flagConfig) > 0 {
+				if err := loadConfigFile(c.Flags(), flagConfig); err != nil {
+					cmdcommon.PrintFlagsError(c, "--config", err)
+				}
+			}
+
 			// If `--genesis` was provided, perfom `sebak genesis` before starting the node
 			// This allows one-step startup from scratch, quite useful for testing
 			if len(flagGenesis) != 0 {
@@ -140,8 +171,11 @@ func init() {
 	}
 	flagStorageConfigString = common.GetENVValue("SEBAK_STORAGE", fmt.Sprintf("file://%s/db", currentDirectory))
 
+	nodeCmd.Flags().StringVar(&flagConfig, "config", flagConfig, "yaml config file; the keys are the flag names without '--', and the given flags and the environment variables override them")
 	nodeCmd.Flags().StringVar(&flagGenesis, "genesis", flagGenesis, "performs the 'genesis' command before running node. Syntax: key[,balance]")
 	nodeCmd.Flags().StringVar(&flagKPSecretSeed, "secret-seed", flagKPSecretSeed, "secret seed of this node")
+	nodeCmd.Flags().StringVar(&flagKeystore, "keystore", flagKeystore, "encrypted keystore file of this node, instead of --secret-seed")
+	nodeCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase of --keystore; the passphrase is asked without it")
 	nodeCmd.Flags().StringVar(&flagNetworkID, "network-id", flagNetworkID, "network id")
 	nodeCmd.Flags().StringVar(&flagLogLevel, "log-level", flagLogLevel, "log level, {crit, error, warn, info, debug}")
 	nodeCmd.Flags().StringVar(&flagLogFormat, "log-format", flagLogFormat, "log format, {terminal, json}")
@@ -152,7 +186,9 @@ func init() {
 	nodeCmd.Flags().StringVar(&flagStorageConfigString, "storage", flagStorageConfigString, "storage uri")
 	nodeCmd.Flags().StringVar(&flagTLSCertFile, "tls-cert", flagTLSCertFile, "tls certificate file")
 	nodeCmd.Flags().StringVar(&flagTLSKeyFile, "tls-key", flagTLSKeyFile, "tls key file")
+	nodeCmd.Flags().BoolVar(&flagMutualTLS, "mtls", flagMutualTLS, "mutual tls between validators; the tls certificate must be bound to the node address by 'sebak tls --secret-seed'")
 	nodeCmd.Flags().StringVar(&flagValidators, "validators", flagValidators, "set validator: <endpoint url>?address=<public address>[&alias=<alias>] [ <validator>...]")
+	nodeCmd.Flags().BoolVar(&flagWatcher, "watcher", flagWatcher, "run as watcher; the watcher follows the validators and serves the api, but never votes")
 	nodeCmd.Flags().StringVar(&flagThreshold, "threshold", flagThreshold, "threshold")
 	nodeCmd.Flags().StringVar(&flagTimeoutINIT, "timeout-init", flagTimeoutINIT, "timeout of the init state")
 	nodeCmd.Flags().StringVar(&flagTimeoutSIGN, "timeout-sign", flagTimeoutSIGN, "timeout of the sign state")
@@ -160,7 +196,15 @@ func init() {
 	nodeCmd.Flags().StringVar(&flagBlockTime, "block-time", flagBlockTime, "block creation time")
 	nodeCmd.Flags().StringVar(&flagTransactionsLimit, "transactions-limit", flagTransactionsLimit, "transactions limit in a ballot")
 	nodeCmd.Flags().StringVar(&flagUnfreezingPeriod, "unfreezing-period", flagUnfreezingPeriod, "how long freezing must last")
+	nodeCmd.Flags().StringVar(&flagBaseFee, "base-fee", flagBaseFee, "base fee of operation")
+	nodeCmd.Flags().StringVar(&flagBaseReserve, "base-reserve", flagBaseReserve, "minimum balance of new account")
+	nodeCmd.Flags().StringVar(&flagInflationRatio, "inflation-ratio", flagInflationRatio, "inflation ratio in every block")
+	nodeCmd.Flags().StringVar(&flagBlockHeightEndOfInflation, "block-height-end-of-inflation", flagBlockHeightEndOfInflation, "block height of inflation end")
+	nodeCmd.Flags().StringVar(&flagBallotTimeAllowDuration, "ballot-time-allow-duration", flagBallotTimeAllowDuration, "allowed time difference of the confirmed time of ballot")
 	nodeCmd.Flags().StringVar(&flagOperationsLimit, "operations-limit", flagOperationsLimit, "operations limit in a transaction")
+	nodeCmd.Flags().StringVar(&flagTxPoolLimit, "txpool-limit", flagTxPoolLimit, "transactions limit in the transaction pool; 0 means no limit")
+	nodeCmd.Flags().StringVar(&flagProposerSelector, "proposer-selector", flagProposerSelector, fmt.Sprintf("proposer selector; all the validators must use the same one %v", consensus.ProposerSelectors))
+	nodeCmd.Flags().StringVar(&flagGossipFanout, "gossip-fanout", flagGossipFanout, "number of validators to relay ballots and transactions; 0 means direct broadcast to all validators")
 	nodeCmd.Flags().Var(
 		&flagRateLimitAPI,
 		"rate-limit-api",
@@ -176,6 +220,9 @@ func init() {
 	nodeCmd.Flags().StringVar(&flagSyncFetchTimeout, "sync-fetch-timeout", flagSyncFetchTimeout, "sync fetch timeout")
 	nodeCmd.Flags().StringVar(&flagSyncRetryInterval, "sync-retry-interval", flagSyncRetryInterval, "sync retry interval")
 	nodeCmd.Flags().StringVar(&flagSyncCheckInterval, "sync-check-interval", flagSyncCheckInterval, "sync check interval")
+	nodeCmd.Flags().StringVar(&flagSyncWatchInterval, "sync-watch-interval", flagSyncWatchInterval, "interval to check the latest block of validators in watcher mode")
+	nodeCmd.Flags().BoolVar(&flagSyncSnapshot, "sync-snapshot", flagSyncSnapshot, "load the account state snapshot of validators before syncing blocks; only for the new node")
+	nodeCmd.Flags().StringVar(&flagSnapshotInterval, "snapshot-interval", flagSnapshotInterval, "interval of blocks to make the account state snapshot; 0 means no snapshot. The snapshot is confirmed by the threshold of validators, so the validators should use the same interval")
 
 	rootCmd.AddCommand(nodeCmd)
 }
@@ -257,16 +304,25 @@ func parseFlagsNode() {
 	if len(flagNetworkID) < 1 {
 		cmdcommon.PrintFlagsError(nodeCmd, "--network-id", errors.New("--network-id must be given"))
 	}
-	if len(flagKPSecretSeed) < 1 {
-		cmdcommon.PrintFlagsError(nodeCmd, "--secret-seed", errors.New("must be given"))
-	}
-
-	var parsedKP keypair.KP
-	parsedKP, err = keypair.Parse(flagKPSecretSeed)
-	if err != nil {
-		cmdcommon.PrintFlagsError(nodeCmd, "--secret-seed", err)
+	if len(flagKeystore) > 0 {
+		if len(flagKPSecretSeed) > 0 {
+			cmdcommon.PrintFlagsError(nodeCmd, "--keystore", errors.New("--secret-seed and --keystore can not be given together"))
+		}
+		if kp, err = cmdcommon.LoadKeystore(flagKeystore, flagPassphraseFile); err != nil {
+			cmdcommon.PrintFlagsError(nodeCmd, "--keystore", err)
+		}
 	} else {
-		kp = parsedKP.(*keypair.Full)
+		if len(flagKPSecretSeed) < 1 {
+			cmdcommon.PrintFlagsError(nodeCmd, "--secret-seed", errors.New("--secret-seed or --keystore must be given"))
+		}
+
+		var parsedKP keypair.KP
+		parsedKP, err = keypair.Parse(flagKPSecretSeed)
+		if err != nil {
+			cmdcommon.PrintFlagsError(nodeCmd, "--secret-seed", err)
+		} else {
+			kp = parsedKP.(*keypair.Full)
+		}
 	}
 
 	if p, err := common.ParseEndpoint(flagBindURL); err != nil {
@@ -285,9 +341,34 @@ func parseFlagsNode() {
 		}
 	}
 
+	if flagMutualTLS {
+		// the validators refuse the certificate of watcher
+		if flagWatcher {
+			cmdcommon.PrintFlagsError(nodeCmd, "--mtls", errors.New("can not be used with --watcher"))
+		}
+		if strings.ToLower(bindEndpoint.Scheme) != "https" {
+			cmdcommon.PrintFlagsError(nodeCmd, "--mtls", errors.New("https is needed"))
+		}
+
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(flagTLSCertFile, flagTLSKeyFile); err != nil {
+			cmdcommon.PrintFlagsError(nodeCmd, "--tls-cert", err)
+		}
+		var parsed *x509.Certificate
+		if parsed, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			cmdcommon.PrintFlagsError(nodeCmd, "--tls-cert", err)
+		}
+		if address, err := network.CertificateAddress(parsed); err != nil {
+			cmdcommon.PrintFlagsError(nodeCmd, "--tls-cert", err)
+		} else if address != kp.Address() {
+			cmdcommon.PrintFlagsError(nodeCmd, "--tls-cert", errors.New("certificate is bound to the other node"))
+		}
+	}
+
 	queries := bindEndpoint.Query()
 	queries.Add("TLSCertFile", flagTLSCertFile)
 	queries.Add("TLSKeyFile", flagTLSKeyFile)
+	queries.Add("MutualTLS", strconv.FormatBool(flagMutualTLS))
 	queries.Add("IdleTimeout", "3s")
 	bindEndpoint.RawQuery = queries.Encode()
 
@@ -326,6 +407,18 @@ func parseFlagsNode() {
 		cmdcommon.PrintFlagsError(nodeCmd, "--operations-limit", err)
 	}
 
+	if txPoolLimit, err = strconv.ParseUint(flagTxPoolLimit, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--txpool-limit", err)
+	}
+
+	if gossipFanout, err = strconv.ParseUint(flagGossipFanout, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--gossip-fanout", err)
+	}
+
+	if _, found := common.InStringArray(consensus.ProposerSelectors, flagProposerSelector); !found {
+		cmdcommon.PrintFlagsError(nodeCmd, "--proposer-selector", errors.InvalidProposerSelector)
+	}
+
 	var tmpUint64 uint64
 	if tmpUint64, err = strconv.ParseUint(flagThreshold, 10, 64); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--threshold", err)
@@ -333,9 +426,30 @@ func parseFlagsNode() {
 		threshold = int(tmpUint64)
 	}
 
-	if common.UnfreezingPeriod, err = strconv.ParseUint(flagUnfreezingPeriod, 10, 64); err != nil {
+	networkParams = common.NewNetworkParams()
+	if networkParams.UnfreezingPeriod, err = strconv.ParseUint(flagUnfreezingPeriod, 10, 64); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--unfreezing-period", err)
 	}
+	if networkParams.BaseFee, err = common.AmountFromString(flagBaseFee); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--base-fee", err)
+	}
+	if networkParams.BaseReserve, err = common.AmountFromString(flagBaseReserve); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--base-reserve", err)
+	}
+	if networkParams.InflationRatio, err = common.String2InflationRatio(flagInflationRatio); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--inflation-ratio", err)
+	}
+	if networkParams.BlockHeightEndOfInflation, err = strconv.ParseUint(flagBlockHeightEndOfInflation, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--block-height-end-of-inflation", err)
+	}
+	networkParams.BallotConfirmedTimeAllowDuration = getTimeDuration(
+		flagBallotTimeAllowDuration,
+		common.BallotConfirmedTimeAllowDuration,
+		"--ballot-time-allow-duration",
+	)
+	if err = networkParams.Validate(); err != nil {
+		cmdcommon.PrintError(nodeCmd, err)
+	}
 
 	if syncPoolSize, err = strconv.ParseUint(flagSyncPoolSize, 10, 64); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--sync-pool-size", err)
@@ -344,6 +458,11 @@ func parseFlagsNode() {
 	syncRetryInterval = getTimeDuration(flagSyncRetryInterval, sync.RetryInterval, "--sync-retry-interval")
 	syncFetchTimeout = getTimeDuration(flagSyncFetchTimeout, sync.FetchTimeout, "--sync-fetch-timeout")
 	syncCheckInterval = getTimeDuration(flagSyncCheckInterval, sync.CheckBlockHeightInterval, "--sync-check-interval")
+	syncWatchInterval = getTimeDuration(flagSyncWatchInterval, sync.WatchInterval, "--sync-watch-interval")
+
+	if snapshotInterval, err = strconv.ParseUint(flagSnapshotInterval, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--snapshot-interval", err)
+	}
 
 	if logLevel, err = logging.LvlFromString(flagLogLevel); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--log-level", err)
@@ -408,6 +527,8 @@ func parseFlagsNode() {
 	parsedFlags = append(parsedFlags, "\n\tstorage", flagStorageConfigString)
 	parsedFlags = append(parsedFlags, "\n\ttls-cert", flagTLSCertFile)
 	parsedFlags = append(parsedFlags, "\n\ttls-key", flagTLSKeyFile)
+	parsedFlags = append(parsedFlags, "\n\tmtls", flagMutualTLS)
+	parsedFlags = append(parsedFlags, "\n\twatcher", flagWatcher)
 	parsedFlags = append(parsedFlags, "\n\tlog-level", flagLogLevel)
 	parsedFlags = append(parsedFlags, "\n\tlog-format", flagLogFormat)
 	parsedFlags = append(parsedFlags, "\n\tlog", flagLog)
@@ -418,8 +539,19 @@ func parseFlagsNode() {
 	parsedFlags = append(parsedFlags, "\n\tblock-time", flagBlockTime)
 	parsedFlags = append(parsedFlags, "\n\ttransactions-limit", flagTransactionsLimit)
 	parsedFlags = append(parsedFlags, "\n\toperations-limit", flagOperationsLimit)
+	parsedFlags = append(parsedFlags, "\n\ttxpool-limit", flagTxPoolLimit)
+	parsedFlags = append(parsedFlags, "\n\tgossip-fanout", flagGossipFanout)
+	parsedFlags = append(parsedFlags, "\n\tproposer-selector", flagProposerSelector)
+	parsedFlags = append(parsedFlags, "\n\tunfreezing-period", networkParams.UnfreezingPeriod)
+	parsedFlags = append(parsedFlags, "\n\tbase-fee", networkParams.BaseFee)
+	parsedFlags = append(parsedFlags, "\n\tbase-reserve", networkParams.BaseReserve)
+	parsedFlags = append(parsedFlags, "\n\tinflation-ratio", networkParams.InflationRatioString())
+	parsedFlags = append(parsedFlags, "\n\tblock-height-end-of-inflation", networkParams.BlockHeightEndOfInflation)
+	parsedFlags = append(parsedFlags, "\n\tballot-time-allow-duration", networkParams.BallotConfirmedTimeAllowDuration)
 	parsedFlags = append(parsedFlags, "\n\trate-limit-api", rateLimitRuleAPI)
 	parsedFlags = append(parsedFlags, "\n\trate-limit-node", rateLimitRuleNode)
+	parsedFlags = append(parsedFlags, "\n\tsnapshot-interval", snapshotInterval)
+	parsedFlags = append(parsedFlags, "\n\tsync-snapshot", flagSyncSnapshot)
 
 	// create current Node
 	localNode, err = node.NewLocalNode(kp, bindEndpoint, "")
@@ -428,6 +560,9 @@ func parseFlagsNode() {
 	}
 	localNode.AddValidators(validators...)
 	localNode.SetPublishEndpoint(publishEndpoint)
+	if flagWatcher {
+		localNode.SetWatcher()
+	}
 
 	var vl []interface{}
 	for _, v := range localNode.GetValidators() {
@@ -490,12 +625,6 @@ func runNode() error {
 		return err
 	}
 
-	connectionManager := network.NewValidatorConnectionManager(
-		localNode,
-		nt,
-		policy,
-	)
-
 	conf := common.Config{
 		TimeoutINIT:       timeoutINIT,
 		TimeoutSIGN:       timeoutSIGN,
@@ -503,9 +632,22 @@ func runNode() error {
 		BlockTime:         blockTime,
 		TxsLimit:          int(transactionsLimit),
 		OpsLimit:          int(operationsLimit),
+		TxPoolLimit:       int(txPoolLimit),
+		GossipFanout:      int(gossipFanout),
+		ProposerSelector:  flagProposerSelector,
+		SnapshotInterval:  snapshotInterval,
 		RateLimitRuleAPI:  rateLimitRuleAPI,
 		RateLimitRuleNode: rateLimitRuleNode,
+		NetworkParams:     networkParams,
 	}
+
+	connectionManager := network.NewValidatorConnectionManager(
+		localNode,
+		nt,
+		policy,
+		conf,
+	)
+
 	st, err := storage.NewStorage(storageConfig)
 	if err != nil {
 		log.Crit("failed to initialize storage", "error", err)
@@ -518,8 +660,10 @@ func runNode() error {
 	c.FetchTimeout = syncFetchTimeout
 	c.RetryInterval = syncRetryInterval
 	c.CheckBlockHeightInterval = syncCheckInterval
+	c.WatchInterval = syncWatchInterval
+	c.SyncSnapshot = flagSyncSnapshot
 
-	syncer := c.NewSyncer()
+	syncer := c.NewSyncer(policy)
 
 	isaac, err := consensus.NewISAAC([]byte(flagNetworkID), localNode, policy, connectionManager, st, conf, syncer)
 	if err != nil {
@@ -536,6 +680,7 @@ func runNode() error {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 			return err
 		}
+		nr.SetSyncInfoFunc(syncer.SyncInfo)
 
 		g.Add(func() error {
 			if err := nr.Start(); err != nil {
@@ -554,6 +699,14 @@ func runNode() error {
 			syncer.Stop()
 		})
 	}
+	if localNode.IsWatcher() {
+		watcher := c.NewWatcher(syncer, policy)
+		g.Add(func() error {
+			return watcher.Start()
+		}, func(error) {
+			watcher.Stop()
+		})
+	}
 	{
 		cancel := make(chan struct{})
 		g.Add(func() error {
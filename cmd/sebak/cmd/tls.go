@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 
 	logging "github.com/inconshreveable/log15"
 	"github.com/spf13/cobra"
+	"github.com/stellar/go/keypair"
 
 	"boscoin.io/sebak/cmd/sebak/common"
 	"boscoin.io/sebak/lib/network"
@@ -28,6 +30,9 @@ func init() {
 	tlsCmd.Flags().StringVar(&flagTLSCertFile, "cert", flagTLSCertFile, "tls certificate file name")
 	tlsCmd.Flags().StringVar(&flagTLSKeyFile, "key", flagTLSKeyFile, "tls key file name")
 	tlsCmd.Flags().StringVar(&flagTLSOutputPath, "output", flagTLSOutputPath, "tls output path")
+	tlsCmd.Flags().StringVar(&flagKPSecretSeed, "secret-seed", flagKPSecretSeed, "secret seed of node; the certificate is bound to the node address for mutual tls")
+	tlsCmd.Flags().StringVar(&flagKeystore, "keystore", flagKeystore, "encrypted keystore file of node, instead of --secret-seed")
+	tlsCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase of --keystore; the passphrase is asked without it")
 
 	rootCmd.AddCommand(tlsCmd)
 }
@@ -35,7 +40,25 @@ func init() {
 func generate() {
 	var err error
 
-	network.NewKeyGenerator(flagTLSOutputPath, flagTLSCertFile, flagTLSKeyFile)
+	var kp *keypair.Full
+	if len(flagKeystore) > 0 {
+		if len(flagKPSecretSeed) > 0 {
+			common.PrintFlagsError(tlsCmd, "--keystore", errors.New("--secret-seed and --keystore can not be given together"))
+		}
+		if kp, err = common.LoadKeystore(flagKeystore, flagPassphraseFile); err != nil {
+			common.PrintFlagsError(tlsCmd, "--keystore", err)
+		}
+	} else if len(flagKPSecretSeed) > 0 {
+		if parsedKP, err := keypair.Parse(flagKPSecretSeed); err != nil {
+			common.PrintFlagsError(tlsCmd, "--secret-seed", err)
+		} else if full, ok := parsedKP.(*keypair.Full); !ok {
+			common.PrintFlagsError(tlsCmd, "--secret-seed", errors.New("secret seed is needed, not public address"))
+		} else {
+			kp = full
+		}
+	}
+
+	network.NewKeyGeneratorWithKeypair(flagTLSOutputPath, flagTLSCertFile, flagTLSKeyFile, kp)
 
 	if _, err = os.Stat(flagTLSOutputPath); os.IsNotExist(err) {
 		common.PrintFlagsError(tlsCmd, "output", err)
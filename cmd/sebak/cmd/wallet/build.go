@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/keypair"
+
+	cmdcommon "boscoin.io/sebak/cmd/sebak/common"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+var (
+	BuildCmd *cobra.Command
+
+	flagOperations cmdcommon.ListFlags
+	flagFee        string
+	flagBaseFee    string = common.GetENVValue("SEBAK_BASE_FEE", common.BaseFee.String())
+	flagMinHeight  uint64
+	flagMaxHeight  uint64
+	flagOutput     string
+)
+
+func init() {
+	BuildCmd = &cobra.Command{
+		Use:   "build <source address> <sequence id>",
+		Short: "Build the unsigned transaction, which can be signed offline by 'wallet sign'",
+		Long: `Build the unsigned transaction, which can be signed offline by 'wallet sign'.
+
+The operations are given by --operation '<type>:<JSON body>', for example
+
+  --operation 'payment:{"target":"GDIRF4UW...","amount":"100000000"}'
+  --operation 'create-account:{"target":"GDIRF4UW...","amount":"100000000","linked":""}'
+
+The network is not accessed, so <sequence id> should be the 'sequence_id' of
+the source account. The fee is --base-fee by operation unless --fee is given;
+--base-fee should be the 'base-fee' of the node info, if the network does not
+use the default one.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(c *cobra.Command, args []string) {
+			var err error
+			var source keypair.KP
+			var sequenceID uint64
+			var fee common.Amount
+			var memo *transaction.Memo
+			var ops []operation.Operation
+
+			if source, err = keypair.Parse(args[0]); err != nil {
+				cmdcommon.PrintFlagsError(c, "<source address>", err)
+			} else if _, err = source.Sign([]byte("witness")); err == nil {
+				cmdcommon.PrintFlagsError(c, "<source address>", fmt.Errorf("Provided key is a secret seed, not an address"))
+			}
+
+			if sequenceID, err = strconv.ParseUint(args[1], 10, 64); err != nil {
+				cmdcommon.PrintFlagsError(c, "<sequence id>", err)
+			}
+
+			if len(flagOperations) < 1 {
+				cmdcommon.PrintFlagsError(c, "--operation", fmt.Errorf("at least one --operation needs to be provided"))
+			}
+			for _, value := range flagOperations {
+				var op operation.Operation
+				if op, err = parseOperation(value); err != nil {
+					cmdcommon.PrintFlagsError(c, "--operation", err)
+				}
+				ops = append(ops, op)
+			}
+
+			if len(flagFee) > 0 {
+				if fee, err = cmdcommon.ParseAmountFromString(flagFee); err != nil {
+					cmdcommon.PrintFlagsError(c, "--fee", err)
+				}
+			} else {
+				var baseFee common.Amount
+				if baseFee, err = cmdcommon.ParseAmountFromString(flagBaseFee); err != nil {
+					cmdcommon.PrintFlagsError(c, "--base-fee", err)
+				}
+				fee = baseFee.MustMult(len(ops))
+			}
+
+			if len(flagMemo) > 0 {
+				if memo, err = transaction.NewMemo(transaction.MemoType(flagMemoType), flagMemo); err != nil {
+					cmdcommon.PrintFlagsError(c, "--memo", err)
+				}
+			}
+
+			tx := buildTransaction(source.Address(), sequenceID, fee, ops)
+			tx.B.MinHeight = flagMinHeight
+			tx.B.MaxHeight = flagMaxHeight
+			tx.B.Memo = memo
+			tx.H.Hash = tx.B.MakeHashString()
+
+			if err = writeTransaction(flagOutput, tx); err != nil {
+				cmdcommon.PrintError(c, err)
+			}
+		},
+	}
+	BuildCmd.Flags().Var(&flagOperations, "operation", "operation of the transaction, '<type>:<JSON body>'; can be given multiple times")
+	BuildCmd.Flags().StringVar(&flagFee, "fee", flagFee, "fee of the transaction in GON (default: --base-fee * number of operations)")
+	BuildCmd.Flags().StringVar(&flagBaseFee, "base-fee", flagBaseFee, "base fee of the network in GON")
+	BuildCmd.Flags().Uint64Var(&flagMinHeight, "min-height", flagMinHeight, "minimum block height, which can include the transaction")
+	BuildCmd.Flags().Uint64Var(&flagMaxHeight, "max-height", flagMaxHeight, "maximum block height, which can include the transaction")
+	BuildCmd.Flags().StringVar(&flagMemo, "memo", flagMemo, "memo of the transaction, like the customer reference")
+	BuildCmd.Flags().StringVar(&flagMemoType, "memo-type", flagMemoType, "type of --memo: text, id or hash")
+	BuildCmd.Flags().StringVar(&flagOutput, "output", flagOutput, "file to write the transaction (default: stdout)")
+}
+
+// parseOperation parses '<type>:<JSON body>'. The network parameters are not
+// known offline, so only the format of operation is checked.
+func parseOperation(value string) (op operation.Operation, err error) {
+	i := strings.Index(value, ":")
+	if i < 1 {
+		err = fmt.Errorf("operation should be '<type>:<JSON body>'")
+		return
+	}
+
+	t := operation.OperationType(strings.TrimSpace(value[:i]))
+
+	var body operation.Body
+	if body, err = operation.UnmarshalBodyJSON(t, []byte(value[i+1:])); err != nil {
+		err = fmt.Errorf("invalid body of operation, %q: %v", t, err)
+		return
+	}
+	if err = body.IsWellFormed(nil, common.Config{}); err != nil {
+		err = fmt.Errorf("invalid body of operation, %q: %v", t, err)
+		return
+	}
+
+	op = operation.Operation{
+		H: operation.Header{Type: t},
+		B: body,
+	}
+
+	return
+}
+
+// buildTransaction makes the transaction without signature.
+func buildTransaction(source string, sequenceID uint64, fee common.Amount, ops []operation.Operation) transaction.Transaction {
+	txBody := transaction.Body{
+		Source:     source,
+		Fee:        fee,
+		SequenceID: sequenceID,
+		Operations: ops,
+	}
+
+	return transaction.Transaction{
+		H: transaction.Header{
+			Created: common.NowISO8601(),
+			Hash:    txBody.MakeHashString(),
+		},
+		B: txBody,
+	}
+}
@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"github.com/stellar/go/keypair"
+
+	cmdcommon "boscoin.io/sebak/cmd/sebak/common"
+)
+
+// parseSender returns the keypair from the optional secret seed argument or
+// from --keystore.
+func parseSender(args []string) (keypair.KP, error) {
+	var seed string
+	if len(args) > 0 {
+		seed = args[0]
+	}
+
+	kp, err := cmdcommon.ParseSecretSeed(seed, flagKeystore, flagPassphraseFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return kp, nil
+}
+
+// senderFlagName is the name of flag for the error of `parseSender()`.
+func senderFlagName(arg string) string {
+	if len(flagKeystore) > 0 {
+		return "--keystore"
+	}
+
+	return arg
+}
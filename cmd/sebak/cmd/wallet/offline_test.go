@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+var networkID = []byte("sebak-test-network")
+
+func TestParseOperation(t *testing.T) {
+	target, _ := keypair.Random()
+
+	op, err := parseOperation(fmt.Sprintf(`payment:{"target":"%s","amount":"100"}`, target.Address()))
+	require.NoError(t, err)
+	require.Equal(t, operation.TypePayment, op.H.Type)
+	require.Equal(t, operation.NewPayment(target.Address(), common.Amount(100)), op.B)
+
+	_, err = parseOperation(fmt.Sprintf(`{"target":"%s","amount":"100"}`, target.Address()))
+	require.Error(t, err)
+
+	_, err = parseOperation(fmt.Sprintf(`unknown:{"target":"%s","amount":"100"}`, target.Address()))
+	require.Error(t, err)
+
+	// not well-formed
+	_, err = parseOperation(`payment:{"target":"wrong address","amount":"100"}`)
+	require.Error(t, err)
+}
+
+func TestBuildAndSignTransaction(t *testing.T) {
+	source, _ := keypair.Random()
+	target, _ := keypair.Random()
+	signer, _ := keypair.Random()
+
+	op, err := parseOperation(fmt.Sprintf(`payment:{"target":"%s","amount":"100"}`, target.Address()))
+	require.NoError(t, err)
+
+	tx := buildTransaction(source.Address(), 3, common.BaseFee, []operation.Operation{op})
+	require.Empty(t, tx.Signers())
+
+	dir, err := ioutil.TempDir("", "sebak-wallet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// unsigned transaction is moved to the offline machine
+	path := filepath.Join(dir, "tx.json")
+	require.NoError(t, writeTransaction(path, tx))
+	unsigned, err := readTransaction(path)
+	require.NoError(t, err)
+	require.Equal(t, tx.H.Hash, unsigned.H.Hash)
+
+	// the operations are shown before signing
+	var out bytes.Buffer
+	printOperations(&out, unsigned)
+	require.Contains(t, out.String(), fmt.Sprintf("type=payment target=%s amount=100", target.Address()))
+
+	require.NoError(t, signTransaction(&unsigned, source, networkID))
+	require.Equal(t, []string{source.Address()}, unsigned.Signers())
+	require.NoError(t, unsigned.IsWellFormed(networkID, common.NewConfig()))
+
+	// the other signer adds the signature
+	require.NoError(t, signTransaction(&unsigned, signer, networkID))
+	require.Equal(t, []string{source.Address(), signer.Address()}, unsigned.Signers())
+
+	// signed by the different network id
+	require.Error(t, signTransaction(&unsigned, signer, []byte("another-network")))
+}
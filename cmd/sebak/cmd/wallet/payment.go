@@ -17,25 +17,34 @@ import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 var (
-	flagNetworkID     string = common.GetENVValue("SEBAK_NETWORK_ID", "")
-	PaymentCmd        *cobra.Command
-	flagEndpoint      string
-	flagCreateAccount bool
-	flagDry           bool
-	flagFreeze        bool
-	flagVerbose       bool
+	flagNetworkID      string = common.GetENVValue("SEBAK_NETWORK_ID", "")
+	PaymentCmd         *cobra.Command
+	flagEndpoint       string
+	flagCreateAccount  bool
+	flagDry            bool
+	flagFreeze         bool
+	flagVerbose        bool
+	flagMemo           string
+	flagMemoType       string = string(transaction.MemoTypeText)
+	flagKeystore       string
+	flagPassphraseFile string
 )
 
 func init() {
 	PaymentCmd = &cobra.Command{
-		Use:   "payment <receiver pubkey> <amount> <sender secret seed>",
+		Use:   "payment <receiver pubkey> <amount> [<sender secret seed>]",
 		Short: "Send <amount> BOSCoin from one wallet to another",
-		Args:  cobra.ExactArgs(3),
+		Long: `Send <amount> BOSCoin from one wallet to another.
+
+<sender secret seed> can be omitted with --keystore; the sender is loaded from
+the encrypted keystore file created by 'key import'.`,
+		Args: cobra.RangeArgs(2, 3),
 		Run: func(c *cobra.Command, args []string) {
 			var err error
 			var amount common.Amount
@@ -43,6 +52,8 @@ func init() {
 			var sender keypair.KP
 			var receiver keypair.KP
 			var endpoint *common.Endpoint
+			var baseFee common.Amount
+			var memo *transaction.Memo
 
 			// Receiver's public key
 			if receiver, err = keypair.Parse(args[0]); err != nil {
@@ -61,10 +72,8 @@ func init() {
 			}
 
 			// Sender's secret seed
-			if sender, err = keypair.Parse(args[2]); err != nil {
-				cmdcommon.PrintFlagsError(c, "<sender secret seed>", err)
-			} else if _, ok := sender.(*keypair.Full); !ok {
-				cmdcommon.PrintFlagsError(c, "<sender secret seed>", fmt.Errorf("Provided key is an address, not a secret seed"))
+			if sender, err = parseSender(args[2:]); err != nil {
+				cmdcommon.PrintFlagsError(c, senderFlagName("<sender secret seed>"), err)
 			}
 
 			// Check a network ID was provided
@@ -76,6 +85,12 @@ func init() {
 				cmdcommon.PrintFlagsError(c, "--endpoint", err)
 			}
 
+			if len(flagMemo) > 0 {
+				if memo, err = transaction.NewMemo(transaction.MemoType(flagMemoType), flagMemo); err != nil {
+					cmdcommon.PrintFlagsError(c, "--memo", err)
+				}
+			}
+
 			// TODO: Validate input transaction (does the sender have enough money?)
 
 			// At the moment this is a rather crude implementation: There is no support for pooling of transaction,
@@ -96,6 +111,11 @@ func init() {
 				os.Exit(1)
 			}
 
+			if baseFee, err = getBaseFee(client); err != nil {
+				log.Fatal("Could not fetch base fee of network: ", err)
+				os.Exit(1)
+			}
+
 			if flagVerbose == true {
 				fmt.Println("Account before transaction: ", senderAccount)
 			}
@@ -104,25 +124,26 @@ func init() {
 			{
 				newBalance, err = senderAccount.GetBalance().Sub(amount)
 				if err == nil {
-					newBalance, err = newBalance.Sub(common.BaseFee)
+					newBalance, err = newBalance.Sub(baseFee)
 				}
 
 				if err != nil {
 					fmt.Printf("Attempting to draft %v GON (+ %v fees), but sender account only have %v GON\n",
-						amount, common.BaseFee, senderAccount.GetBalance())
+						amount, baseFee, senderAccount.GetBalance())
 					os.Exit(1)
 				}
 			}
 
 			// TODO: Validate that the account doesn't already exists
 			if flagFreeze {
-				tx = makeTransactionCreateAccount(sender, receiver, amount, senderAccount.SequenceID, sender.Address())
+				tx = makeTransactionCreateAccount(sender, receiver, amount, senderAccount.SequenceID, sender.Address(), baseFee)
 			} else if flagCreateAccount {
-				tx = makeTransactionCreateAccount(sender, receiver, amount, senderAccount.SequenceID, "")
+				tx = makeTransactionCreateAccount(sender, receiver, amount, senderAccount.SequenceID, "", baseFee)
 			} else {
-				tx = makeTransactionPayment(sender, receiver, amount, senderAccount.SequenceID)
+				tx = makeTransactionPayment(sender, receiver, amount, senderAccount.SequenceID, baseFee)
 			}
 
+			tx.B.Memo = memo
 			tx.Sign(sender, []byte(flagNetworkID))
 
 			// Send request
@@ -131,7 +152,7 @@ func init() {
 				fmt.Println(tx)
 			}
 			if flagDry == false {
-				if retbody, err = client.SendMessage(tx); err != nil {
+				if retbody, err = client.SendTransaction(tx); err != nil {
 					log.Fatal("Network error: ", err, " body: ", string(retbody))
 					os.Exit(1)
 				}
@@ -152,6 +173,10 @@ func init() {
 	PaymentCmd.Flags().BoolVar(&flagFreeze, "freeze", flagFreeze, "When present, the payment is a frozen account creation. Imply --create.")
 	PaymentCmd.Flags().BoolVar(&flagDry, "dry-run", flagDry, "Print the transaction instead of sending it")
 	PaymentCmd.Flags().BoolVar(&flagVerbose, "verbose", flagVerbose, "Print extra data (transaction sent, before/after balance...)")
+	PaymentCmd.Flags().StringVar(&flagMemo, "memo", flagMemo, "memo of the transaction, like the customer reference")
+	PaymentCmd.Flags().StringVar(&flagMemoType, "memo-type", flagMemoType, "type of --memo: text, id or hash")
+	PaymentCmd.Flags().StringVar(&flagKeystore, "keystore", flagKeystore, "encrypted keystore file of sender, instead of <sender secret seed>")
+	PaymentCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase of --keystore; the passphrase is asked without it")
 }
 
 ///
@@ -166,11 +191,12 @@ func init() {
 ///   amount   = Amount to send as initial value
 ///   seqid    = SequenceID of the last transaction
 ///   target   = Address of the linked account, if we're creating a frozen account
+///   fee      = Base fee of the network
 ///
 /// Returns:
 ///   `sebak.Transaction` = The generated `Transaction` creating the account
 ///
-func makeTransactionCreateAccount(kpSource keypair.KP, kpDest keypair.KP, amount common.Amount, seqid uint64, target string) transaction.Transaction {
+func makeTransactionCreateAccount(kpSource keypair.KP, kpDest keypair.KP, amount common.Amount, seqid uint64, target string, fee common.Amount) transaction.Transaction {
 	opb := operation.NewCreateAccount(kpDest.Address(), amount, target)
 
 	op := operation.Operation{
@@ -182,7 +208,7 @@ func makeTransactionCreateAccount(kpSource keypair.KP, kpDest keypair.KP, amount
 
 	txBody := transaction.Body{
 		Source:     kpSource.Address(),
-		Fee:        common.BaseFee,
+		Fee:        fee,
 		SequenceID: seqid,
 		Operations: []operation.Operation{op},
 	}
@@ -209,11 +235,12 @@ func makeTransactionCreateAccount(kpSource keypair.KP, kpDest keypair.KP, amount
 ///   kpDest   = Receiver's keypair.FromAddress address
 ///   amount   = Amount to send as initial value
 ///   seqid    = SequenceID of the last transaction
+///   fee      = Base fee of the network
 ///
 /// Returns:
 ///  `sebak.Transaction` = The generated `Transaction` to do a payment
 ///
-func makeTransactionPayment(kpSource keypair.KP, kpDest keypair.KP, amount common.Amount, seqid uint64) transaction.Transaction {
+func makeTransactionPayment(kpSource keypair.KP, kpDest keypair.KP, amount common.Amount, seqid uint64, fee common.Amount) transaction.Transaction {
 	opb := operation.NewPayment(kpDest.Address(), amount)
 
 	op := operation.Operation{
@@ -225,7 +252,7 @@ func makeTransactionPayment(kpSource keypair.KP, kpDest keypair.KP, amount commo
 
 	txBody := transaction.Body{
 		Source:     kpSource.Address(),
-		Fee:        common.Amount(common.BaseFee),
+		Fee:        fee,
 		SequenceID: seqid,
 		Operations: []operation.Operation{op},
 	}
@@ -268,3 +295,20 @@ func getSenderDetails(conn *network.HTTP2NetworkClient, sender keypair.KP) (bloc
 	err = json.Unmarshal(retBody, &ba)
 	return ba, err
 }
+
+// getBaseFee returns the base fee of network from the node info of the node;
+// the base fee of network can be different from `common.BaseFee`.
+func getBaseFee(conn *network.HTTP2NetworkClient) (fee common.Amount, err error) {
+	var retBody []byte
+	if retBody, err = conn.Get("/"); err != nil {
+		return
+	}
+
+	var info node.NodeInfo
+	if info, err = node.NewNodeInfoFromJSON(retBody); err != nil {
+		return
+	}
+
+	fee = info.Policy.BaseFee
+	return
+}
@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+)
+
+func TestGetBaseFee(t *testing.T) {
+	info := node.NodeInfo{}
+	info.Policy.BaseFee = common.BaseFee.MustMult(3)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(info)
+	}))
+	defer ts.Close()
+
+	endpoint, err := common.ParseEndpoint(ts.URL)
+	require.NoError(t, err)
+	connection, err := common.NewHTTP2Client(0, 0, false)
+	require.NoError(t, err)
+
+	fee, err := getBaseFee(network.NewHTTP2NetworkClient(endpoint, connection))
+	require.NoError(t, err)
+	require.Equal(t, info.Policy.BaseFee, fee)
+}
@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/keypair"
+
+	cmdcommon "boscoin.io/sebak/cmd/sebak/common"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+var (
+	SignCmd *cobra.Command
+)
+
+func init() {
+	SignCmd = &cobra.Command{
+		Use:   "sign <transaction file> [<signer secret seed>]",
+		Short: "Sign the transaction built by 'wallet build' without network access",
+		Long: `Sign the transaction built by 'wallet build' without network access.
+
+<transaction file> can be "-" for reading stdin. If the signer is not the
+source of transaction, the signature is added as one of the signers of source
+account. <signer secret seed> can be omitted with --keystore.`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(c *cobra.Command, args []string) {
+			var err error
+			var tx transaction.Transaction
+			var signer keypair.KP
+
+			if tx, err = readTransaction(args[0]); err != nil {
+				cmdcommon.PrintFlagsError(c, "<transaction file>", err)
+			}
+
+			if signer, err = parseSender(args[1:]); err != nil {
+				cmdcommon.PrintFlagsError(c, senderFlagName("<signer secret seed>"), err)
+			}
+
+			if len(flagNetworkID) == 0 {
+				cmdcommon.PrintFlagsError(c, "--network-id", fmt.Errorf("A --network-id needs to be provided"))
+			}
+
+			// the signer should see what will be signed
+			printOperations(os.Stderr, tx)
+
+			if err = signTransaction(&tx, signer, []byte(flagNetworkID)); err != nil {
+				cmdcommon.PrintError(c, err)
+			}
+
+			fmt.Fprintf(
+				os.Stderr,
+				"signed transaction: hash=%s source=%s sequence_id=%d fee=%v operations=%d\n",
+				tx.H.Hash, tx.B.Source, tx.B.SequenceID, tx.B.Fee, len(tx.B.Operations),
+			)
+
+			if err = writeTransaction(flagOutput, tx); err != nil {
+				cmdcommon.PrintError(c, err)
+			}
+		},
+	}
+	SignCmd.Flags().StringVar(&flagNetworkID, "network-id", flagNetworkID, "network id")
+	SignCmd.Flags().StringVar(&flagOutput, "output", flagOutput, "file to write the signed transaction (default: stdout)")
+	SignCmd.Flags().StringVar(&flagKeystore, "keystore", flagKeystore, "encrypted keystore file of signer, instead of <signer secret seed>")
+	SignCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase of --keystore; the passphrase is asked without it")
+}
+
+// printOperations writes the type, target and amount of every operation of
+// `tx`; the operation without target shows the body instead.
+func printOperations(w io.Writer, tx transaction.Transaction) {
+	fmt.Fprintf(w, "transaction: source=%s sequence_id=%d fee=%v\n", tx.B.Source, tx.B.SequenceID, tx.B.Fee)
+	for i, op := range tx.B.Operations {
+		if pop, ok := op.B.(operation.Payable); ok {
+			fmt.Fprintf(w, "operation %d: type=%s target=%s amount=%v\n", i, op.H.Type, pop.TargetAddress(), pop.GetAmount())
+		} else {
+			body, _ := common.EncodeJSONValue(op.B)
+			fmt.Fprintf(w, "operation %d: type=%s body=%s\n", i, op.H.Type, body)
+		}
+	}
+}
+
+// signTransaction signs `tx` by `signer`; the source signs the transaction
+// itself and the other signers add their signatures. The signatures, which
+// `tx` already has, must be valid for `networkID`.
+func signTransaction(tx *transaction.Transaction, signer keypair.KP, networkID []byte) error {
+	if len(tx.B.Operations) < 1 {
+		return fmt.Errorf("transaction has no operations")
+	}
+
+	if signer.Address() == tx.B.Source {
+		tx.Sign(signer, networkID)
+	} else {
+		tx.AddSignature(signer, networkID)
+	}
+
+	checker := &transaction.Checker{
+		NetworkID:   networkID,
+		Transaction: *tx,
+	}
+
+	return transaction.CheckVerifySignature(checker)
+}
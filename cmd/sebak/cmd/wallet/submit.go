@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cmdcommon "boscoin.io/sebak/cmd/sebak/common"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+var (
+	SubmitCmd *cobra.Command
+)
+
+func init() {
+	SubmitCmd = &cobra.Command{
+		Use:   "submit <signed transaction file>",
+		Short: "Send the transaction signed by 'wallet sign' to the node",
+		Long: `Send the transaction signed by 'wallet sign' to the node.
+
+<signed transaction file> can be "-" for reading stdin.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			var err error
+			var tx transaction.Transaction
+			var endpoint *common.Endpoint
+
+			if tx, err = readTransaction(args[0]); err != nil {
+				cmdcommon.PrintFlagsError(c, "<signed transaction file>", err)
+			}
+			if len(tx.Signers()) < 1 {
+				cmdcommon.PrintFlagsError(c, "<signed transaction file>", fmt.Errorf("transaction is not signed"))
+			}
+
+			if endpoint, err = common.ParseEndpoint(flagEndpoint); err != nil {
+				cmdcommon.PrintFlagsError(c, "--endpoint", err)
+			}
+
+			var connection *common.HTTP2Client
+
+			// Keep-alive ignores timeout/idle timeout
+			if connection, err = common.NewHTTP2Client(0, 0, true); err != nil {
+				log.Fatal("Error while creating network client: ", err)
+				os.Exit(1)
+			}
+			client := network.NewHTTP2NetworkClient(endpoint, connection)
+
+			var retbody []byte
+			if retbody, err = client.SendTransaction(tx); err != nil {
+				log.Fatal("Network error: ", err, " body: ", string(retbody))
+				os.Exit(1)
+			}
+
+			fmt.Println("transaction submitted:", tx.H.Hash)
+			if flagVerbose == true {
+				fmt.Println(string(retbody))
+			}
+		},
+	}
+	SubmitCmd.Flags().StringVar(&flagEndpoint, "endpoint", flagEndpoint, "endpoint to send the transaction to (https / memory address)")
+	SubmitCmd.Flags().BoolVar(&flagVerbose, "verbose", flagVerbose, "Print the response of node")
+}
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"boscoin.io/sebak/lib/transaction"
+)
+
+// readTransaction reads the transaction JSON from `path`; "-" means stdin.
+func readTransaction(path string) (tx transaction.Transaction, err error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		var f *os.File
+		if f, err = os.Open(path); err != nil {
+			return
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var b []byte
+	if b, err = ioutil.ReadAll(r); err != nil {
+		return
+	}
+
+	err = json.Unmarshal(b, &tx)
+	return
+}
+
+// writeTransaction writes the transaction JSON to `path`; an empty `path`
+// means stdout.
+func writeTransaction(path string, tx transaction.Transaction) error {
+	b := []byte(tx.String() + "\n")
+	if len(path) < 1 {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0600)
+}
@@ -25,20 +25,23 @@ var (
 
 func init() {
 	UnfreezeRequestCmd = &cobra.Command{
-		Use:   "unfreezeRequest <sender secret seed>",
+		Use:   "unfreezeRequest [<sender secret seed>]",
 		Short: "Request unfreezing for the frozen account",
-		Args:  cobra.ExactArgs(1),
+		Long: `Request unfreezing for the frozen account.
+
+<sender secret seed> can be omitted with --keystore; the sender is loaded from
+the encrypted keystore file created by 'key import'.`,
+		Args: cobra.RangeArgs(0, 1),
 		Run: func(c *cobra.Command, args []string) {
 			var err error
 			var frozenAccountBalance common.Amount
 			var sender keypair.KP
 			var endpoint *common.Endpoint
+			var baseFee common.Amount
 
 			// Sender's secret seed
-			if sender, err = keypair.Parse(args[0]); err != nil {
-				cmdcommon.PrintFlagsError(c, "<sender secret seed>", err)
-			} else if _, ok := sender.(*keypair.Full); !ok {
-				cmdcommon.PrintFlagsError(c, "<sender secret seed>", fmt.Errorf("Provided key is an address, not a secret seed"))
+			if sender, err = parseSender(args); err != nil {
+				cmdcommon.PrintFlagsError(c, senderFlagName("<sender secret seed>"), err)
 			}
 
 			// Check a network ID was provided
@@ -66,6 +69,11 @@ func init() {
 				os.Exit(1)
 			}
 
+			if baseFee, err = getBaseFee(client); err != nil {
+				log.Fatal("Could not fetch base fee of network: ", err)
+				os.Exit(1)
+			}
+
 			if senderAccount.Linked == "" {
 				fmt.Printf("Account is not frozen account")
 				os.Exit(1)
@@ -83,7 +91,7 @@ func init() {
 				}
 			}
 
-			tx = makeTransactionUnfreezingRequest(sender, senderAccount.SequenceID)
+			tx = makeTransactionUnfreezingRequest(sender, senderAccount.SequenceID, baseFee)
 
 			tx.Sign(sender, []byte(flagNetworkID))
 
@@ -93,7 +101,7 @@ func init() {
 				fmt.Println(tx)
 			}
 			if flagDry == false {
-				if retbody, err = client.SendMessage(tx); err != nil {
+				if retbody, err = client.SendTransaction(tx); err != nil {
 					log.Fatal("Network error: ", err, " body: ", string(retbody))
 					os.Exit(1)
 				}
@@ -104,6 +112,8 @@ func init() {
 	UnfreezeRequestCmd.Flags().StringVar(&flagNetworkID, "network-id", flagNetworkID, "network id")
 	UnfreezeRequestCmd.Flags().BoolVar(&flagDry, "dry-run", flagDry, "Print the transaction instead of sending it")
 	UnfreezeRequestCmd.Flags().BoolVar(&flagVerbose, "verbose", flagVerbose, "Print extra data (transaction sent, before/after balance...)")
+	UnfreezeRequestCmd.Flags().StringVar(&flagKeystore, "keystore", flagKeystore, "encrypted keystore file of sender, instead of <sender secret seed>")
+	UnfreezeRequestCmd.Flags().StringVar(&flagPassphraseFile, "passphrase-file", flagPassphraseFile, "file of the passphrase of --keystore; the passphrase is asked without it")
 }
 
 //
@@ -114,11 +124,12 @@ func init() {
 ///   kpDest   = Receiver's keypair.FromAddress address
 ///   amount   = Amount to send as initial value
 ///   seqid    = SequenceID of the last transaction
+///   fee      = Base fee of the network
 ///
 /// Returns:
 ///  `sebak.Transaction` = The generated `Transaction` to do a unfreezing request
 ///
-func makeTransactionUnfreezingRequest(kpSource keypair.KP, seqid uint64) transaction.Transaction {
+func makeTransactionUnfreezingRequest(kpSource keypair.KP, seqid uint64, fee common.Amount) transaction.Transaction {
 	opb := operation.NewUnfreezeRequest()
 
 	op := operation.Operation{
@@ -130,7 +141,7 @@ func makeTransactionUnfreezingRequest(kpSource keypair.KP, seqid uint64) transac
 
 	txBody := transaction.Body{
 		Source:     kpSource.Address(),
-		Fee:        common.Amount(common.BaseFee),
+		Fee:        fee,
 		SequenceID: seqid,
 		Operations: []operation.Operation{op},
 	}
@@ -0,0 +1,126 @@
+package common
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/stellar/go/keypair"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"boscoin.io/sebak/lib/keystore"
+)
+
+// ReadSecret reads the secret, like secret seed, from the terminal without
+// echo; if stdin is not terminal, the first line of stdin is read.
+func ReadSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && len(line) < 1 {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// ReadPassphrase reads the passphrase from the first line of `file`; without
+// `file`, the passphrase is asked on the terminal and, if `confirm`, it is
+// asked once more.
+func ReadPassphrase(file string, confirm bool) ([]byte, error) {
+	if len(file) > 0 {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		passphrase := strings.TrimRight(strings.SplitN(string(b), "\n", 2)[0], "\r")
+		if len(passphrase) < 1 {
+			return nil, errors.New("empty passphrase")
+		}
+		return []byte(passphrase), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil, errors.New("passphrase can not be asked without terminal; use --passphrase-file")
+	}
+
+	fmt.Fprint(os.Stderr, "Passphrase: ")
+	passphrase, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		repeated, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		if string(passphrase) != string(repeated) {
+			return nil, errors.New("passphrases do not match")
+		}
+	}
+
+	if len(passphrase) < 1 {
+		return nil, errors.New("empty passphrase")
+	}
+
+	return passphrase, nil
+}
+
+// LoadKeystore decrypts the keystore file of `path`; see `ReadPassphrase()`
+// for `passphraseFile`.
+func LoadKeystore(path, passphraseFile string) (*keypair.Full, error) {
+	k, err := keystore.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := ReadPassphrase(passphraseFile, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.Decrypt(passphrase)
+}
+
+// ParseSecretSeed returns the keypair from `seed` or, if `seed` is empty,
+// from the keystore file of `keystorePath`; only one of them can be given.
+func ParseSecretSeed(seed, keystorePath, passphraseFile string) (*keypair.Full, error) {
+	if len(seed) > 0 && len(keystorePath) > 0 {
+		return nil, errors.New("secret seed and --keystore can not be given together")
+	}
+
+	if len(keystorePath) > 0 {
+		return LoadKeystore(keystorePath, passphraseFile)
+	}
+	if len(seed) < 1 {
+		return nil, errors.New("secret seed or --keystore must be given")
+	}
+
+	kp, err := keypair.Parse(seed)
+	if err != nil {
+		return nil, err
+	}
+	full, ok := kp.(*keypair.Full)
+	if !ok {
+		return nil, errors.New("Provided key is an address, not a secret seed")
+	}
+
+	return full, nil
+}
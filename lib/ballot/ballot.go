@@ -97,8 +97,8 @@ func (b Ballot) isBallotWellFormed(networkID []byte, conf common.Config) (err er
 		return
 	}
 	now := time.Now()
-	timeStart := now.Add(time.Duration(-1) * common.BallotConfirmedTimeAllowDuration)
-	timeEnd := now.Add(common.BallotConfirmedTimeAllowDuration)
+	timeStart := now.Add(time.Duration(-1) * conf.NetworkParams.BallotConfirmedTimeAllowDuration)
+	timeEnd := now.Add(conf.NetworkParams.BallotConfirmedTimeAllowDuration)
 	if confirmed.Before(timeStart) || confirmed.After(timeEnd) {
 		err = errors.MessageHasIncorrectTime
 		return
@@ -118,8 +118,8 @@ func (b Ballot) isProposerInfoWellFormed(networkID []byte, conf common.Config) (
 	}
 
 	now := time.Now()
-	timeStart := now.Add(time.Duration(-1) * common.BallotConfirmedTimeAllowDuration)
-	timeEnd := now.Add(common.BallotConfirmedTimeAllowDuration)
+	timeStart := now.Add(time.Duration(-1) * conf.NetworkParams.BallotConfirmedTimeAllowDuration)
+	timeEnd := now.Add(conf.NetworkParams.BallotConfirmedTimeAllowDuration)
 
 	if proposerConfirmed.Before(timeStart) || proposerConfirmed.After(timeEnd) {
 		err = errors.MessageHasIncorrectTime
@@ -165,6 +165,12 @@ func (b Ballot) ProposerConfirmed() string {
 	return b.B.Proposed.Confirmed
 }
 
+// ProposedHash is the hash of the proposal, which is signed by proposer; the
+// ballots for the same proposal have the same hash.
+func (b Ballot) ProposedHash() string {
+	return common.MustMakeObjectHashString(b.B.Proposed)
+}
+
 func (b Ballot) Vote() voting.Hole {
 	return b.B.Vote
 }
@@ -256,6 +262,17 @@ func (b *Ballot) SetProposerTransaction(ptx ProposerTransaction) {
 	b.B.Proposed.ProposerTransaction = ptx
 }
 
+func (b Ballot) StateRoot() string {
+	return b.B.Proposed.StateRoot
+}
+
+// SetStateRoot sets the state root, which is expected by proposer after
+// `Transactions` and `ProposerTransaction` are applied; it must be set
+// before `Ballot.Sign()`.
+func (b *Ballot) SetStateRoot(root string) {
+	b.B.Proposed.StateRoot = root
+}
+
 type BallotHeader struct {
 	Hash              string `json:"hash"`               // hash of `BallotBody`
 	Signature         string `json:"signature"`          // signed by source node of <networkID> + `Hash`
@@ -268,6 +285,7 @@ type BallotBodyProposed struct {
 	VotingBasis         voting.Basis        `json:"voting_basis"`
 	Transactions        []string            `json:"transactions"`
 	ProposerTransaction ProposerTransaction `json:"proposer_transaction"`
+	StateRoot           string              `json:"state_root"` // state root after applying the proposed transactions
 }
 
 type BallotBody struct {
@@ -36,7 +36,7 @@ func TestErrorBallotHasOverMaxTransactionsInBallot(t *testing.T) {
 		blt := NewBallot(node.Address(), node.Address(), basis, []string{tx.GetHash()})
 
 		opc, _ := NewCollectTxFeeFromBallot(*blt, commonKP.Address(), tx)
-		opi, _ := NewInflationFromBallot(*blt, commonKP.Address(), common.Amount(1))
+		opi, _ := NewInflationFromBallot(*blt, commonKP.Address(), common.Amount(1), common.InflationRatio)
 		ptx, _ := NewProposerTransactionFromBallot(*blt, opc, opi)
 		blt.SetProposerTransaction(ptx)
 
@@ -56,7 +56,7 @@ func TestErrorBallotHasOverMaxTransactionsInBallot(t *testing.T) {
 		blt := NewBallot(node.Address(), node.Address(), basis, txHashes)
 
 		opc, _ := NewCollectTxFeeFromBallot(*blt, commonKP.Address(), tx)
-		opi, _ := NewInflationFromBallot(*blt, commonKP.Address(), common.Amount(1))
+		opi, _ := NewInflationFromBallot(*blt, commonKP.Address(), common.Amount(1), common.InflationRatio)
 		ptx, _ := NewProposerTransactionFromBallot(*blt, opc, opi)
 		blt.SetProposerTransaction(ptx)
 
@@ -86,7 +86,7 @@ func TestBallotBadConfirmedTime(t *testing.T) {
 		ballot := NewBallot(node.Address(), node.Address(), basis, []string{})
 
 		opc, _ := NewCollectTxFeeFromBallot(*ballot, commonKP.Address())
-		opi, _ := NewInflationFromBallot(*ballot, commonKP.Address(), common.Amount(1))
+		opi, _ := NewInflationFromBallot(*ballot, commonKP.Address(), common.Amount(1), common.InflationRatio)
 		ptx, _ := NewProposerTransactionFromBallot(*ballot, opc, opi)
 
 		ballot.SetProposerTransaction(ptx)
@@ -236,7 +236,7 @@ func TestIsBallotWellFormed(t *testing.T) {
 	commonKP, _ := keypair.Random()
 	commonAccount := block.NewBlockAccount(commonKP.Address(), 0)
 
-	opi, _ := NewInflationFromBallot(*wellBallot, commonAccount.Address, initialBalance)
+	opi, _ := NewInflationFromBallot(*wellBallot, commonAccount.Address, initialBalance, common.InflationRatio)
 	opc, _ := NewCollectTxFeeFromBallot(*wellBallot, commonAccount.Address, tx)
 	ptx, _ := NewProposerTransactionFromBallot(*wellBallot, opc, opi)
 	wellBallot.SetProposerTransaction(ptx)
@@ -309,7 +309,7 @@ func TestIsExpiredBallotWithProposerTransactionWellFormed(t *testing.T) {
 	commonKP, _ := keypair.Random()
 	commonAccount := block.NewBlockAccount(commonKP.Address(), 0)
 
-	opi, _ := NewInflationFromBallot(*b, commonAccount.Address, initialBalance)
+	opi, _ := NewInflationFromBallot(*b, commonAccount.Address, initialBalance, common.InflationRatio)
 	opc, _ := NewCollectTxFeeFromBallot(*b, commonAccount.Address, tx)
 	ptx, _ := NewProposerTransactionFromBallot(*b, opc, opi)
 	b.SetProposerTransaction(ptx)
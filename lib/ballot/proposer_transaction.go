@@ -20,12 +20,10 @@ type ProposerTransaction struct {
 
 func NewProposerTransaction(proposer string, ops ...operation.Operation) (ptx ProposerTransaction, err error) {
 	var tx transaction.Transaction
-	tx, err = transaction.NewTransaction(proposer, 0, ops...)
+	tx, err = transaction.NewTransaction(proposer, 0, 0, ops...)
 	if err != nil {
 		return
 	}
-	tx.B.Fee = 0
-	tx.H.Hash = tx.B.MakeHashString()
 
 	ptx = ProposerTransaction{Transaction: tx}
 
@@ -51,11 +49,11 @@ func NewCollectTxFeeFromBallot(blt Ballot, commonAccount string, txs ...transact
 	return
 }
 
-func NewInflationFromBallot(blt Ballot, commonAccount string, initialBalance common.Amount) (opb operation.Inflation, err error) {
+func NewInflationFromBallot(blt Ballot, commonAccount string, initialBalance common.Amount, ratio float64) (opb operation.Inflation, err error) {
 	rd := blt.VotingBasis()
 
 	var amount common.Amount
-	if amount, err = common.CalculateInflation(initialBalance); err != nil {
+	if amount, err = common.CalculateInflation(initialBalance, ratio); err != nil {
 		return
 	}
 
@@ -63,6 +61,7 @@ func NewInflationFromBallot(blt Ballot, commonAccount string, initialBalance com
 		commonAccount,
 		amount,
 		initialBalance,
+		ratio,
 		rd.Height,
 		rd.BlockHash,
 		rd.TotalTxs,
@@ -7,6 +7,7 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/observer"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 // BlockAccount is account model in block. the storage should support,
@@ -28,6 +29,10 @@ type BlockAccount struct {
 	Linked   string      `json:"linked"`
 	CodeHash []byte      `json:"code_hash"`
 	RootHash common.Hash `json:"root_hash"`
+	// Signers and Thresholds are set by `operation.SetSigners`; without
+	// `Signers`, only the account itself can sign.
+	Signers    []operation.Signer   `json:"signers,omitempty"`
+	Thresholds operation.Thresholds `json:"thresholds"`
 }
 
 func NewBlockAccount(address string, balance common.Amount) *BlockAccount {
@@ -62,6 +67,11 @@ func (b *BlockAccount) Save(st *storage.LevelDBBackend) (err error) {
 		err = st.New(key, b)
 		createdKey := GetBlockAccountCreatedKey(common.GetUniqueIDFromUUID())
 		err = st.New(createdKey, b.Address)
+
+		// `Linked` is set only when the account is created
+		if err == nil && b.Linked != "" {
+			err = st.New(GetBlockAccountLinkedKey(b.Linked, b.Address), b.Address)
+		}
 	}
 	if err == nil {
 		event := "saved"
@@ -95,6 +105,14 @@ func GetBlockAccountCreatedKey(created string) string {
 	return fmt.Sprintf("%s%s", common.BlockAccountPrefixCreated, created)
 }
 
+func GetBlockAccountLinkedKey(linked, address string) string {
+	return fmt.Sprintf("%s%s", GetBlockAccountLinkedKeyPrefix(linked), address)
+}
+
+func GetBlockAccountLinkedKeyPrefix(linked string) string {
+	return fmt.Sprintf("%s%s-", common.BlockAccountPrefixLinked, linked)
+}
+
 func ExistsBlockAccount(st *storage.LevelDBBackend, address string) (exists bool, err error) {
 	return st.Has(GetBlockAccountKey(address))
 }
@@ -124,6 +142,25 @@ func GetBlockAccountAddressesByCreated(st *storage.LevelDBBackend, options stora
 		})
 }
 
+// GetBlockAccountAddressesByLinked returns the addresses of the accounts,
+// which are linked to `linked`, like the frozen accounts.
+func GetBlockAccountAddressesByLinked(st *storage.LevelDBBackend, linked string, options storage.ListOptions) (func() (string, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockAccountLinkedKeyPrefix(linked), options)
+
+	return (func() (string, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return "", false, []byte{}
+			}
+
+			var address string
+			json.Unmarshal(item.Value, &address)
+			return address, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
 func GetBlockAccountsByCreated(st *storage.LevelDBBackend, options storage.ListOptions) (func() (*BlockAccount, bool, []byte), func()) {
 	iterFunc, closeFunc := GetBlockAccountAddressesByCreated(st, options)
 
@@ -146,6 +183,38 @@ func GetBlockAccountsByCreated(st *storage.LevelDBBackend, options storage.ListO
 		})
 }
 
+// HasSigners returns true when the account is controlled by the signer set.
+func (b *BlockAccount) HasSigners() bool {
+	return len(b.Signers) > 0
+}
+
+// SignerWeight returns the sum of weights of the given signers; the unknown
+// and duplicated signers are ignored.
+func (b *BlockAccount) SignerWeight(signers ...string) (weight int) {
+	counted := map[string]bool{}
+	for _, address := range signers {
+		if _, found := counted[address]; found {
+			continue
+		}
+		counted[address] = true
+
+		for _, signer := range b.Signers {
+			if signer.Address == address {
+				weight += int(signer.Weight)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// SetSigners replaces the signer set and thresholds.
+func (b *BlockAccount) SetSigners(signers []operation.Signer, thresholds operation.Thresholds) {
+	b.Signers = signers
+	b.Thresholds = thresholds
+}
+
 func (b *BlockAccount) GetBalance() common.Amount {
 	return b.Balance
 }
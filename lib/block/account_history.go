@@ -0,0 +1,96 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// BlockAccountHistory is the snapshot of `BlockAccount` at the block height,
+// which is saved only when the account is changed by the block. the storage
+// should support,
+//  * find by `Address` and `Height`:
+//  * find the latest one at or below the given height
+//
+// models
+//  * 'address' and 'height'
+// 	- 'bah-<BlockAccountHistory.Address>-<BlockAccountHistory.Height>': `BlockAccountHistory`
+type BlockAccountHistory struct {
+	BlockAccount
+	Height uint64 `json:"height"`
+}
+
+func NewBlockAccountHistory(ba BlockAccount, height uint64) BlockAccountHistory {
+	return BlockAccountHistory{
+		BlockAccount: ba,
+		Height:       height,
+	}
+}
+
+func GetBlockAccountHistoryKey(address string, height uint64) string {
+	return fmt.Sprintf("%s%020d", GetBlockAccountHistoryKeyPrefix(address), height)
+}
+
+func GetBlockAccountHistoryKeyPrefix(address string) string {
+	return fmt.Sprintf("%s%s-", common.BlockAccountHistoryPrefix, address)
+}
+
+func (b *BlockAccountHistory) String() string {
+	return string(common.MustJSONMarshal(b))
+}
+
+// Serialize overrides the promoted `BlockAccount.Serialize` to keep `Height`.
+func (b *BlockAccountHistory) Serialize() (encoded []byte, err error) {
+	encoded, err = common.EncodeJSONValue(b)
+	return
+}
+
+func (b *BlockAccountHistory) Deserialize(encoded []byte) (err error) {
+	return common.DecodeJSONValue(encoded, b)
+}
+
+func (b *BlockAccountHistory) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockAccountHistoryKey(b.Address, b.Height)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+
+	return
+}
+
+// GetBlockAccountHistory returns the account state as of the block `height`;
+// it is the latest history at or below `height`.
+func GetBlockAccountHistory(st *storage.LevelDBBackend, address string, height uint64) (b BlockAccountHistory, err error) {
+	options := storage.NewDefaultListOptions(true, nil, 0)
+	iterFunc, closeFunc := st.GetIterator(GetBlockAccountHistoryKeyPrefix(address), options)
+	defer closeFunc()
+
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var bah BlockAccountHistory
+		if err = common.DecodeJSONValue(item.Value, &bah); err != nil {
+			return
+		}
+		if bah.Height <= height {
+			b = bah
+			return
+		}
+	}
+
+	err = errors.BlockAccountDoesNotExists
+	return
+}
@@ -0,0 +1,59 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestBlockAccountHistory(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	ba := TestMakeBlockAccount()
+
+	// the account is changed at height 3, 5 and 10
+	for _, height := range []uint64{3, 5, 10} {
+		ba.Balance = common.Amount(height * 100)
+		ba.SequenceID = height
+		bah := NewBlockAccountHistory(*ba, height)
+		require.NoError(t, bah.Save(st))
+	}
+
+	// the other account must not be found
+	other := TestMakeBlockAccount()
+	require.NoError(t, (&BlockAccountHistory{BlockAccount: *other, Height: 4}).Save(st))
+
+	cases := map[uint64]uint64{3: 3, 4: 3, 5: 5, 9: 5, 10: 10, 100: 10}
+	for height, expected := range cases {
+		bah, err := GetBlockAccountHistory(st, ba.Address, height)
+		require.NoError(t, err)
+		require.Equal(t, expected, bah.Height)
+		require.Equal(t, ba.Address, bah.Address)
+		require.Equal(t, common.Amount(expected*100), bah.Balance)
+		require.Equal(t, expected, bah.SequenceID)
+	}
+
+	// before created
+	_, err := GetBlockAccountHistory(st, ba.Address, 2)
+	require.Equal(t, errors.BlockAccountDoesNotExists, err)
+}
+
+func TestBlockAccountHistoryGenesis(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	for _, kp := range []string{GenesisKP.Address(), CommonKP.Address()} {
+		ba, err := GetBlockAccount(st, kp)
+		require.NoError(t, err)
+
+		bah, err := GetBlockAccountHistory(st, kp, common.GenesisBlockHeight)
+		require.NoError(t, err)
+		require.Equal(t, ba.Balance, bah.Balance)
+		require.Equal(t, ba.SequenceID, bah.SequenceID)
+	}
+}
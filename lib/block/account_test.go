@@ -41,6 +41,38 @@ func TestSaveExistingBlockAccount(t *testing.T) {
 	require.Equal(t, b.GetBalance(), fetched.GetBalance())
 }
 
+func TestBlockAccountLinked(t *testing.T) {
+	st := storage.NewTestStorage()
+
+	linked := TestMakeBlockAccount()
+	linked.MustSave(st)
+
+	var expected []string
+	for i := 0; i < 3; i++ {
+		b := TestMakeBlockAccount()
+		b.Linked = linked.Address
+		b.MustSave(st)
+		expected = append(expected, b.Address)
+
+		// saving again does not make the duplicated index
+		b.MustSave(st)
+	}
+	TestMakeBlockAccount().MustSave(st) // not linked
+
+	var addresses []string
+	iterFunc, closeFunc := GetBlockAccountAddressesByLinked(st, linked.Address, storage.NewDefaultListOptions(false, nil, 0))
+	for {
+		address, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		addresses = append(addresses, address)
+	}
+	closeFunc()
+
+	require.ElementsMatch(t, expected, addresses)
+}
+
 func TestSortMultipleBlockAccount(t *testing.T) {
 	st := storage.NewTestStorage()
 
@@ -40,10 +40,11 @@ func (bck Block) String() string {
 }
 
 // NewBlock creates new block; `ptx` represents the
-// `ProposerTransaction.GetHash()`.
-func NewBlock(proposer string, basis voting.Basis, ptx string, transactions []string, confirmed string) *Block {
+// `ProposerTransaction.GetHash()` and `stateRoot` is the root of account state
+// after the transactions are applied.
+func NewBlock(proposer string, basis voting.Basis, ptx string, transactions []string, stateRoot string, confirmed string) *Block {
 	b := &Block{
-		Header:              *NewBlockHeader(basis, getTransactionRoot(transactions)),
+		Header:              *NewBlockHeader(basis, getTransactionRoot(transactions, ptx), stateRoot),
 		Transactions:        transactions,
 		ProposerTransaction: ptx,
 		Proposer:            proposer,
@@ -55,8 +56,58 @@ func NewBlock(proposer string, basis voting.Basis, ptx string, transactions []st
 	return b
 }
 
-func getTransactionRoot(txs []string) string {
-	return common.MustMakeObjectHashString(txs) // TODO make root
+// getTransactionRoot makes the merkle root of transaction hashes; the
+// proposer transaction is the last one.
+func getTransactionRoot(txs []string, ptx string) string {
+	return common.MakeMerkleRoot(transactionLeaves(txs, ptx))
+}
+
+func transactionLeaves(txs []string, ptx string) []string {
+	if len(ptx) < 1 {
+		return txs
+	}
+
+	return append(append([]string{}, txs...), ptx)
+}
+
+// IsWellFormed checks the hash and the transactions root of block; the block
+// can be checked without the transactions, so the chain of blocks can be
+// verified before the transactions are downloaded.
+func (b Block) IsWellFormed() error {
+	if b.TransactionsRoot != getTransactionRoot(b.Transactions, b.ProposerTransaction) {
+		return errors.HashDoesNotMatch
+	}
+
+	// `Hash` is made from the block without hash; see `NewBlock()`
+	blk := b
+	blk.Hash = ""
+	if b.Hash != base58.Encode(common.MustMakeObjectHash(blk)) {
+		return errors.HashDoesNotMatch
+	}
+
+	return nil
+}
+
+// TransactionProof makes the merkle proof of transaction or proposer
+// transaction, which can be verified with `Header.TransactionsRoot`.
+func (b Block) TransactionProof(hash string) (proof common.MerkleProof, err error) {
+	leaves := transactionLeaves(b.Transactions, b.ProposerTransaction)
+
+	index := -1
+	for i, h := range leaves {
+		if h == hash {
+			index = i
+			break
+		}
+	}
+
+	var ok bool
+	if proof, ok = common.MakeMerkleProof(leaves, index); !ok {
+		err = errors.TransactionNotFound
+		return
+	}
+
+	return
 }
 
 func getBlockKey(hash string) string {
@@ -199,6 +250,16 @@ func GetBlockHeadersByConfirmed(st *storage.LevelDBBackend, options storage.List
 	return LoadBlockHeadersInsideIterator(st, iterFunc, closeFunc)
 }
 
+// GetBlocksByHeight returns the blocks by the order of height.
+func GetBlocksByHeight(st *storage.LevelDBBackend, options storage.ListOptions) (
+	func() (Block, bool, []byte),
+	func(),
+) {
+	iterFunc, closeFunc := st.GetIterator(common.BlockPrefixHeight, options)
+
+	return LoadBlocksInsideIterator(st, iterFunc, closeFunc)
+}
+
 func GetBlockByHeight(st *storage.LevelDBBackend, height uint64) (bt Block, err error) {
 	var hash string
 	if err = st.Get(getBlockKeyPrefixHeight(height), &hash); err != nil {
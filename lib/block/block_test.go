@@ -8,7 +8,9 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
 	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/stellar/go/keypair"
 	"github.com/stretchr/testify/require"
 )
@@ -125,6 +127,19 @@ func TestMakeGenesisBlock(t *testing.T) {
 	require.Equal(t, "", bk.Proposer)
 	require.Equal(t, common.GenesisBlockConfirmedTime, bk.Confirmed)
 
+	// state root has genesis account and common account
+	{
+		tr := trie.NewTrie(bk.StateRootHash(), trie.NewEthDatabase(st))
+		for _, account := range []*BlockAccount{genesisAccount, commonAccount} {
+			encoded, err := tr.TryGet([]byte(account.Address))
+			require.NoError(t, err)
+
+			var ba BlockAccount
+			require.NoError(t, ba.Deserialize(encoded))
+			require.Equal(t, account.Balance, ba.Balance)
+		}
+	}
+
 	// transaction
 	{
 		exists, err := ExistsBlockTransaction(st, bk.Transactions[0])
@@ -282,3 +297,44 @@ func TestMakeGenesisBlockFindCommonAccount(t *testing.T) {
 		require.Equal(t, commonAccount.SequenceID, ac.SequenceID)
 	}
 }
+
+func TestBlockIsWellFormed(t *testing.T) {
+	blk := TestMakeNewBlock([]string{"tx0", "tx1"})
+	require.NoError(t, blk.IsWellFormed())
+
+	{ // modified header
+		modified := blk
+		modified.StateRoot = "modified"
+		require.Equal(t, errors.HashDoesNotMatch, modified.IsWellFormed())
+	}
+
+	{ // transactions are not matched with the transactions root
+		modified := blk
+		modified.Transactions = []string{"tx0"}
+		require.Equal(t, errors.HashDoesNotMatch, modified.IsWellFormed())
+	}
+}
+
+func TestBlockTransactionProof(t *testing.T) {
+	txs := []string{"tx0", "tx1", "tx2"}
+	blk := *NewBlock("proposer", voting.Basis{Height: 2}, "ptx", txs, "", common.NowISO8601())
+	require.NoError(t, blk.IsWellFormed())
+
+	// the proposer transaction is the last leaf of the transactions root
+	for i, hash := range append(txs, "ptx") {
+		proof, err := blk.TransactionProof(hash)
+		require.NoError(t, err)
+		require.Equal(t, i, proof.Index)
+		require.Equal(t, len(txs)+1, proof.Total)
+		require.True(t, common.VerifyMerkleProof(blk.TransactionsRoot, hash, proof))
+	}
+
+	_, err := blk.TransactionProof("unknown")
+	require.Equal(t, errors.TransactionNotFound, err)
+
+	{ // proposer transaction is not matched with the transactions root
+		modified := blk
+		modified.ProposerTransaction = "modified"
+		require.Equal(t, errors.HashDoesNotMatch, modified.IsWellFormed())
+	}
+}
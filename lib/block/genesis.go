@@ -8,11 +8,13 @@ package block
 import (
 	"fmt"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stellar/go/keypair"
 
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
@@ -102,6 +104,11 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 	kp := keypair.Master(string(networkID))
 	tx.Sign(kp, []byte(networdID))
 
+	var stateRoot string
+	if stateRoot, err = makeGenesisStateRoot(st, genesisAccount, commonAccount); err != nil {
+		return
+	}
+
 	blk = NewBlock(
 		"",
 		voting.Basis{
@@ -111,12 +118,20 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 		},
 		"",
 		[]string{tx.GetHash()},
+		stateRoot,
 		common.GenesisBlockConfirmedTime,
 	)
 	if err = blk.Save(st); err != nil {
 		return
 	}
 
+	for _, account := range []BlockAccount{genesisAccount, commonAccount} {
+		bah := NewBlockAccountHistory(account, blk.Height)
+		if err = bah.Save(st); err != nil {
+			return
+		}
+	}
+
 	bt := NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.Confirmed, tx)
 	if err = bt.Save(st); err != nil {
 		return
@@ -128,3 +143,37 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 
 	return
 }
+
+// makeGenesisStateRoot stores the genesis account and common account into the
+// empty state trie and returns it's root. The state trie of the next blocks is
+// managed by `statedb.StateDB`, which uses the same layout; the key is the
+// address and the value is the serialized `BlockAccount`.
+func makeGenesisStateRoot(st *storage.LevelDBBackend, accounts ...BlockAccount) (root string, err error) {
+	return updateStateRoot(st, common.Hash{}, accounts...)
+}
+
+// updateStateRoot stores the accounts into the state trie of `parent` and
+// returns the new root.
+func updateStateRoot(st *storage.LevelDBBackend, parent common.Hash, accounts ...BlockAccount) (root string, err error) {
+	tr := trie.NewTrie(parent, trie.NewEthDatabase(st))
+	for _, account := range accounts {
+		var encoded []byte
+		if encoded, err = account.Serialize(); err != nil {
+			return
+		}
+		if err = tr.TryUpdate([]byte(account.Address), encoded); err != nil {
+			return
+		}
+	}
+
+	var hash common.Hash
+	if hash, err = tr.Commit(nil); err != nil {
+		return
+	}
+	if err = tr.CommitDB(hash); err != nil {
+		return
+	}
+
+	root = base58.Encode(hash[:])
+	return
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -16,11 +19,12 @@ type Header struct {
 	Height           uint64    `json:"height"`
 	TotalTxs         uint64    `json:"total-txs"`
 	TotalOps         uint64    `json:"total-ops"`
+	StateRoot        string    `json:"state_root"` // Root of account state trie after this block
 
 	// TODO smart contract fields
 }
 
-func NewBlockHeader(basis voting.Basis, txRoot string) *Header {
+func NewBlockHeader(basis voting.Basis, txRoot, stateRoot string) *Header {
 	return &Header{
 		PrevBlockHash:    basis.BlockHash,
 		Timestamp:        time.Now(),
@@ -28,9 +32,15 @@ func NewBlockHeader(basis voting.Basis, txRoot string) *Header {
 		TotalTxs:         basis.TotalTxs,
 		TotalOps:         basis.TotalOps,
 		TransactionsRoot: txRoot,
+		StateRoot:        stateRoot,
 	}
 }
 
+// StateRootHash returns `StateRoot` as the root hash of the state trie.
+func (h Header) StateRootHash() common.Hash {
+	return common.BytesToHash(base58.Decode(h.StateRoot))
+}
+
 func (h Header) Serialize() (encoded []byte, err error) {
 	encoded, err = json.Marshal(h)
 	return
@@ -0,0 +1,411 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
+)
+
+// SnapshotChunkSize is the maximum number of accounts in one `SnapshotChunk`.
+const SnapshotChunkSize int = 1000
+
+// Snapshot is the account state at the block of `SnapshotBody.Block`, which
+// is signed by the validator of `SnapshotBody.Source`; the new node can load
+// the snapshot instead of replaying all the blocks from genesis. The accounts
+// are split into `SnapshotChunk`s to be downloaded one by one, and
+// `SnapshotBody.Chunks` has the hashes of them in order.
+//
+// Besides the accounts, the snapshot has the validator set changes and the
+// operations of the frozen accounts, which are needed to check the unfreezing.
+//
+// models
+//  * 'height'
+// 	- 'ss-<Block.Height>': `Snapshot`
+//  * 'height and chunk index'
+// 	- 'ssc-<Block.Height>-<SnapshotChunk.Index>': `SnapshotChunk`
+type Snapshot struct {
+	H SnapshotHeader `json:"H"`
+	B SnapshotBody   `json:"B"`
+}
+
+type SnapshotHeader struct {
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+type SnapshotBody struct {
+	Source              string               `json:"source"`
+	Block               Block                `json:"block"`
+	TotalAccounts       uint64               `json:"total_accounts"`
+	Chunks              []string             `json:"chunks"`
+	ValidatorSetChanges []ValidatorSetChange `json:"validator_set_changes"`
+	Created             string               `json:"created"`
+}
+
+// SnapshotChunk has the part of accounts of `Snapshot` in the order of
+// address and the operations of the frozen accounts in it.
+type SnapshotChunk struct {
+	Height     uint64           `json:"height"`
+	Index      uint64           `json:"index"`
+	Accounts   []BlockAccount   `json:"accounts"`
+	Operations []BlockOperation `json:"operations"`
+}
+
+func GetSnapshotKey(height uint64) string {
+	return fmt.Sprintf("%s%020d", common.SnapshotPrefix, height)
+}
+
+func GetSnapshotChunkKeyPrefix(height uint64) string {
+	return fmt.Sprintf("%s%020d-", common.SnapshotChunkPrefix, height)
+}
+
+func GetSnapshotChunkKey(height, index uint64) string {
+	return fmt.Sprintf("%s%020d", GetSnapshotChunkKeyPrefix(height), index)
+}
+
+func (sb SnapshotBody) MakeHashString() string {
+	return base58.Encode(common.MustMakeObjectHash(sb))
+}
+
+// MakeStateHashString makes the hash of the body without the source and the
+// created time; the snapshots of the same block by the different validators
+// have the same state hash, so the chunks and the validator set changes can
+// be compared with the other validators.
+func (sb SnapshotBody) MakeStateHashString() string {
+	sb.Source = ""
+	sb.Created = ""
+	return base58.Encode(common.MustMakeObjectHash(sb))
+}
+
+func (s Snapshot) Height() uint64 {
+	return s.B.Block.Height
+}
+
+func (s Snapshot) String() string {
+	return string(common.MustJSONMarshal(s))
+}
+
+func (s *Snapshot) Sign(kp keypair.KP, networkID []byte) {
+	s.B.Source = kp.Address()
+	s.B.Created = common.NowISO8601()
+	s.H.Hash = s.B.MakeHashString()
+	signature, _ := common.MakeSignature(kp, networkID, s.H.Hash)
+	s.H.Signature = base58.Encode(signature)
+}
+
+// IsWellFormed checks the hash and signature of snapshot and the hash of the
+// block in it; the source should be checked by the caller.
+func (s Snapshot) IsWellFormed(networkID []byte) (err error) {
+	if s.H.Hash != s.B.MakeHashString() {
+		return errors.HashDoesNotMatch
+	}
+
+	var kp keypair.KP
+	if kp, err = keypair.Parse(s.B.Source); err != nil {
+		return
+	}
+	if err = kp.Verify(append(networkID, []byte(s.H.Hash)...), base58.Decode(s.H.Signature)); err != nil {
+		return errors.SignatureVerificationFailed
+	}
+
+	if err = s.B.Block.IsWellFormed(); err != nil {
+		return
+	}
+
+	if len(s.B.Chunks) < 1 {
+		return errors.InvalidSnapshot
+	}
+
+	return
+}
+
+func (s Snapshot) Save(st *storage.LevelDBBackend) (err error) {
+	return st.New(GetSnapshotKey(s.Height()), s)
+}
+
+func (c SnapshotChunk) MakeHashString() string {
+	return base58.Encode(common.MustMakeObjectHash(c))
+}
+
+func (c SnapshotChunk) Save(st *storage.LevelDBBackend) (err error) {
+	return st.New(GetSnapshotChunkKey(c.Height, c.Index), c)
+}
+
+// MakeSnapshot stores the chunks of the account state at `blk` and returns the
+// snapshot of them; the snapshot should be signed and stored by the caller.
+// The state is read from the state trie of `blk`, so the blocks after `blk`
+// can be stored at the same time.
+func MakeSnapshot(st *storage.LevelDBBackend, blk Block, chunkSize int) (s Snapshot, err error) {
+	s.B.Block = blk
+
+	chunk := SnapshotChunk{Height: blk.Height}
+	saveChunk := func() error {
+		if err := chunk.Save(st); err != nil {
+			return err
+		}
+		s.B.Chunks = append(s.B.Chunks, chunk.MakeHashString())
+		chunk = SnapshotChunk{Height: blk.Height, Index: chunk.Index + 1}
+		return nil
+	}
+
+	it := trie.NewTrie(blk.StateRootHash(), trie.NewEthDatabase(st)).NewIterator()
+	for it.Next() {
+		var ba BlockAccount
+		if err = ba.Deserialize(it.Value); err != nil {
+			return
+		}
+		chunk.Accounts = append(chunk.Accounts, ba)
+		s.B.TotalAccounts++
+
+		if ba.Linked != "" {
+			var ops []BlockOperation
+			if ops, err = getBlockOperationsUntil(st, ba.Address, blk.Height); err != nil {
+				return
+			}
+			chunk.Operations = append(chunk.Operations, ops...)
+		}
+
+		if len(chunk.Accounts) >= chunkSize {
+			if err = saveChunk(); err != nil {
+				return
+			}
+		}
+	}
+	if err = it.Err; err != nil {
+		return
+	}
+	if len(chunk.Accounts) > 0 {
+		if err = saveChunk(); err != nil {
+			return
+		}
+	}
+
+	var changes []ValidatorSetChange
+	if changes, err = GetValidatorSetChanges(st, 0, ^uint64(0)); err != nil {
+		return
+	}
+	for _, vsc := range changes {
+		if vsc.Height <= blk.Height {
+			s.B.ValidatorSetChanges = append(s.B.ValidatorSetChanges, vsc)
+		}
+	}
+
+	return
+}
+
+// getBlockOperationsUntil returns the operations of source, which are stored
+// until the block of `height`.
+func getBlockOperationsUntil(st *storage.LevelDBBackend, source string, height uint64) (ops []BlockOperation, err error) {
+	iterFunc, closeFunc := GetBlockOperationsBySource(st, source, nil)
+	defer closeFunc()
+
+	for {
+		bo, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		if bo.Height > height {
+			continue
+		}
+		ops = append(ops, bo)
+	}
+
+	return
+}
+
+func GetSnapshot(st *storage.LevelDBBackend, height uint64) (s Snapshot, err error) {
+	err = st.Get(GetSnapshotKey(height), &s)
+	return
+}
+
+func GetSnapshotChunk(st *storage.LevelDBBackend, height, index uint64) (c SnapshotChunk, err error) {
+	err = st.Get(GetSnapshotChunkKey(height, index), &c)
+	return
+}
+
+func GetSnapshots(st *storage.LevelDBBackend, options storage.ListOptions) (
+	func() (Snapshot, bool, []byte),
+	func(),
+) {
+	iterFunc, closeFunc := st.GetIterator(common.SnapshotPrefix, options)
+
+	return (func() (Snapshot, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return Snapshot{}, false, []byte{}
+			}
+
+			var s Snapshot
+			if err := common.DecodeJSONValue(item.Value, &s); err != nil {
+				return Snapshot{}, false, []byte{}
+			}
+
+			return s, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+func GetLatestSnapshot(st *storage.LevelDBBackend) (s Snapshot, err error) {
+	iterFunc, closeFunc := GetSnapshots(st, storage.NewDefaultListOptions(true, nil, 1))
+	s, hasNext, _ := iterFunc()
+	closeFunc()
+
+	if !hasNext {
+		err = errors.StorageRecordDoesNotExist
+	}
+
+	return
+}
+
+// DeleteSnapshot removes the snapshot and it's chunks.
+func DeleteSnapshot(st *storage.LevelDBBackend, height uint64) (err error) {
+	var s Snapshot
+	if s, err = GetSnapshot(st, height); err != nil {
+		return
+	}
+
+	if err = st.Remove(GetSnapshotKey(height)); err != nil {
+		return
+	}
+	for i := range s.B.Chunks {
+		if err = st.Remove(GetSnapshotChunkKey(height, uint64(i))); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// PruneSnapshots removes the snapshots except the latest `keep` snapshots.
+func PruneSnapshots(st *storage.LevelDBBackend, keep int) (err error) {
+	var heights []uint64
+	iterFunc, closeFunc := GetSnapshots(st, storage.NewDefaultListOptions(true, nil, 0))
+	for {
+		s, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		heights = append(heights, s.Height())
+	}
+	closeFunc()
+
+	if len(heights) <= keep {
+		return
+	}
+	for _, height := range heights[keep:] {
+		if err = DeleteSnapshot(st, height); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// SnapshotLoader stores the chunks of snapshot in order and rebuilds the state
+// trie from the accounts of them. After all the chunks are added, `Finish()`
+// checks the root of the rebuilt trie with the state root of the snapshot
+// block; `st` is expected to be the batch, which is discarded when the
+// snapshot does not match.
+type SnapshotLoader struct {
+	st       *storage.LevelDBBackend
+	snapshot Snapshot
+	trie     *trie.Trie
+	next     uint64
+	accounts uint64
+}
+
+func NewSnapshotLoader(st *storage.LevelDBBackend, s Snapshot) *SnapshotLoader {
+	return &SnapshotLoader{
+		st:       st,
+		snapshot: s,
+		trie:     trie.NewTrie(common.Hash{}, trie.NewEthDatabase(st)),
+	}
+}
+
+// Next returns the index of the chunk, which should be added next.
+func (l *SnapshotLoader) Next() uint64 {
+	return l.next
+}
+
+// IsFull checks all the chunks of snapshot are added.
+func (l *SnapshotLoader) IsFull() bool {
+	return l.next >= uint64(len(l.snapshot.B.Chunks))
+}
+
+func (l *SnapshotLoader) Add(c SnapshotChunk) (err error) {
+	if l.IsFull() || c.Height != l.snapshot.Height() || c.Index != l.next {
+		return errors.InvalidSnapshotChunk
+	}
+	if c.MakeHashString() != l.snapshot.B.Chunks[c.Index] {
+		return errors.InvalidSnapshotChunk
+	}
+
+	for _, ba := range c.Accounts {
+		var encoded []byte
+		if encoded, err = ba.Serialize(); err != nil {
+			return
+		}
+		if err = l.trie.TryUpdate([]byte(ba.Address), encoded); err != nil {
+			return
+		}
+
+		account := ba
+		if err = account.Save(l.st); err != nil {
+			return
+		}
+		bah := NewBlockAccountHistory(account, c.Height)
+		if err = bah.Save(l.st); err != nil {
+			return
+		}
+	}
+
+	for _, bo := range c.Operations {
+		if err = bo.Save(l.st); err != nil && err != errors.BlockAlreadyExists {
+			return
+		}
+	}
+
+	l.next++
+	l.accounts += uint64(len(c.Accounts))
+
+	return nil
+}
+
+// Finish stores the state trie, the validator set changes and the block of
+// snapshot.
+func (l *SnapshotLoader) Finish() (err error) {
+	if !l.IsFull() || l.accounts != l.snapshot.B.TotalAccounts {
+		return errors.InvalidSnapshot
+	}
+
+	blk := l.snapshot.B.Block
+
+	var hash common.Hash
+	if hash, err = l.trie.Commit(nil); err != nil {
+		return
+	}
+	if base58.Encode(hash[:]) != blk.StateRoot {
+		return errors.StateRootDoesNotMatch
+	}
+	if err = l.trie.CommitDB(hash); err != nil {
+		return
+	}
+
+	// the indices of transaction and operation are not kept in the snapshot,
+	// but the order of changes is kept by the position.
+	for i, vsc := range l.snapshot.B.ValidatorSetChanges {
+		vsc.opIndex = i
+		if err = vsc.Save(l.st); err != nil {
+			return
+		}
+	}
+
+	return blk.Save(l.st)
+}
@@ -0,0 +1,114 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestSnapshotMakeAndLoad(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	kp, _ := keypair.Random()
+	genesis := GetGenesis(st)
+
+	s, err := MakeSnapshot(st, genesis, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), s.B.TotalAccounts) // genesis account and common account
+	require.Equal(t, 2, len(s.B.Chunks))
+
+	s.Sign(kp, networkID)
+	require.NoError(t, s.IsWellFormed(networkID))
+	require.Equal(t, errors.SignatureVerificationFailed, s.IsWellFormed([]byte("another-network")))
+	require.NoError(t, s.Save(st))
+
+	latest, err := GetLatestSnapshot(st)
+	require.NoError(t, err)
+	require.Equal(t, s.H.Hash, latest.H.Hash)
+
+	var chunks []SnapshotChunk
+	for i := range s.B.Chunks {
+		c, err := GetSnapshotChunk(st, s.Height(), uint64(i))
+		require.NoError(t, err)
+		chunks = append(chunks, c)
+	}
+
+	{ // the chunks must be added in order
+		fresh := storage.NewTestStorage()
+		defer fresh.Close()
+
+		l := NewSnapshotLoader(fresh, s)
+		require.Equal(t, errors.InvalidSnapshotChunk, l.Add(chunks[1]))
+		require.NoError(t, l.Add(chunks[0]))
+		require.Equal(t, errors.InvalidSnapshot, l.Finish())
+	}
+
+	{ // modified chunk
+		fresh := storage.NewTestStorage()
+		defer fresh.Close()
+
+		modified := chunks[0]
+		modified.Accounts = []BlockAccount{chunks[0].Accounts[0]}
+		modified.Accounts[0].Balance++
+
+		l := NewSnapshotLoader(fresh, s)
+		require.Equal(t, errors.InvalidSnapshotChunk, l.Add(modified))
+	}
+
+	fresh := storage.NewTestStorage()
+	defer fresh.Close()
+
+	l := NewSnapshotLoader(fresh, s)
+	for _, c := range chunks {
+		require.NoError(t, l.Add(c))
+	}
+	require.True(t, l.IsFull())
+	require.NoError(t, l.Finish())
+
+	require.Equal(t, genesis.Hash, GetLatestBlock(fresh).Hash)
+	for _, c := range chunks {
+		for _, ba := range c.Accounts {
+			loaded, err := GetBlockAccount(fresh, ba.Address)
+			require.NoError(t, err)
+			require.Equal(t, ba.Balance, loaded.Balance)
+		}
+	}
+}
+
+func TestSnapshotPrune(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	kp, _ := keypair.Random()
+	genesis := GetGenesis(st)
+
+	for _, height := range []uint64{10, 20, 30} {
+		blk := genesis
+		blk.Height = height
+		s, err := MakeSnapshot(st, blk, SnapshotChunkSize)
+		require.NoError(t, err)
+		s.Sign(kp, networkID)
+		require.NoError(t, s.Save(st))
+	}
+
+	require.NoError(t, PruneSnapshots(st, 2))
+
+	_, err := GetSnapshot(st, 10)
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+	_, err = GetSnapshotChunk(st, 10, 0)
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+
+	for _, height := range []uint64{20, 30} {
+		_, err = GetSnapshot(st, height)
+		require.NoError(t, err)
+	}
+
+	latest, err := GetLatestSnapshot(st)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), latest.Height())
+}
@@ -113,6 +113,7 @@ func TestMakeNewBlock(transactions []string) Block {
 		},
 		"",
 		transactions,
+		"",
 		common.NowISO8601(),
 	)
 }
@@ -130,10 +131,45 @@ func TestMakeNewBlockWithPrevBlock(prevBlock Block, txs []string) Block {
 		},
 		"",
 		txs,
+		prevBlock.StateRoot,
 		common.NowISO8601(),
 	)
 }
 
+// TestMakeNewBlockWithAccounts saves the accounts into the storage and into
+// the state trie of `prevBlock`, and saves the new block with the new state
+// root; the transactions can use only the accounts in the state trie.
+func TestMakeNewBlockWithAccounts(st *storage.LevelDBBackend, prevBlock Block, accounts ...*BlockAccount) Block {
+	var stateAccounts []BlockAccount
+	for _, account := range accounts {
+		account.MustSave(st)
+		stateAccounts = append(stateAccounts, *account)
+	}
+
+	root, err := updateStateRoot(st, prevBlock.StateRootHash(), stateAccounts...)
+	if err != nil {
+		panic(err)
+	}
+
+	kp, _ := keypair.Random()
+	blk := *NewBlock(
+		kp.Address(),
+		voting.Basis{
+			Height:    prevBlock.Height + 1,
+			BlockHash: prevBlock.Hash,
+			TotalTxs:  prevBlock.TotalTxs,
+			TotalOps:  prevBlock.TotalOps,
+		},
+		"",
+		[]string{},
+		root,
+		common.NowISO8601(),
+	)
+	blk.MustSave(st)
+
+	return blk
+}
+
 func TestMakeNewBlockOperation(networkID []byte, n int) (bos []BlockOperation) {
 	_, tx := transaction.TestMakeTransaction(networkID, n)
 
@@ -27,12 +27,14 @@ type BlockTransaction struct {
 	Hash  string `json:"hash"`
 	Block string/* `Block.Hash` */ `json:"block"`
 
-	SequenceID uint64        `json:"sequence_id"`
-	Signature  string        `json:"signature"`
-	Source     string        `json:"source"`
-	Fee        common.Amount `json:"fee"`
-	Operations []string      `json:"operations"`
-	Amount     common.Amount `json:"amount"`
+	SequenceID uint64                  `json:"sequence_id"`
+	Signature  string                  `json:"signature"`
+	Signatures []transaction.Signature `json:"signatures,omitempty"`
+	Source     string                  `json:"source"`
+	Fee        common.Amount           `json:"fee"`
+	Operations []string                `json:"operations"`
+	Amount     common.Amount           `json:"amount"`
+	Memo       *transaction.Memo       `json:"memo,omitempty"`
 
 	Confirmed string `json:"confirmed"`
 	Created   string `json:"created"`
@@ -54,10 +56,12 @@ func NewBlockTransactionFromTransaction(blockHash string, blockHeight uint64, co
 		Block:      blockHash,
 		SequenceID: tx.B.SequenceID,
 		Signature:  tx.H.Signature,
+		Signatures: tx.H.Signatures,
 		Source:     tx.B.Source,
 		Fee:        tx.B.Fee,
 		Operations: opHashes,
 		Amount:     tx.TotalAmount(true),
+		Memo:       tx.B.Memo,
 
 		Confirmed: confirmed,
 		Created:   tx.H.Created,
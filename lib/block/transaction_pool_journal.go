@@ -0,0 +1,80 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+// TransactionPoolJournal keeps the pending transactions of
+// `transaction.Pool`, so they can be restored after the node restarts. Unlike
+// `TransactionPool`, the transaction is removed from the journal when it is
+// removed from `transaction.Pool`.
+//
+// models
+//  * 'hash'
+// 	- 'tpj-<Transaction.GetHash()>': `transaction.Transaction`
+type TransactionPoolJournal struct {
+	st *storage.LevelDBBackend
+}
+
+func NewTransactionPoolJournal(st *storage.LevelDBBackend) *TransactionPoolJournal {
+	return &TransactionPoolJournal{st: st}
+}
+
+func GetTransactionPoolJournalKey(hash string) string {
+	return fmt.Sprintf("%s%s", common.TransactionPoolJournalPrefix, hash)
+}
+
+func (j *TransactionPoolJournal) Add(tx transaction.Transaction) (err error) {
+	key := GetTransactionPoolJournalKey(tx.GetHash())
+
+	var exists bool
+	if exists, err = j.st.Has(key); err != nil || exists {
+		return
+	}
+
+	return j.st.New(key, tx)
+}
+
+func (j *TransactionPoolJournal) Remove(hashes ...string) (err error) {
+	for _, hash := range hashes {
+		key := GetTransactionPoolJournalKey(hash)
+
+		var exists bool
+		if exists, err = j.st.Has(key); err != nil {
+			return
+		} else if !exists {
+			continue
+		}
+
+		if err = j.st.Remove(key); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Transactions returns the all the transactions in the journal.
+func (j *TransactionPoolJournal) Transactions() (txs []transaction.Transaction, err error) {
+	iterFunc, closeFunc := j.st.GetIterator(common.TransactionPoolJournalPrefix, nil)
+	defer closeFunc()
+
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var tx transaction.Transaction
+		if err = common.DecodeJSONValue(item.Value, &tx); err != nil {
+			return
+		}
+		txs = append(txs, tx)
+	}
+
+	return
+}
@@ -0,0 +1,42 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+func TestTransactionPoolJournal(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	journal := NewTransactionPoolJournal(st)
+
+	_, tx0 := transaction.TestMakeTransaction(networkID, 1)
+	_, tx1 := transaction.TestMakeTransaction(networkID, 1)
+
+	require.NoError(t, journal.Add(tx0))
+	require.NoError(t, journal.Add(tx1))
+	require.NoError(t, journal.Add(tx0)) // already added
+
+	txs, err := journal.Transactions()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(txs))
+
+	hashes := map[string]bool{}
+	for _, tx := range txs {
+		hashes[tx.GetHash()] = true
+	}
+	require.True(t, hashes[tx0.GetHash()])
+	require.True(t, hashes[tx1.GetHash()])
+
+	require.NoError(t, journal.Remove(tx0.GetHash(), "unknown-hash"))
+
+	txs, err = journal.Transactions()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(txs))
+	require.Equal(t, tx1.GetHash(), txs[0].GetHash())
+}
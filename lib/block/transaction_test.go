@@ -34,6 +34,23 @@ func TestNewBlockTransaction(t *testing.T) {
 	require.Equal(t, bt.Amount, tx.TotalAmount(true))
 }
 
+func TestNewBlockTransactionWithMemo(t *testing.T) {
+	kp, tx := transaction.TestMakeTransaction(networkID, 1)
+	tx.B.Memo, _ = transaction.NewMemo(transaction.MemoTypeID, "1234")
+	tx.Sign(kp, networkID)
+
+	block := TestMakeNewBlock([]string{tx.GetHash()})
+	bt := NewBlockTransactionFromTransaction(block.Hash, block.Height, block.Confirmed, tx)
+
+	st := storage.NewTestStorage()
+	defer st.Close()
+	require.NoError(t, bt.Save(st))
+
+	fetched, err := GetBlockTransaction(st, bt.Hash)
+	require.NoError(t, err)
+	require.Equal(t, *tx.B.Memo, *fetched.Memo)
+}
+
 func TestBlockTransactionSaveAndGet(t *testing.T) {
 	st := storage.NewTestStorage()
 
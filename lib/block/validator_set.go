@@ -0,0 +1,131 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// ValidatorSetChange is the change of validator set by the confirmed
+// `operation.AddValidator` or `operation.RemoveValidator`; the nodes apply it
+// from the block of `ActivationHeight`. the storage should support,
+//  * find the changes in the range of activation height, in the order of
+//  confirmation
+//
+// models
+//  * 'activation height'
+// 	- 'vsc-<ActivationHeight>-<Height>-<transaction index>-<operation index>': `ValidatorSetChange`
+type ValidatorSetChange struct {
+	Type             operation.OperationType `json:"type"`
+	Address          string                  `json:"address"`
+	Endpoint         string                  `json:"endpoint,omitempty"`
+	Alias            string                  `json:"alias,omitempty"`
+	ActivationHeight uint64                  `json:"activation-height"`
+	Height           uint64                  `json:"height"` // the height of block, which confirms the change
+	TxHash           string                  `json:"tx-hash"`
+
+	txIndex int
+	opIndex int
+}
+
+// NewValidatorSetChange makes `ValidatorSetChange` from the operation of
+// transaction; `txIndex` and `opIndex` are the positions of transaction in
+// block and operation in transaction.
+func NewValidatorSetChange(height uint64, txHash string, txIndex, opIndex int, op operation.Operation) (vsc ValidatorSetChange, err error) {
+	vsc = ValidatorSetChange{
+		Type:    op.H.Type,
+		Height:  height,
+		TxHash:  txHash,
+		txIndex: txIndex,
+		opIndex: opIndex,
+	}
+
+	switch opb := op.B.(type) {
+	case operation.AddValidator:
+		vsc.Address = opb.Address
+		vsc.Endpoint = opb.Endpoint
+		vsc.Alias = opb.Alias
+		vsc.ActivationHeight = opb.ActivationHeight
+	case operation.RemoveValidator:
+		vsc.Address = opb.Address
+		vsc.ActivationHeight = opb.ActivationHeight
+	default:
+		err = errors.TypeOperationBodyNotMatched
+	}
+
+	return
+}
+
+// IsValidatorSetChange checks the operation changes the validator set.
+func IsValidatorSetChange(op operation.Operation) bool {
+	switch op.H.Type {
+	case operation.TypeAddValidator, operation.TypeRemoveValidator:
+		return true
+	default:
+		return false
+	}
+}
+
+func GetValidatorSetChangeKey(activationHeight, height uint64, txIndex, opIndex int) string {
+	return fmt.Sprintf(
+		"%s%020d-%020d-%05d-%05d",
+		common.ValidatorSetChangePrefix,
+		activationHeight,
+		height,
+		txIndex,
+		opIndex,
+	)
+}
+
+func (v ValidatorSetChange) String() string {
+	return string(common.MustJSONMarshal(v))
+}
+
+func (v ValidatorSetChange) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetValidatorSetChangeKey(v.ActivationHeight, v.Height, v.txIndex, v.opIndex)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, v)
+	} else {
+		err = st.New(key, v)
+	}
+
+	return
+}
+
+// GetValidatorSetChanges returns the changes, which are activated after the
+// block of `from` and until the block of `to`.
+func GetValidatorSetChanges(st *storage.LevelDBBackend, from, to uint64) (changes []ValidatorSetChange, err error) {
+	options := storage.NewDefaultListOptions(false, nil, 0)
+	iterFunc, closeFunc := st.GetIterator(common.ValidatorSetChangePrefix, options)
+	defer closeFunc()
+
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var vsc ValidatorSetChange
+		if err = common.DecodeJSONValue(item.Value, &vsc); err != nil {
+			return
+		}
+		if vsc.ActivationHeight <= from {
+			continue
+		}
+		if vsc.ActivationHeight > to {
+			break
+		}
+		changes = append(changes, vsc)
+	}
+
+	return
+}
@@ -0,0 +1,75 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestValidatorSetChanges(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	endpoint, _ := common.NewEndpointFromString("https://localhost:12345")
+	kp0, _ := keypair.Random()
+	kp1, _ := keypair.Random()
+
+	ops := []operation.Operation{}
+	for _, opb := range []operation.Body{
+		operation.NewAddValidator(kp0.Address(), endpoint, "v0", 5),
+		operation.NewRemoveValidator(kp0.Address(), 5), // same activation height; applied after the previous one
+		operation.NewRemoveValidator(kp1.Address(), 3),
+		operation.NewAddValidator(kp1.Address(), endpoint, "v1", 10),
+	} {
+		op, err := operation.NewOperation(opb)
+		require.NoError(t, err)
+		require.True(t, IsValidatorSetChange(op))
+		ops = append(ops, op)
+	}
+
+	for i, op := range ops {
+		vsc, err := NewValidatorSetChange(2, "tx-hash", 0, i, op)
+		require.NoError(t, err)
+		require.NoError(t, vsc.Save(st))
+	}
+
+	{ // all the changes in the order of activation height
+		changes, err := GetValidatorSetChanges(st, 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(changes))
+		require.Equal(t, uint64(3), changes[0].ActivationHeight)
+		require.Equal(t, operation.TypeAddValidator, changes[1].Type)
+		require.Equal(t, "v0", changes[1].Alias)
+		require.Equal(t, endpoint.String(), changes[1].Endpoint)
+		require.Equal(t, operation.TypeRemoveValidator, changes[2].Type)
+		require.Equal(t, uint64(10), changes[3].ActivationHeight)
+	}
+
+	{ // in range
+		changes, err := GetValidatorSetChanges(st, 3, 9)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(changes))
+		for _, vsc := range changes {
+			require.Equal(t, uint64(5), vsc.ActivationHeight)
+			require.Equal(t, uint64(2), vsc.Height)
+		}
+
+		changes, err = GetValidatorSetChanges(st, 5, 9)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(changes))
+	}
+
+	{ // not validator set change
+		op, _ := operation.NewOperation(operation.NewSetSigners(nil, operation.Thresholds{}))
+		require.False(t, IsValidatorSetChange(op))
+
+		_, err := NewValidatorSetChange(2, "tx-hash", 0, 0, op)
+		require.Equal(t, errors.TypeOperationBodyNotMatched, err)
+	}
+}
@@ -16,10 +16,15 @@ const (
 	UrlAccountTransactions   = "/accounts/{id}/transactions"
 	UrlAccount               = "/accounts/{id}"
 	UrlAccountOperations     = "/accounts/{id}/operations"
+	UrlAccountProof          = "/accounts/{id}/proof"
 	UrlTransactions          = "/transactions"
 	UrlTransactionByHash     = "/transactions/{id}"
 	UrlTransactionHistory    = "/transactions/{id}/history"
+	UrlTransactionProof      = "/transactions/{id}/proof"
 	UrlTransactionOperations = "/transactions/{id}/operations"
+	UrlBlocks                = "/blocks"
+	UrlBlock                 = "/blocks/{id}"
+	UrlBlockTransactions     = "/blocks/{id}/transactions"
 )
 
 type QueryKey string
@@ -29,10 +34,12 @@ func (qk QueryKey) String() string {
 }
 
 const (
-	QueryLimit  QueryKey = "limit"
-	QueryOrder  QueryKey = "order"
-	QueryCursor QueryKey = "cursor"
-	QueryType   QueryKey = "type"
+	QueryLimit   QueryKey = "limit"
+	QueryOrder   QueryKey = "order"
+	QueryCursor  QueryKey = "cursor"
+	QueryType    QueryKey = "type"
+	QueryHeight  QueryKey = "height"
+	QueryReverse QueryKey = "reverse"
 )
 
 type Q struct {
@@ -57,6 +64,10 @@ func (qs Queries) toQueryString() string {
 			urlValues.Add(QueryCursor.String(), q.Value)
 		case QueryType:
 			urlValues.Add(QueryType.String(), q.Value)
+		case QueryHeight:
+			urlValues.Add(QueryHeight.String(), q.Value)
+		case QueryReverse:
+			urlValues.Add(QueryReverse.String(), q.Value)
 
 		}
 	}
@@ -130,6 +141,13 @@ func (c *Client) LoadAccount(id string, queries ...Q) (account Account, err erro
 	return
 }
 
+func (c *Client) LoadAccountProof(id string, queries ...Q) (accountProof AccountProof, err error) {
+	url := strings.Replace(UrlAccountProof, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &accountProof)
+	return
+}
+
 func (c *Client) LoadTransaction(id string, queries ...Q) (transaction Transaction, err error) {
 	url := strings.Replace(UrlTransactionByHash, "{id}", id, -1)
 	url += Queries(queries).toQueryString()
@@ -144,6 +162,13 @@ func (c *Client) LoadTransactionHistory(id string, queries ...Q) (transactionHis
 	return
 }
 
+func (c *Client) LoadTransactionProof(id string, queries ...Q) (transactionProof TransactionProof, err error) {
+	url := strings.Replace(UrlTransactionProof, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &transactionProof)
+	return
+}
+
 func (c *Client) LoadTransactions(queries ...Q) (tPage TransactionsPage, err error) {
 	url := UrlTransactions
 	url += Queries(queries).toQueryString()
@@ -172,6 +197,28 @@ func (c *Client) LoadOperationsByTransaction(id string, queries ...Q) (oPage Ope
 	return
 }
 
+func (c *Client) LoadBlocks(queries ...Q) (bPage BlocksPage, err error) {
+	url := UrlBlocks
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &bPage)
+	return
+}
+
+// LoadBlock loads the block by hash or height.
+func (c *Client) LoadBlock(id string, queries ...Q) (blk Block, err error) {
+	url := strings.Replace(UrlBlock, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &blk)
+	return
+}
+
+func (c *Client) LoadTransactionsByBlock(id string, queries ...Q) (tPage TransactionsPage, err error) {
+	url := strings.Replace(UrlBlockTransactions, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &tPage)
+	return
+}
+
 func (c *Client) SubmitTransaction(tx []byte) (pTransaction TransactionPost, err error) {
 	url := UrlTransactions
 	headers := http.Header{}
@@ -2,6 +2,11 @@ package client
 
 import (
 	"encoding/json"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/common"
+	trieproof "boscoin.io/sebak/lib/trie/proof"
 )
 
 type Problem struct {
@@ -20,10 +25,85 @@ type Account struct {
 		Operations   Link `json:"operations"`
 	} `json:"_links"`
 
-	Address    string `json:"address"`
-	SequenceID uint64 `json:"sequence_id"`
-	Balance    string `json:"balance"`
-	Linked     string `json:"linked"`
+	Address    string     `json:"address"`
+	SequenceID uint64     `json:"sequence_id"`
+	Balance    string     `json:"balance"`
+	Linked     string     `json:"linked"`
+	Signers    []Signer   `json:"signers,omitempty"`
+	Thresholds Thresholds `json:"thresholds"`
+}
+
+type AccountProof struct {
+	Links struct {
+		Self    Link `json:"self"`
+		Account Link `json:"account"`
+	} `json:"_links"`
+
+	Address     string     `json:"address"`
+	SequenceID  uint64     `json:"sequence_id"`
+	Balance     string     `json:"balance"`
+	Linked      string     `json:"linked"`
+	Signers     []Signer   `json:"signers,omitempty"`
+	Thresholds  Thresholds `json:"thresholds"`
+	Block       string     `json:"block"`
+	BlockHeight uint64     `json:"block_height"`
+	StateRoot   string     `json:"state_root"`
+	Proof       []string   `json:"proof"`
+}
+
+// VerifyAccountProof checks the account of proof is committed in `stateRoot`;
+// like `VerifyTransactionProof`, the root should come from the trusted block
+// header.
+func VerifyAccountProof(proof AccountProof, stateRoot string) bool {
+	nodes := make([][]byte, len(proof.Proof))
+	for i, node := range proof.Proof {
+		nodes[i] = base58.Decode(node)
+	}
+
+	root := common.BytesToHash(base58.Decode(stateRoot))
+	value, err := trieproof.VerifyProof(root, []byte(proof.Address), nodes)
+	if err != nil || value == nil {
+		return false
+	}
+
+	var account struct {
+		Address    string     `json:"address"`
+		SequenceID uint64     `json:"sequence_id"`
+		Balance    string     `json:"balance"`
+		Linked     string     `json:"linked"`
+		Signers    []Signer   `json:"signers,omitempty"`
+		Thresholds Thresholds `json:"thresholds"`
+	}
+	if err = json.Unmarshal(value, &account); err != nil {
+		return false
+	}
+
+	if account.Address != proof.Address ||
+		account.SequenceID != proof.SequenceID ||
+		account.Balance != proof.Balance ||
+		account.Linked != proof.Linked ||
+		account.Thresholds != proof.Thresholds ||
+		len(account.Signers) != len(proof.Signers) {
+		return false
+	}
+	for i, signer := range account.Signers {
+		if signer != proof.Signers[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Signer struct {
+	Address string `json:"address"`
+	Weight  uint8  `json:"weight"`
+}
+
+type Thresholds struct {
+	Low    uint8 `json:"low"`
+	Medium uint8 `json:"medium"`
+	High   uint8 `json:"high"`
 }
 
 type Link struct {
@@ -43,6 +123,12 @@ type Transaction struct {
 	SequenceID     uint64 `json:"sequence_id"`
 	Created        string `json:"created"`
 	OperationCount uint64 `json:"operation_count"`
+	Memo           *Memo  `json:"memo,omitempty"`
+}
+
+type Memo struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 type TransactionPost struct {
@@ -69,6 +155,34 @@ type TransactionHistory struct {
 	Status  string `json:"status"`
 }
 
+type TransactionProof struct {
+	Links struct {
+		Self        Link `json:"self"`
+		Transaction Link `json:"transaction"`
+	} `json:"_links"`
+	Hash             string                   `json:"hash"`
+	Block            string                   `json:"block"`
+	BlockHeight      uint64                   `json:"block_height"`
+	TransactionsRoot string                   `json:"transactions_root"`
+	Index            int                      `json:"index"`
+	Total            int                      `json:"total"`
+	Path             []common.MerkleProofNode `json:"path"`
+}
+
+// VerifyTransactionProof checks the transaction of proof is included in
+// `transactionsRoot`; the root should come from the trusted block header,
+// not from the proof itself.
+func VerifyTransactionProof(proof TransactionProof, transactionsRoot string) bool {
+	if proof.Index < 0 || proof.Index >= proof.Total {
+		return false
+	}
+	return common.VerifyMerkleProof(
+		transactionsRoot,
+		proof.Hash,
+		common.MerkleProof{Index: proof.Index, Total: proof.Total, Path: proof.Path},
+	)
+}
+
 type TransactionsPage struct {
 	Links struct {
 		Self Link `json:"self"`
@@ -80,6 +194,39 @@ type TransactionsPage struct {
 	} `json:"_embedded"`
 }
 
+type Block struct {
+	Links struct {
+		Self         Link `json:"self"`
+		Transactions Link `json:"transactions"`
+		Prev         Link `json:"prev"`
+	} `json:"_links"`
+	Hash                string   `json:"hash"`
+	Height              uint64   `json:"height"`
+	Version             uint32   `json:"version"`
+	PrevBlockHash       string   `json:"prev_block_hash"`
+	TransactionsRoot    string   `json:"transactions_root"`
+	StateRoot           string   `json:"state_root"`
+	Timestamp           string   `json:"timestamp"`
+	TotalTxs            uint64   `json:"total_txs"`
+	TotalOps            uint64   `json:"total_ops"`
+	Proposer            string   `json:"proposer"`
+	Round               uint64   `json:"round"`
+	Confirmed           string   `json:"confirmed"`
+	Transactions        []string `json:"transactions"`
+	ProposerTransaction string   `json:"proposer_transaction"`
+}
+
+type BlocksPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Block `json:"records"`
+	} `json:"_embedded"`
+}
+
 type Operation struct {
 	Links struct {
 		Self        Link `json:"self"`
@@ -138,6 +285,23 @@ type Payment struct {
 	Amount []byte `json:"amount"`
 }
 
+type SetSigners struct {
+	Signers    []Signer   `json:"signers"`
+	Thresholds Thresholds `json:"thresholds"`
+}
+
+type AddValidator struct {
+	Address          string `json:"address"`
+	Endpoint         string `json:"endpoint"`
+	Alias            string `json:"alias"`
+	ActivationHeight uint64 `json:"activation-height"`
+}
+
+type RemoveValidator struct {
+	Address          string `json:"address"`
+	ActivationHeight uint64 `json:"activation-height"`
+}
+
 type Inflation struct {
 	Target         string `json:"target"`
 	Amount         []byte `json:"amount"`
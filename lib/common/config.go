@@ -15,11 +15,20 @@ type Config struct {
 	TimeoutACCEPT time.Duration
 	BlockTime     time.Duration
 
-	TxsLimit int
-	OpsLimit int
+	TxsLimit    int
+	OpsLimit    int
+	TxPoolLimit int // 0 means no limit
+
+	GossipFanout int // 0 means direct broadcast to all validators
+
+	ProposerSelector string // name of proposer selector; see `consensus.ProposerSelectors`
+
+	SnapshotInterval uint64 // 0 means no snapshot; see `block.Snapshot`
 
 	RateLimitRuleAPI  RateLimitRule
 	RateLimitRuleNode RateLimitRule
+
+	NetworkParams NetworkParams
 }
 
 func NewConfig() Config {
@@ -32,8 +41,11 @@ func NewConfig() Config {
 
 	p.TxsLimit = 1000
 	p.OpsLimit = 1000
+	p.TxPoolLimit = 10000
+	p.ProposerSelector = "sequential"
 	p.RateLimitRuleAPI = NewRateLimitRule(RateLimitAPI)
 	p.RateLimitRuleNode = NewRateLimitRule(RateLimitNode)
+	p.NetworkParams = NewNetworkParams()
 
 	return p
 }
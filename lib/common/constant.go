@@ -6,6 +6,8 @@ import (
 	"github.com/ulule/limiter"
 )
 
+// The economic constants are the default values of `NetworkParams`; the
+// network can have the different values.
 const (
 	// BaseFee is the default transaction fee, if fee is lower than BaseFee, the
 	// transaction will fail validation.
@@ -30,9 +32,7 @@ const (
 
 	// BlockHeightEndOfInflation sets the block height of inflation end.
 	BlockHeightEndOfInflation uint64 = 36000000
-)
 
-var (
 	// UnfreezingPeriod is the number of blocks required for unfreezing to take effect.
 	// When frozen funds are unfreezed, the transaction is record in the blockchain,
 	// and after `UnfreezingPeriod`, it takes effect on the account.
@@ -45,7 +45,9 @@ var (
 	// BallotConfirmedTimeAllowDuration, it will be considered not-wellformed.
 	// For details, `Ballot.IsWellFormed()`
 	BallotConfirmedTimeAllowDuration time.Duration = time.Minute * time.Duration(1)
+)
 
+var (
 	InflationRatioString string = InflationRatio2String(InflationRatio)
 
 	// RateLimitAPI set the rate limit for API interface, the default value
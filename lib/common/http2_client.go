@@ -17,14 +17,18 @@ type HTTP2Client struct {
 }
 
 func NewHTTP2Client(timeout, idleTimeout time.Duration, keepAlive bool) (client *HTTP2Client, err error) {
+	return NewHTTP2ClientWithTLSConfig(timeout, idleTimeout, keepAlive, &tls.Config{InsecureSkipVerify: true})
+}
+
+// NewHTTP2ClientWithTLSConfig creates `HTTP2Client` with the given
+// `tls.Config`, which can have the client certificate for mutual TLS.
+func NewHTTP2ClientWithTLSConfig(timeout, idleTimeout time.Duration, keepAlive bool, tlsConfig *tls.Config) (client *HTTP2Client, err error) {
 	if keepAlive {
 		timeout, idleTimeout = 0, 0
 	}
 
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true,
-		},
+		TLSClientConfig:   tlsConfig,
 		IdleConnTimeout:   idleTimeout,
 		DisableKeepAlives: !keepAlive,
 		DialContext: (&net.Dialer{
@@ -9,13 +9,13 @@ import (
 )
 
 // CalculateInflation returns the amount of inflation in every block.
-func CalculateInflation(initialBalance Amount) (a Amount, err error) {
+func CalculateInflation(initialBalance Amount, ratio float64) (a Amount, err error) {
 	if initialBalance > MaximumBalance {
 		err = errors.MaximumBalanceReached
 		return
 	}
 
-	a = Amount(uint64(math.Round(float64(initialBalance) * InflationRatio)))
+	a = Amount(uint64(math.Round(float64(initialBalance) * ratio)))
 	return
 }
 
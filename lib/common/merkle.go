@@ -0,0 +1,114 @@
+package common
+
+import (
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// The leaf and node of merkle tree are hashed with different prefixes to
+// prevent the second preimage attack.
+var (
+	merkleLeafPrefix []byte = []byte{0x00}
+	merkleNodePrefix []byte = []byte{0x01}
+)
+
+// MerkleProofNode is the sibling hash in the audit path of `MerkleProof`.
+// `Left` is true when the sibling is on the left side.
+type MerkleProofNode struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"`
+}
+
+// MerkleProof is the audit path from the leaf to the root.
+type MerkleProof struct {
+	Index int               `json:"index"`
+	Total int               `json:"total"`
+	Path  []MerkleProofNode `json:"path"`
+}
+
+func merkleLeaf(hash string) []byte {
+	b := append(append([]byte{}, merkleLeafPrefix...), base58.Decode(hash)...)
+	return MakeHash(b)
+}
+
+func merkleNode(left, right []byte) []byte {
+	b := append(append(append([]byte{}, merkleNodePrefix...), left...), right...)
+	return MakeHash(b)
+}
+
+func merkleLeaves(hashes []string) [][]byte {
+	leaves := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		leaves[i] = merkleLeaf(hash)
+	}
+
+	return leaves
+}
+
+// merkleLevel makes the upper level; the last node of odd level is promoted
+// without hashing.
+func merkleLevel(nodes [][]byte) [][]byte {
+	var upper [][]byte
+	for i := 0; i < len(nodes); i += 2 {
+		if i+1 == len(nodes) {
+			upper = append(upper, nodes[i])
+			continue
+		}
+		upper = append(upper, merkleNode(nodes[i], nodes[i+1]))
+	}
+
+	return upper
+}
+
+// MakeMerkleRoot makes the merkle root from the base58 encoded hashes.
+func MakeMerkleRoot(hashes []string) string {
+	if len(hashes) < 1 {
+		return base58.Encode(MakeHash([]byte{}))
+	}
+
+	nodes := merkleLeaves(hashes)
+	for len(nodes) > 1 {
+		nodes = merkleLevel(nodes)
+	}
+
+	return base58.Encode(nodes[0])
+}
+
+// MakeMerkleProof makes the audit path of the hash at `index`.
+func MakeMerkleProof(hashes []string, index int) (proof MerkleProof, ok bool) {
+	if index < 0 || index >= len(hashes) {
+		return
+	}
+
+	proof = MerkleProof{Index: index, Total: len(hashes)}
+
+	nodes := merkleLeaves(hashes)
+	for len(nodes) > 1 {
+		if index%2 == 0 {
+			if index+1 < len(nodes) {
+				proof.Path = append(proof.Path, MerkleProofNode{Hash: base58.Encode(nodes[index+1])})
+			}
+		} else {
+			proof.Path = append(proof.Path, MerkleProofNode{Hash: base58.Encode(nodes[index-1]), Left: true})
+		}
+
+		nodes = merkleLevel(nodes)
+		index = index / 2
+	}
+
+	ok = true
+	return
+}
+
+// VerifyMerkleProof checks the hash is included in the merkle tree of `root`.
+func VerifyMerkleProof(root, hash string, proof MerkleProof) bool {
+	node := merkleLeaf(hash)
+	for _, p := range proof.Path {
+		if p.Left {
+			node = merkleNode(base58.Decode(p.Hash), node)
+		} else {
+			node = merkleNode(node, base58.Decode(p.Hash))
+		}
+	}
+
+	return base58.Encode(node) == root
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestMerkleHashes(n int) []string {
+	var hashes []string
+	for i := 0; i < n; i++ {
+		hashes = append(hashes, base58.Encode(MakeHash([]byte(fmt.Sprintf("tx-%d", i)))))
+	}
+	return hashes
+}
+
+func TestMerkleRoot(t *testing.T) {
+	require.Equal(t, base58.Encode(MakeHash([]byte{})), MakeMerkleRoot(nil))
+
+	hashes := makeTestMerkleHashes(3)
+
+	// single leaf is still hashed with the leaf prefix
+	require.Equal(t, base58.Encode(merkleLeaf(hashes[0])), MakeMerkleRoot(hashes[:1]))
+	require.NotEqual(t, hashes[0], MakeMerkleRoot(hashes[:1]))
+
+	// order matters
+	require.NotEqual(t, MakeMerkleRoot(hashes), MakeMerkleRoot([]string{hashes[1], hashes[0], hashes[2]}))
+
+	// odd node is promoted
+	expected := merkleNode(merkleNode(merkleLeaf(hashes[0]), merkleLeaf(hashes[1])), merkleLeaf(hashes[2]))
+	require.Equal(t, base58.Encode(expected), MakeMerkleRoot(hashes))
+}
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		hashes := makeTestMerkleHashes(n)
+		root := MakeMerkleRoot(hashes)
+		for i, hash := range hashes {
+			proof, ok := MakeMerkleProof(hashes, i)
+			require.True(t, ok)
+			require.Equal(t, i, proof.Index)
+			require.Equal(t, n, proof.Total)
+			require.True(t, VerifyMerkleProof(root, hash, proof), "n=%d index=%d", n, i)
+
+			if n > 1 { // wrong hash
+				require.False(t, VerifyMerkleProof(root, hashes[(i+1)%n], proof))
+			}
+		}
+	}
+
+	hashes := makeTestMerkleHashes(4)
+	{ // out of range
+		_, ok := MakeMerkleProof(hashes, 4)
+		require.False(t, ok)
+		_, ok = MakeMerkleProof(hashes, -1)
+		require.False(t, ok)
+	}
+
+	{ // tampered path
+		proof, _ := MakeMerkleProof(hashes, 1)
+		proof.Path[0].Left = !proof.Path[0].Left
+		require.False(t, VerifyMerkleProof(MakeMerkleRoot(hashes), hashes[1], proof))
+	}
+}
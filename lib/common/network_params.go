@@ -0,0 +1,68 @@
+package common
+
+import (
+	"math"
+	"time"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+// NetworkParams has the economic and consensus parameters of network; all the
+// nodes of the same network must have the same parameters. The default
+// values are the constants of this package, like `BaseFee`.
+type NetworkParams struct {
+	BaseFee                          Amount        `json:"base-fee"`                             // base fee of operation
+	BaseReserve                      Amount        `json:"base-reserve"`                         // base reserve for one account
+	InflationRatio                   float64       `json:"inflation-ratio"`                      // inflation ratio in every block
+	BlockHeightEndOfInflation        uint64        `json:"block-height-end-of-inflation"`        // block height of inflation end
+	UnfreezingPeriod                 uint64        `json:"unfreezing-period"`                    // number of blocks for unfreezing
+	BallotConfirmedTimeAllowDuration time.Duration `json:"ballot-confirmed-time-allow-duration"` // allowed time difference of ballot
+}
+
+func NewNetworkParams() NetworkParams {
+	return NetworkParams{
+		BaseFee:                          BaseFee,
+		BaseReserve:                      BaseReserve,
+		InflationRatio:                   InflationRatio,
+		BlockHeightEndOfInflation:        BlockHeightEndOfInflation,
+		UnfreezingPeriod:                 UnfreezingPeriod,
+		BallotConfirmedTimeAllowDuration: BallotConfirmedTimeAllowDuration,
+	}
+}
+
+func (p NetworkParams) Validate() error {
+	if p.BaseFee < 1 || p.BaseFee > MaximumBalance {
+		return errors.InvalidNetworkParams.Clone().SetData("base-fee", p.BaseFee)
+	}
+	if p.BaseReserve < 1 || p.BaseReserve > MaximumBalance {
+		return errors.InvalidNetworkParams.Clone().SetData("base-reserve", p.BaseReserve)
+	}
+	if p.InflationRatio < 0 || p.InflationRatio >= 1 || math.IsNaN(p.InflationRatio) {
+		return errors.InvalidNetworkParams.Clone().SetData("inflation-ratio", p.InflationRatio)
+	}
+	// the zero period unfreezes at once, and the block height over
+	// `math.MaxUint32` can not be reached in practice, it's about 680 years
+	// by 5 seconds block time.
+	if p.UnfreezingPeriod < 1 || p.UnfreezingPeriod > math.MaxUint32 {
+		return errors.InvalidNetworkParams.Clone().SetData("unfreezing-period", p.UnfreezingPeriod)
+	}
+	if p.BlockHeightEndOfInflation < 1 || p.BlockHeightEndOfInflation > math.MaxUint32 {
+		return errors.InvalidNetworkParams.Clone().SetData(
+			"block-height-end-of-inflation",
+			p.BlockHeightEndOfInflation,
+		)
+	}
+	if p.BallotConfirmedTimeAllowDuration <= 0 {
+		return errors.InvalidNetworkParams.Clone().SetData(
+			"ballot-confirmed-time-allow-duration",
+			p.BallotConfirmedTimeAllowDuration,
+		)
+	}
+
+	return nil
+}
+
+// InflationRatioString is the inflation ratio in `Inflation` operation.
+func (p NetworkParams) InflationRatioString() string {
+	return InflationRatio2String(p.InflationRatio)
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestNetworkParamsValidate(t *testing.T) {
+	require.NoError(t, NewNetworkParams().Validate())
+	require.Equal(t, InflationRatioString, NewNetworkParams().InflationRatioString())
+
+	cases := map[string]func(p *NetworkParams){
+		"zero base fee":          func(p *NetworkParams) { p.BaseFee = 0 },
+		"zero base reserve":      func(p *NetworkParams) { p.BaseReserve = 0 },
+		"negative inflation":     func(p *NetworkParams) { p.InflationRatio = -0.1 },
+		"too high inflation":     func(p *NetworkParams) { p.InflationRatio = 1 },
+		"zero ballot time allow": func(p *NetworkParams) { p.BallotConfirmedTimeAllowDuration = 0 },
+		"zero unfreezing period": func(p *NetworkParams) { p.UnfreezingPeriod = 0 },
+		"too long unfreezing":    func(p *NetworkParams) { p.UnfreezingPeriod = math.MaxUint32 + 1 },
+		"zero inflation end":     func(p *NetworkParams) { p.BlockHeightEndOfInflation = 0 },
+		"too high inflation end": func(p *NetworkParams) { p.BlockHeightEndOfInflation = math.MaxUint64 },
+	}
+
+	for name, f := range cases {
+		p := NewNetworkParams()
+		f(&p)
+
+		err := p.Validate()
+		require.Error(t, err, name)
+		require.Equal(t, errors.InvalidNetworkParams.Code, err.(*errors.Error).Code, name)
+	}
+
+	p := NewNetworkParams()
+	p.BaseFee = BaseFee * 10
+	p.InflationRatio = 0
+	p.UnfreezingPeriod = 10
+	p.BallotConfirmedTimeAllowDuration = 10 * time.Second
+	require.NoError(t, p.Validate())
+}
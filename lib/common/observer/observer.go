@@ -7,4 +7,3 @@ var BlockTransactionObserver = observable.New()
 var BlockTransactionHistoryObserver = observable.New()
 var BlockObserver = observable.New()
 var BlockOperationObserver = observable.New()
-var SyncBlockWaitObserver = observable.New()
@@ -1,22 +1,32 @@
 package common
 
 const (
-	BlockPrefixHash                       = string(0x00)
-	BlockPrefixConfirmed                  = string(0x01)
-	BlockPrefixHeight                     = string(0x02)
-	BlockTransactionPrefixHash            = string(0x10)
-	BlockTransactionPrefixSource          = string(0x11)
-	BlockTransactionPrefixConfirmed       = string(0x12)
-	BlockTransactionPrefixAccount         = string(0x13)
-	BlockTransactionPrefixBlock           = string(0x14)
-	BlockOperationPrefixHash              = string(0x20)
-	BlockOperationPrefixTxHash            = string(0x21)
-	BlockOperationPrefixSource            = string(0x22)
-	BlockOperationPrefixTarget            = string(0x23)
-	BlockOperationPrefixPeers             = string(0x24)
-	BlockAccountPrefixAddress             = string(0x30)
-	BlockAccountPrefixCreated             = string(0x31)
-	BlockAccountSequenceIDPrefix          = string(0x32)
-	BlockAccountSequenceIDByAddressPrefix = string(0x33)
-	TransactionPoolPrefix                 = string(0x40)
+	BlockPrefixHash                       = "\x00"
+	BlockPrefixConfirmed                  = "\x01"
+	BlockPrefixHeight                     = "\x02"
+	BlockTransactionPrefixHash            = "\x10"
+	BlockTransactionPrefixSource          = "\x11"
+	BlockTransactionPrefixConfirmed       = "\x12"
+	BlockTransactionPrefixAccount         = "\x13"
+	BlockTransactionPrefixBlock           = "\x14"
+	BlockOperationPrefixHash              = "\x20"
+	BlockOperationPrefixTxHash            = "\x21"
+	BlockOperationPrefixSource            = "\x22"
+	BlockOperationPrefixTarget            = "\x23"
+	BlockOperationPrefixPeers             = "\x24"
+	BlockAccountPrefixAddress             = "\x30"
+	BlockAccountPrefixCreated             = "\x31"
+	BlockAccountSequenceIDPrefix          = "\x32"
+	BlockAccountSequenceIDByAddressPrefix = "\x33"
+	BlockAccountHistoryPrefix             = "\x34"
+	BlockAccountPrefixLinked              = "\x35"
+	TransactionPoolPrefix                 = "\x40"
+	TransactionPoolJournalPrefix          = "\x41"
+	StateTriePrefix                       = "\x50"
+	ValidatorSetChangePrefix              = "\x60"
+	VoteJournalPrefix                     = "\x70"
+	EvidencePrefix                        = "\x71"
+	SnapshotPrefix                        = "\x72"
+	SnapshotChunkPrefix                   = "\x73"
+	EvidenceHashPrefix                    = "\x74"
 )
@@ -0,0 +1,153 @@
+package consensus
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/metrics"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// Evidence is the proof of equivocation; the validator of `Source` signed the
+// two different ballots for the same voting basis and state. The signatures
+// of ballots can be verified by anyone, so the evidence can be used to act
+// against the validator.
+//
+// models
+//  * 'voting basis, state and source'
+// 	- 'ev-<voting.Basis.Height>-<voting.Basis.Round>-<ballot.State>-<Source>': `Evidence`
+//  * 'hash'
+// 	- 'evh-<Hash>': the key of `Evidence`
+type Evidence struct {
+	Hash        string          `json:"hash"` // made from the conflicting ballots
+	Source      string          `json:"source"`
+	VotingBasis voting.Basis    `json:"voting_basis"`
+	State       ballot.State    `json:"state"`
+	Ballots     []ballot.Ballot `json:"ballots"` // the first voted ballot and the conflicting one
+	Detected    string          `json:"detected"`
+}
+
+func NewEvidence(voted, conflicting ballot.Ballot) Evidence {
+	ballots := []ballot.Ballot{voted, conflicting}
+
+	return Evidence{
+		Hash:        common.MustMakeObjectHashString(ballots),
+		Source:      voted.Source(),
+		VotingBasis: voted.VotingBasis(),
+		State:       voted.State(),
+		Ballots:     ballots,
+		Detected:    common.NowISO8601(),
+	}
+}
+
+// IsConflictingBallot checks the two ballots of the same source, voting basis
+// and state have the different votes or proposals.
+func IsConflictingBallot(a, b ballot.Ballot) bool {
+	return a.Vote() != b.Vote() || a.ProposedHash() != b.ProposedHash()
+}
+
+func GetEvidenceKey(basis voting.Basis, state ballot.State, source string) string {
+	return fmt.Sprintf(
+		"%s%020d-%020d-%s-%s",
+		common.EvidencePrefix,
+		basis.Height,
+		basis.Round,
+		state,
+		source,
+	)
+}
+
+func GetEvidenceHashKey(hash string) string {
+	return fmt.Sprintf("%s%s", common.EvidenceHashPrefix, hash)
+}
+
+func (e Evidence) String() string {
+	return string(common.MustJSONMarshal(e))
+}
+
+// Save stores the evidence; the evidence of the same voting basis, state and
+// source is kept only once.
+func (e Evidence) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetEvidenceKey(e.VotingBasis, e.State, e.Source)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil || exists {
+		return
+	}
+
+	if err = st.New(key, e); err != nil {
+		return
+	}
+
+	return st.New(GetEvidenceHashKey(e.Hash), key)
+}
+
+// GetEvidence returns the evidence by it's hash.
+func GetEvidence(st *storage.LevelDBBackend, hash string) (e Evidence, err error) {
+	var key string
+	if err = st.Get(GetEvidenceHashKey(hash), &key); err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			err = errors.EvidenceNotFound
+		}
+		return
+	}
+
+	err = st.Get(key, &e)
+	return
+}
+
+func GetEvidences(st *storage.LevelDBBackend, options storage.ListOptions) (
+	func() (Evidence, bool, []byte),
+	func(),
+) {
+	iterFunc, closeFunc := st.GetIterator(common.EvidencePrefix, options)
+
+	return (func() (Evidence, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return Evidence{}, false, []byte{}
+			}
+
+			var e Evidence
+			if err := common.DecodeJSONValue(item.Value, &e); err != nil {
+				return Evidence{}, false, []byte{}
+			}
+
+			return e, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// equivocated keeps the evidence of the conflicting ballots and alerts it
+// once for the same voting basis, state and source; the conflicting ballot is
+// not voted.
+func (is *ISAAC) equivocated(voted, conflicting ballot.Ballot) error {
+	e := NewEvidence(voted, conflicting)
+
+	exists, err := is.storage.Has(GetEvidenceKey(e.VotingBasis, e.State, e.Source))
+	if err != nil {
+		is.log.Error("failed to check evidence", "error", err)
+	} else if exists {
+		return errors.BallotEquivocation
+	}
+
+	if err = e.Save(is.storage); err != nil {
+		is.log.Error("failed to save evidence", "error", err, "evidence", e)
+	}
+
+	metrics.ConsensusEquivocations.Inc()
+	is.log.Error(
+		"equivocation detected",
+		"source", e.Source,
+		"basis", e.VotingBasis,
+		"state", e.State,
+		"voted", voted.ProposedHash(),
+		"conflicting", conflicting.ProposedHash(),
+	)
+
+	return errors.BallotEquivocation
+}
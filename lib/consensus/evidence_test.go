@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"testing"
+
+	logging "github.com/inconshreveable/log15"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestISAACVoteEquivocation(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	proposer := keypair.Master("evidence-proposer")
+	voter := keypair.Master("evidence-voter")
+
+	networkID := []byte("sebak-evidence-test")
+	basis := voting.Basis{Height: 2, Round: 0}
+	proposed := *ballot.NewBallot(proposer.Address(), proposer.Address(), basis, []string{})
+	proposed.SignByProposer(proposer, networkID)
+
+	runningRound, err := NewRunningRound(networkID, proposer.Address(), proposed)
+	require.NoError(t, err)
+
+	is := ISAAC{
+		storage:       st,
+		log:           logging.New("module", "consensus"),
+		RunningRounds: map[string]*RunningRound{basis.Index(): runningRound},
+	}
+
+	vote := func(hole voting.Hole) ballot.Ballot {
+		b := proposed
+		b.SetSource(voter.Address())
+		b.SetVote(ballot.StateSIGN, hole)
+		return b
+	}
+
+	yes := vote(voting.YES)
+	_, err = is.Vote(yes)
+	require.NoError(t, err)
+	require.True(t, is.IsVoted(yes))
+
+	// the same ballot again is not the equivocation
+	_, err = is.Vote(yes)
+	require.NoError(t, err)
+
+	no := vote(voting.NO)
+	require.False(t, is.IsVoted(no))
+	_, err = is.Vote(no)
+	require.Equal(t, errors.BallotEquivocation, err)
+
+	// the conflicting ballot is not voted
+	roundVote, err := runningRound.RoundVote(proposer.Address())
+	require.NoError(t, err)
+	require.Equal(t, voting.YES, roundVote.SIGN[voter.Address()])
+
+	// the evidence is kept once
+	_, err = is.Vote(no)
+	require.Equal(t, errors.BallotEquivocation, err)
+
+	var evidences []Evidence
+	iterFunc, closeFunc := GetEvidences(st, nil)
+	for {
+		e, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		evidences = append(evidences, e)
+	}
+	closeFunc()
+
+	require.Equal(t, 1, len(evidences))
+	e := evidences[0]
+	require.Equal(t, voter.Address(), e.Source)
+	require.Equal(t, basis, e.VotingBasis)
+	require.Equal(t, ballot.StateSIGN, e.State)
+	require.Equal(t, 2, len(e.Ballots))
+	require.Equal(t, voting.YES, e.Ballots[0].Vote())
+	require.Equal(t, voting.NO, e.Ballots[1].Vote())
+}
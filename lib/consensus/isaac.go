@@ -48,6 +48,11 @@ type ISAAC struct {
 func NewISAAC(networkID []byte, node *node.LocalNode, p voting.ThresholdPolicy,
 	cm network.ConnectionManager, st *storage.LevelDBBackend, conf common.Config, syncer SyncController) (is *ISAAC, err error) {
 
+	var selector ProposerSelector
+	if selector, err = NewProposerSelector(conf.ProposerSelector, cm, st); err != nil {
+		return
+	}
+
 	is = &ISAAC{
 		NetworkID:         networkID,
 		Node:              node,
@@ -55,7 +60,7 @@ func NewISAAC(networkID []byte, node *node.LocalNode, p voting.ThresholdPolicy,
 		RunningRounds:     map[string]*RunningRound{},
 		connectionManager: cm,
 		storage:           st,
-		proposerSelector:  SequentialSelector{cm},
+		proposerSelector:  selector,
 		Conf:              conf,
 		log:               log.New(logging.Ctx{"node": node.Alias()}),
 		nodesHeight:       make(map[string]uint64),
@@ -90,8 +95,10 @@ func (is *ISAAC) CloseConsensus(proposer string, basis voting.Basis, vh voting.H
 		return
 	}
 
+	// the running rounds are cleaned up even if the transactions are not
+	// removed from the pool
 	if vh == voting.YES {
-		transactionPool.Remove(rr.Transactions[proposer]...)
+		err = transactionPool.Remove(rr.Transactions[proposer]...)
 	}
 
 	delete(is.RunningRounds, roundHash)
@@ -119,8 +126,11 @@ func (is *ISAAC) ConnectionManager() network.ConnectionManager {
 	return is.connectionManager
 }
 
-func (is *ISAAC) SelectProposer(blockHeight uint64, roundNumber uint64) string {
-	return is.proposerSelector.Select(blockHeight, roundNumber)
+// SelectProposer returns the proposer of the round of `basis`; the error is
+// returned when the selector can not decide it, for example the weighted
+// selector does not have the block of `basis` yet.
+func (is *ISAAC) SelectProposer(basis voting.Basis) (string, error) {
+	return is.proposerSelector.Select(basis)
 }
 
 func (is *ISAAC) SaveNodeHeight(senderAddr string, height uint64) {
@@ -218,18 +228,22 @@ func (is *ISAAC) Vote(b ballot.Ballot) (isNew bool, err error) {
 	var found bool
 	var runningRound *RunningRound
 	if runningRound, found = is.RunningRounds[roundHash]; !found {
-		proposer := is.SelectProposer(
-			b.VotingBasis().Height,
-			b.VotingBasis().Round,
-		)
+		var proposer string
+		if proposer, err = is.SelectProposer(b.VotingBasis()); err != nil {
+			return false, err
+		}
 
-		if runningRound, err = NewRunningRound(proposer, b); err != nil {
+		if runningRound, err = NewRunningRound(is.NetworkID, proposer, b); err != nil {
 			return true, err
 		}
 
 		is.RunningRounds[roundHash] = runningRound
 		isNew = true
 	} else {
+		if voted, conflicted := runningRound.Conflicting(b); conflicted {
+			return false, is.equivocated(voted, b)
+		}
+
 		if _, found = runningRound.Voted[b.Proposer()]; !found {
 			isNew = true
 		}
@@ -245,7 +259,13 @@ func (is *ISAAC) CanGetVotingResult(b ballot.Ballot) (RoundVoteResult, voting.Ho
 	defer is.RUnlock()
 	runningRound, _ := is.RunningRounds[b.VotingBasis().Index()]
 	if roundVote, err := runningRound.RoundVote(b.Proposer()); err == nil {
-		return roundVote.CanGetVotingResult(is.policy, b.State(), is.log)
+		result, votingHole, finished := roundVote.CanGetVotingResult(is.policy, b.State(), is.log)
+		if finished && votingHole == voting.YES && b.ProposedHash() != roundVote.Proposed {
+			// the finishing ballot is stored or broadcasted, so it must have
+			// the voted proposal.
+			return result, voting.NOTYET, false
+		}
+		return result, votingHole, finished
 	} else {
 		return nil, voting.NOTYET, false
 	}
@@ -1,21 +1,196 @@
 package consensus
 
 import (
+	"encoding/binary"
+	"fmt"
 	"sort"
+	"sync"
 
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
 )
 
+const (
+	ProposerSelectorSequential string = "sequential"
+	ProposerSelectorRandom     string = "random"
+	ProposerSelectorWeighted   string = "weighted"
+)
+
+// ProposerSelectors is the names of `ProposerSelector`, which can be
+// selected by `common.Config.ProposerSelector`.
+var ProposerSelectors = []string{
+	ProposerSelectorSequential,
+	ProposerSelectorRandom,
+	ProposerSelectorWeighted,
+}
+
+// ProposerSelector selects the proposer of the round of `voting.Basis`.
 type ProposerSelector interface {
-	Select(uint64, uint64) string
+	Select(voting.Basis) (string, error)
+}
+
+// NewProposerSelector returns the `ProposerSelector` of the given name; the
+// empty name means `SequentialSelector`. All the validators must use the same
+// selector.
+func NewProposerSelector(name string, cm network.ConnectionManager, st *storage.LevelDBBackend) (ProposerSelector, error) {
+	switch name {
+	case "", ProposerSelectorSequential:
+		return SequentialSelector{cm}, nil
+	case ProposerSelectorRandom:
+		return RandomSelector{cm: cm}, nil
+	case ProposerSelectorWeighted:
+		return NewWeightedSelector(cm, st), nil
+	default:
+		return nil, errors.InvalidProposerSelector
+	}
 }
 
 type SequentialSelector struct {
 	cm network.ConnectionManager
 }
 
-func (s SequentialSelector) Select(blockHeight uint64, roundNumber uint64) string {
+func (s SequentialSelector) Select(basis voting.Basis) (string, error) {
 	candidates := sort.StringSlice(s.cm.AllValidators())
 	candidates.Sort()
-	return candidates[(blockHeight+roundNumber)%uint64(len(candidates))]
+	return candidates[(basis.Height+basis.Round)%uint64(len(candidates))], nil
+}
+
+// RandomSelector selects the proposer pseudo-randomly; the seed is made from
+// the block hash and the round of `voting.Basis`, so the node, which does not
+// have the block yet, like the node in sync, also selects the same proposer.
+type RandomSelector struct {
+	cm network.ConnectionManager
+}
+
+func (s RandomSelector) Select(basis voting.Basis) (string, error) {
+	seed := selectorSeed(basis)
+
+	candidates := sort.StringSlice(s.cm.AllValidators())
+	candidates.Sort()
+	return candidates[seed%uint64(len(candidates))], nil
+}
+
+// WeightedSelector selects the proposer pseudo-randomly like
+// `RandomSelector`, but the chance of validator is proportional to it's
+// stake. The stake is the sum of balances of the frozen accounts, which are
+// linked to the validator, at the block of `voting.Basis`, so the block must
+// be stored. When no validator has stake, all the validators have the same
+// chance.
+type WeightedSelector struct {
+	sync.Mutex
+
+	cm network.ConnectionManager
+	st *storage.LevelDBBackend
+
+	stakesHeight uint64
+	stakes       map[ /* linked address */ string]common.Amount
+}
+
+func NewWeightedSelector(cm network.ConnectionManager, st *storage.LevelDBBackend) *WeightedSelector {
+	return &WeightedSelector{cm: cm, st: st}
+}
+
+func (s *WeightedSelector) Select(basis voting.Basis) (string, error) {
+	blk, err := block.GetBlockByHeight(s.st, basis.Height)
+	if err != nil {
+		return "", err
+	}
+	if blk.Hash != basis.BlockHash {
+		return "", errors.HashDoesNotMatch
+	}
+
+	seed := selectorSeed(basis)
+
+	candidates := sort.StringSlice(s.cm.AllValidators())
+	candidates.Sort()
+
+	stakes, err := s.getStakes(basis.Height, candidates...)
+	if err != nil {
+		return "", err
+	}
+
+	var total uint64
+	for _, address := range candidates {
+		total += uint64(stakes[address])
+	}
+	if total < 1 {
+		return candidates[seed%uint64(len(candidates))], nil
+	}
+
+	r := seed % total
+	for _, address := range candidates {
+		stake := uint64(stakes[address])
+		if r < stake {
+			return address, nil
+		}
+		r -= stake
+	}
+
+	return candidates[len(candidates)-1], nil
+}
+
+// getStakes returns the frozen balances of the validators at the block of
+// `height`; only the accounts linked to the validators are loaded by
+// `block.GetBlockAccountAddressesByLinked()`. The stakes are kept until the
+// next height is requested.
+func (s *WeightedSelector) getStakes(height uint64, validators ...string) (stakes map[string]common.Amount, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.stakes == nil || s.stakesHeight != height {
+		s.stakes = map[string]common.Amount{}
+		s.stakesHeight = height
+	}
+
+	for _, validator := range validators {
+		if _, found := s.stakes[validator]; found {
+			continue
+		}
+
+		var stake common.Amount
+		if stake, err = getStake(s.st, validator, height); err != nil {
+			return
+		}
+		s.stakes[validator] = stake
+	}
+
+	stakes = s.stakes
+
+	return
+}
+
+// getStake returns the sum of balances of the accounts linked to `validator`
+// at the block of `height`.
+func getStake(st *storage.LevelDBBackend, validator string, height uint64) (stake common.Amount, err error) {
+	iterFunc, closeFunc := block.GetBlockAccountAddressesByLinked(st, validator, storage.NewDefaultListOptions(false, nil, 0))
+	defer closeFunc()
+
+	for {
+		address, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var bah block.BlockAccountHistory
+		if bah, err = block.GetBlockAccountHistory(st, address, height); err != nil {
+			if err == errors.BlockAccountDoesNotExists { // created after `height`
+				err = nil
+				continue
+			}
+			return
+		}
+		stake = stake + bah.Balance
+	}
+
+	return
+}
+
+// selectorSeed makes the seed from the block hash and the round of `basis`.
+func selectorSeed(basis voting.Basis) uint64 {
+	h := common.MakeHash([]byte(fmt.Sprintf("%s-%d", basis.BlockHash, basis.Round)))
+	return binary.BigEndian.Uint64(h[:8])
 }
@@ -0,0 +1,138 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/voting"
+)
+
+type validatorsConnectionManager struct {
+	network.ConnectionManager
+	validators []string
+}
+
+func (c validatorsConnectionManager) AllValidators() []string {
+	return c.validators
+}
+
+func makeValidatorsConnectionManager(n int) validatorsConnectionManager {
+	var validators []string
+	for i := 0; i < n; i++ {
+		kp, _ := keypair.Random()
+		validators = append(validators, kp.Address())
+	}
+
+	return validatorsConnectionManager{validators: validators}
+}
+
+func TestNewProposerSelector(t *testing.T) {
+	cm := makeValidatorsConnectionManager(4)
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	for name, expected := range map[string]interface{}{
+		"":                         SequentialSelector{},
+		ProposerSelectorSequential: SequentialSelector{},
+		ProposerSelectorRandom:     RandomSelector{},
+		ProposerSelectorWeighted:   &WeightedSelector{},
+	} {
+		selector, err := NewProposerSelector(name, cm, st)
+		require.NoError(t, err)
+		require.IsType(t, expected, selector)
+	}
+
+	_, err := NewProposerSelector("showme", cm, st)
+	require.Equal(t, errors.InvalidProposerSelector, err)
+}
+
+func TestRandomSelector(t *testing.T) {
+	cm := makeValidatorsConnectionManager(4)
+	selector := RandomSelector{cm: cm}
+
+	// the block is not needed, so the node in sync can select the proposer
+	blockHash := common.GenerateUUID()
+
+	proposers := map[string]bool{}
+	for round := uint64(0); round < 20; round++ {
+		basis := voting.Basis{Height: 100, BlockHash: blockHash, Round: round}
+		proposer, err := selector.Select(basis)
+		require.NoError(t, err)
+		require.Contains(t, cm.validators, proposer)
+
+		// same block and round, same proposer
+		again, _ := selector.Select(basis)
+		require.Equal(t, proposer, again)
+
+		// the validator set is given in different order
+		reversed := validatorsConnectionManager{}
+		for i := len(cm.validators) - 1; i >= 0; i-- {
+			reversed.validators = append(reversed.validators, cm.validators[i])
+		}
+		again, _ = RandomSelector{cm: reversed}.Select(basis)
+		require.Equal(t, proposer, again)
+
+		proposers[proposer] = true
+	}
+	require.True(t, len(proposers) > 1)
+}
+
+func TestWeightedSelector(t *testing.T) {
+	cm := makeValidatorsConnectionManager(4)
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	genesis := block.GetGenesis(st)
+	genesisBasis := func(round uint64) voting.Basis {
+		return voting.Basis{Height: genesis.Height, BlockHash: genesis.Hash, Round: round}
+	}
+
+	freeze := func(linked string, amount common.Amount, height uint64) {
+		kp, _ := keypair.Random()
+		ba := block.NewBlockAccountLinked(kp.Address(), amount, linked)
+		ba.MustSave(st)
+		bah := block.NewBlockAccountHistory(*ba, height)
+		require.NoError(t, bah.Save(st))
+	}
+
+	{ // without stake, every validator can be selected
+		selector := NewWeightedSelector(cm, st)
+		for round := uint64(0); round < 10; round++ {
+			proposer, err := selector.Select(genesisBasis(round))
+			require.NoError(t, err)
+			require.Contains(t, cm.validators, proposer)
+		}
+	}
+
+	// only the validator 1 has the stake at genesis block; the validator 2
+	// freezes after the genesis block.
+	freeze(cm.validators[1], common.Unit*10, common.GenesisBlockHeight)
+	freeze(cm.validators[1], common.Unit*5, common.GenesisBlockHeight)
+	freeze(cm.validators[2], common.Unit*10000, common.GenesisBlockHeight+1)
+
+	selector := NewWeightedSelector(cm, st)
+	for round := uint64(0); round < 20; round++ {
+		proposer, err := selector.Select(genesisBasis(round))
+		require.NoError(t, err)
+		require.Equal(t, cm.validators[1], proposer)
+	}
+
+	// the block is not stored yet
+	_, err := selector.Select(voting.Basis{Height: 100, BlockHash: common.GenerateUUID()})
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+
+	// the block is different
+	_, err = selector.Select(voting.Basis{Height: common.GenesisBlockHeight, BlockHash: common.GenerateUUID()})
+	require.Equal(t, errors.HashDoesNotMatch, err)
+
+	stakes, err := selector.getStakes(common.GenesisBlockHeight, cm.validators...)
+	require.NoError(t, err)
+	require.Equal(t, common.Unit*15, stakes[cm.validators[1]])
+	require.Equal(t, common.Amount(0), stakes[cm.validators[2]])
+}
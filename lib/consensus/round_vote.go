@@ -9,15 +9,26 @@ import (
 
 type RoundVoteResult map[ /* Node.Address() */ string]voting.Hole
 
+// RoundVote counts the votes for the first proposal, which is signed by the
+// selected proposer; the proposer can propose the different ballots to the
+// validators, so the `YES` for the other proposal is counted as `NO`. The
+// `EXP` ballot is signed by it's source, not by the proposer, so it can not
+// be the proposal.
 type RoundVote struct {
-	SIGN   RoundVoteResult
-	ACCEPT RoundVoteResult
+	Proposed string // `Ballot.ProposedHash()` of the first proposal
+	SIGN     RoundVoteResult
+	ACCEPT   RoundVoteResult
+
+	networkID []byte
+	proposer  string // selected proposer of the round
 }
 
-func NewRoundVote(ballot ballot.Ballot) (rv *RoundVote) {
+func NewRoundVote(networkID []byte, proposer string, ballot ballot.Ballot) (rv *RoundVote) {
 	rv = &RoundVote{
-		SIGN:   RoundVoteResult{},
-		ACCEPT: RoundVoteResult{},
+		SIGN:      RoundVoteResult{},
+		ACCEPT:    RoundVoteResult{},
+		networkID: networkID,
+		proposer:  proposer,
 	}
 
 	rv.Vote(ballot)
@@ -40,16 +51,34 @@ func (rv *RoundVote) IsVotedByNode(state ballot.State, node string) bool {
 }
 
 func (rv *RoundVote) Vote(b ballot.Ballot) (isNew bool, err error) {
+	if rv.Proposed == "" && rv.isProposal(b) {
+		rv.Proposed = b.ProposedHash()
+	}
+
 	if b.State() == ballot.StateSIGN || b.State() == ballot.StateACCEPT {
 		result := rv.GetResult(b.State())
 
 		_, isNew = result[b.Source()]
-		result[b.Source()] = b.Vote()
+
+		vote := b.Vote()
+		if vote == voting.YES && b.ProposedHash() != rv.Proposed {
+			vote = voting.NO
+		}
+		result[b.Source()] = vote
 	}
 
 	return
 }
 
+// isProposal checks the proposal of ballot is signed by the selected proposer.
+func (rv *RoundVote) isProposal(b ballot.Ballot) bool {
+	if b.Vote() == voting.EXP || b.Proposer() != rv.proposer {
+		return false
+	}
+
+	return b.VerifyProposer(rv.networkID) == nil
+}
+
 func (rv *RoundVote) GetResult(state ballot.State) (result RoundVoteResult) {
 	if !state.IsValidForVote() {
 		return
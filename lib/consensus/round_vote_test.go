@@ -0,0 +1,138 @@
+package consensus
+
+import (
+	"testing"
+
+	logging "github.com/inconshreveable/log15"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/voting"
+)
+
+var roundVoteTestNetworkID = []byte("sebak-round-vote-test")
+
+var roundVoteTestProposer = keypair.Master("round-vote-proposer")
+
+func makeRoundVoteTestBallots() (proposed, other ballot.Ballot) {
+	proposer := roundVoteTestProposer
+	basis := voting.Basis{Height: 2, Round: 0}
+
+	proposed = *ballot.NewBallot(proposer.Address(), proposer.Address(), basis, []string{})
+	proposed.SignByProposer(proposer, roundVoteTestNetworkID)
+	// the equivocating proposer proposes the other ballot for the same round
+	other = *ballot.NewBallot(proposer.Address(), proposer.Address(), basis, []string{"tx"})
+	other.SignByProposer(proposer, roundVoteTestNetworkID)
+
+	return
+}
+
+func roundVoteTestBallot(proposed ballot.Ballot, source string, state ballot.State, hole voting.Hole) ballot.Ballot {
+	b := proposed
+	b.SetSource(source)
+	b.SetVote(state, hole)
+	return b
+}
+
+func TestRoundVoteConflictingProposal(t *testing.T) {
+	proposed, other := makeRoundVoteTestBallots()
+	require.NotEqual(t, proposed.ProposedHash(), other.ProposedHash())
+
+	rv := NewRoundVote(roundVoteTestNetworkID, proposed.Proposer(), proposed)
+	require.Equal(t, proposed.ProposedHash(), rv.Proposed)
+
+	rv.Vote(roundVoteTestBallot(proposed, "a", ballot.StateSIGN, voting.YES))
+	rv.Vote(roundVoteTestBallot(other, "b", ballot.StateSIGN, voting.YES))
+	rv.Vote(roundVoteTestBallot(other, "c", ballot.StateSIGN, voting.NO))
+
+	// the YES for the other proposal is counted as NO
+	result := rv.GetResult(ballot.StateSIGN)
+	require.Equal(t, voting.YES, result["a"])
+	require.Equal(t, voting.NO, result["b"])
+	require.Equal(t, voting.NO, result["c"])
+
+	policy, err := NewDefaultVotingThresholdPolicy(66)
+	require.NoError(t, err)
+	policy.SetValidators(3)
+
+	_, hole, finished := rv.CanGetVotingResult(policy, ballot.StateSIGN, logging.New())
+	require.True(t, finished)
+	require.Equal(t, voting.NO, hole)
+
+	// the conflicting YES votes can not make the YES result
+	rv = NewRoundVote(roundVoteTestNetworkID, proposed.Proposer(), proposed)
+	for _, source := range []string{"a", "b", "c"} {
+		rv.Vote(roundVoteTestBallot(other, source, ballot.StateSIGN, voting.YES))
+	}
+	_, hole, finished = rv.CanGetVotingResult(policy, ballot.StateSIGN, logging.New())
+	require.True(t, finished)
+	require.Equal(t, voting.NO, hole)
+}
+
+// TestRoundVoteExpiredBallotFirst checks the `EXP` ballot, which is signed by
+// the timed out node, and the proposal, which is not signed by the selected
+// proposer, can not be the proposal of `RoundVote`.
+func TestRoundVoteExpiredBallotFirst(t *testing.T) {
+	proposed, _ := makeRoundVoteTestBallots()
+
+	timedOut := keypair.Master("round-vote-timed-out")
+	expired := *ballot.NewBallot(timedOut.Address(), proposed.Proposer(), proposed.VotingBasis(), []string{})
+	expired.SetVote(ballot.StateSIGN, voting.EXP)
+	expired.SignByProposer(timedOut, roundVoteTestNetworkID)
+	require.NotEqual(t, proposed.ProposedHash(), expired.ProposedHash())
+
+	rv := NewRoundVote(roundVoteTestNetworkID, proposed.Proposer(), expired)
+	require.Empty(t, rv.Proposed)
+
+	// the YES ballot, which is not signed by the proposer
+	forged := *ballot.NewBallot(timedOut.Address(), proposed.Proposer(), proposed.VotingBasis(), []string{"tx"})
+	forged.SignByProposer(timedOut, roundVoteTestNetworkID)
+	rv.Vote(roundVoteTestBallot(forged, "a", ballot.StateSIGN, voting.YES))
+	require.Empty(t, rv.Proposed)
+
+	for _, source := range []string{"a", "b", "c"} {
+		rv.Vote(roundVoteTestBallot(proposed, source, ballot.StateSIGN, voting.YES))
+	}
+	require.Equal(t, proposed.ProposedHash(), rv.Proposed)
+
+	policy, err := NewDefaultVotingThresholdPolicy(66)
+	require.NoError(t, err)
+	policy.SetValidators(4)
+
+	result, hole, finished := rv.CanGetVotingResult(policy, ballot.StateSIGN, logging.New())
+	require.True(t, finished)
+	require.Equal(t, voting.YES, hole)
+	require.Equal(t, voting.EXP, result[timedOut.Address()])
+}
+
+func TestISAACCanGetVotingResultConflictingProposal(t *testing.T) {
+	proposed, other := makeRoundVoteTestBallots()
+
+	runningRound, err := NewRunningRound(roundVoteTestNetworkID, proposed.Proposer(), proposed)
+	require.NoError(t, err)
+
+	policy, err := NewDefaultVotingThresholdPolicy(66)
+	require.NoError(t, err)
+	policy.SetValidators(3)
+
+	is := ISAAC{
+		log:           logging.New("module", "consensus"),
+		policy:        policy,
+		RunningRounds: map[string]*RunningRound{proposed.VotingBasis().Index(): runningRound},
+	}
+
+	for _, source := range []string{"a", "b", "c"} {
+		runningRound.Vote(roundVoteTestBallot(proposed, source, ballot.StateSIGN, voting.YES))
+	}
+
+	_, hole, finished := is.CanGetVotingResult(roundVoteTestBallot(proposed, "c", ballot.StateSIGN, voting.YES))
+	require.True(t, finished)
+	require.Equal(t, voting.YES, hole)
+
+	// the ballot of the other proposal can not finish the voting, because the
+	// finishing ballot is stored
+	_, hole, finished = is.CanGetVotingResult(roundVoteTestBallot(other, "c", ballot.StateSIGN, voting.YES))
+	require.False(t, finished)
+	require.Equal(t, voting.NOTYET, hole)
+}
@@ -15,24 +15,31 @@ type RunningRound struct {
 	Proposer     string                              // LocalNode's `Proposer`
 	Transactions map[ /* Proposer */ string][]string /* Transaction.Hash */
 	Voted        map[ /* Proposer */ string]*RoundVote
+
+	networkID []byte
+	ballots   map[ballot.State]map[ /* Source */ string]ballot.Ballot // the first ballots of the validators
 }
 
-func NewRunningRound(proposer string, ballot ballot.Ballot) (*RunningRound, error) {
+func NewRunningRound(networkID []byte, proposer string, ballot ballot.Ballot) (*RunningRound, error) {
 	transactions := map[string][]string{
 		ballot.Proposer(): ballot.Transactions(),
 	}
 
-	roundVote := NewRoundVote(ballot)
+	roundVote := NewRoundVote(networkID, proposer, ballot)
 	voted := map[string]*RoundVote{
 		ballot.Proposer(): roundVote,
 	}
 
-	return &RunningRound{
+	rr := &RunningRound{
 		VotingBasis:  ballot.VotingBasis(),
 		Proposer:     proposer,
 		Transactions: transactions,
 		Voted:        voted,
-	}, nil
+		networkID:    networkID,
+	}
+	rr.keepBallot(ballot)
+
+	return rr, nil
 }
 
 func (rr *RunningRound) RoundVote(proposer string) (rv *RoundVote, err error) {
@@ -48,6 +55,13 @@ func (rr *RunningRound) RoundVote(proposer string) (rv *RoundVote, err error) {
 func (rr *RunningRound) IsVoted(ballot ballot.Ballot) bool {
 	rr.RLock()
 	defer rr.RUnlock()
+
+	// the conflicting ballot is not regarded as voted, so it can be detected
+	// by `Conflicting()`.
+	if voted, found := rr.ballots[ballot.State()][ballot.Source()]; found && IsConflictingBallot(voted, ballot) {
+		return false
+	}
+
 	if roundVote, found := rr.Voted[ballot.Proposer()]; !found {
 		return false
 	} else {
@@ -60,8 +74,35 @@ func (rr *RunningRound) Vote(ballot ballot.Ballot) {
 	defer rr.Unlock()
 
 	if _, found := rr.Voted[ballot.Proposer()]; !found {
-		rr.Voted[ballot.Proposer()] = NewRoundVote(ballot)
+		rr.Voted[ballot.Proposer()] = NewRoundVote(rr.networkID, rr.Proposer, ballot)
 	} else {
 		rr.Voted[ballot.Proposer()].Vote(ballot)
 	}
+	rr.keepBallot(ballot)
+}
+
+// Conflicting returns the ballot, which the source of the given ballot
+// already signed for the same state with the different vote or proposal.
+func (rr *RunningRound) Conflicting(b ballot.Ballot) (ballot.Ballot, bool) {
+	rr.RLock()
+	defer rr.RUnlock()
+
+	voted, found := rr.ballots[b.State()][b.Source()]
+	if !found || !IsConflictingBallot(voted, b) {
+		return ballot.Ballot{}, false
+	}
+
+	return voted, true
+}
+
+func (rr *RunningRound) keepBallot(b ballot.Ballot) {
+	if rr.ballots == nil {
+		rr.ballots = map[ballot.State]map[string]ballot.Ballot{}
+	}
+	if _, found := rr.ballots[b.State()]; !found {
+		rr.ballots[b.State()] = map[string]ballot.Ballot{}
+	}
+	if _, found := rr.ballots[b.State()][b.Source()]; !found {
+		rr.ballots[b.State()][b.Source()] = b
+	}
 }
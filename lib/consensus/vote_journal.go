@@ -0,0 +1,94 @@
+package consensus
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// VoteJournal keeps the ballots, which are voted by the local node, so the
+// node does not vote differently for the same round after it restarts. The
+// votes are removed after the block of the round is stored.
+//
+// models
+//  * 'voting basis and state'
+// 	- 'vj-<voting.Basis.Height>-<voting.Basis.Round>-<ballot.State>': `ballot.Ballot`
+type VoteJournal struct {
+	st *storage.LevelDBBackend
+}
+
+func NewVoteJournal(st *storage.LevelDBBackend) *VoteJournal {
+	return &VoteJournal{st: st}
+}
+
+func GetVoteJournalKey(basis voting.Basis, state ballot.State) string {
+	return fmt.Sprintf(
+		"%s%020d-%020d-%s",
+		common.VoteJournalPrefix,
+		basis.Height,
+		basis.Round,
+		state,
+	)
+}
+
+// Add keeps the ballot; if the ballot of the same round and state is already
+// kept, it is not replaced.
+func (j *VoteJournal) Add(b ballot.Ballot) (err error) {
+	key := GetVoteJournalKey(b.VotingBasis(), b.State())
+
+	var exists bool
+	if exists, err = j.st.Has(key); err != nil || exists {
+		return
+	}
+
+	return j.st.New(key, b)
+}
+
+// Get returns the ballot, which is voted for the round and state.
+func (j *VoteJournal) Get(basis voting.Basis, state ballot.State) (b ballot.Ballot, found bool, err error) {
+	key := GetVoteJournalKey(basis, state)
+
+	if found, err = j.st.Has(key); err != nil || !found {
+		return
+	}
+
+	err = j.st.Get(key, &b)
+
+	return
+}
+
+// RemoveUntil removes the ballots, which are voted until the voting basis
+// height of `height`.
+func (j *VoteJournal) RemoveUntil(height uint64) (err error) {
+	var keys []string
+
+	iterFunc, closeFunc := j.st.GetIterator(common.VoteJournalPrefix, nil)
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var b ballot.Ballot
+		if err = common.DecodeJSONValue(item.Value, &b); err != nil {
+			closeFunc()
+			return
+		}
+		if b.VotingBasis().Height > height {
+			break
+		}
+		keys = append(keys, string(item.Key))
+	}
+	closeFunc()
+
+	for _, key := range keys {
+		if err = j.st.Remove(key); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,57 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestVoteJournal(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	journal := NewVoteJournal(st)
+
+	kp := keypair.Master("vote-journal")
+	makeBallot := func(height, round uint64, state ballot.State, vote voting.Hole) ballot.Ballot {
+		basis := voting.Basis{Height: height, Round: round}
+		b := ballot.NewBallot(kp.Address(), kp.Address(), basis, []string{})
+		b.SetVote(state, vote)
+		return *b
+	}
+
+	b0 := makeBallot(2, 0, ballot.StateSIGN, voting.YES)
+	require.NoError(t, journal.Add(b0))
+	require.NoError(t, journal.Add(makeBallot(2, 0, ballot.StateSIGN, voting.NO))) // not replaced
+	require.NoError(t, journal.Add(makeBallot(2, 0, ballot.StateACCEPT, voting.YES)))
+	require.NoError(t, journal.Add(makeBallot(3, 1, ballot.StateSIGN, voting.EXP)))
+
+	voted, found, err := journal.Get(b0.VotingBasis(), ballot.StateSIGN)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, voting.YES, voted.Vote())
+	require.Equal(t, b0.ProposerConfirmed(), voted.ProposerConfirmed())
+
+	_, found, err = journal.Get(voting.Basis{Height: 2, Round: 1}, ballot.StateSIGN)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, journal.RemoveUntil(2))
+
+	_, found, err = journal.Get(b0.VotingBasis(), ballot.StateSIGN)
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = journal.Get(b0.VotingBasis(), ballot.StateACCEPT)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	voted, found, err = journal.Get(voting.Basis{Height: 3, Round: 1}, ballot.StateSIGN)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, voting.EXP, voted.Vote())
+}
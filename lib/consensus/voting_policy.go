@@ -17,6 +17,9 @@ type ISAACVotingThresholdPolicy struct {
 }
 
 func (vt *ISAACVotingThresholdPolicy) Validators() int {
+	vt.RLock()
+	defer vt.RUnlock()
+
 	return vt.validators
 }
 
@@ -25,6 +28,9 @@ func (vt *ISAACVotingThresholdPolicy) SetValidators(n int) error {
 		return errors.VotingThresholdInvalidValidators
 	}
 
+	vt.Lock()
+	defer vt.Unlock()
+
 	vt.validators = n
 
 	return nil
@@ -51,6 +57,9 @@ func (vt *ISAACVotingThresholdPolicy) SetConnected(n int) error {
 }
 
 func (vt *ISAACVotingThresholdPolicy) Threshold() int {
+	vt.RLock()
+	defer vt.RUnlock()
+
 	v := float64(vt.validators) * (float64(vt.threshold) / float64(100))
 	threshold := int(math.Ceil(v))
 
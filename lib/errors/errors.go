@@ -87,4 +87,43 @@ var (
 	HTTPServerError                           = NewError(173, "Internal Server Error")
 	BlockTransactionHistoryDoesNotExists      = NewError(174, "transaction history does not exists in block")
 	NotCommittable                            = NewError(175, "not Committable")
+	TransactionNotEnoughSignatureWeight       = NewError(176, "signatures of transaction do not reach the threshold of source account")
+	DuplicatedSigner                          = NewError(177, "duplicated signer found")
+	InvalidSignerThresholds                   = NewError(178, "invalid signer weights or thresholds")
+	TooManySigners                            = NewError(179, "too many signers")
+	StateRootDoesNotMatch                     = NewError(180, "state root does not match")
+	TransactionInvalidHeightBounds            = NewError(181, "`min_height` of transaction is greater than `max_height`")
+	TransactionNotYetValid                    = NewError(182, "transaction is not valid until `min_height`")
+	TransactionExpired                        = NewError(183, "transaction is expired after `max_height`")
+	TransactionPoolFull                       = NewError(184, "transaction pool is full; fee is too low to evict the others")
+	TransactionInvalidMemo                    = NewError(185, "invalid memo; check `type` and the length of `value`")
+	NodeRequestNotSigned                      = NewError(186, "node request is not signed")
+	NodeRequestInvalidSignature               = NewError(187, "node request has invalid signature")
+	NodeRequestFromUnknownNode                = NewError(188, "node request is not from known validators")
+	NodeRequestExpired                        = NewError(189, "node request timestamp is out of the allowed window")
+	NodeRequestReplayed                       = NewError(190, "node request nonce is already used")
+	PeerCertificateNotFound                   = NewError(191, "peer certificate is not found")
+	PeerCertificateNotBound                   = NewError(192, "peer certificate is not bound to the node address")
+	PeerCertificateFromUnknownNode            = NewError(193, "peer certificate is not from known validators")
+	PeerCertificateMismatch                   = NewError(194, "peer certificate does not match the node of request")
+	MemoryNetworkLinkDown                     = NewError(195, "link of memory network is down")
+	ValidatorSetChangeNotAllowed              = NewError(196, "validator set can be changed only by genesis account")
+	InvalidActivationHeight                   = NewError(197, "activation height must be after the next block")
+	InvalidValidatorEndpoint                  = NewError(198, "invalid validator endpoint")
+	InvalidProposerSelector                   = NewError(199, "invalid proposer selector")
+	ConflictingVote                           = NewError(200, "vote conflicts with the journaled vote")
+	BallotEquivocation                        = NewError(201, "ballot conflicts with the ballot of the same source")
+	InvalidNetworkParams                      = NewError(202, "invalid network parameters")
+	InvalidSnapshot                           = NewError(203, "invalid snapshot")
+	InvalidSnapshotChunk                      = NewError(204, "snapshot chunk does not match the snapshot")
+	SnapshotFromUnknownValidator              = NewError(205, "snapshot is not signed by known validators")
+	BlockHeaderChainNotLinked                 = NewError(206, "block headers are not linked to the previous block")
+	BlockHeaderChainNotAgreed                 = NewError(207, "block headers are not agreed by the peers")
+	InvalidKeystore                           = NewError(208, "invalid keystore")
+	KeystoreDecryptionFailed                  = NewError(209, "failed to decrypt keystore; wrong passphrase")
+	ProposerSelectorMismatch                  = NewError(210, "proposer selector is different from the validator")
+	EvidenceNotFound                          = NewError(211, "evidence not found")
+	SnapshotNotConfirmed                      = NewError(212, "snapshot block is not confirmed by the threshold of validators")
+	SnapshotTooLarge                          = NewError(213, "snapshot response is too large")
+	BallotHasInvalidProposer                  = NewError(214, "ballot is not proposed by the selected proposer")
 )
@@ -0,0 +1,270 @@
+// Package keystore keeps the secret seed in the file encrypted by the
+// passphrase; the key of cipher is derived from the passphrase by scrypt or
+// argon2id, and the secret seed is encrypted by AES-GCM.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stellar/go/keypair"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+const (
+	Version      int    = 1
+	CipherAESGCM string = "aes-256-gcm"
+	KDFScrypt    string = "scrypt"
+	KDFArgon2id  string = "argon2id"
+
+	keyLength  int = 32
+	saltLength int = 32
+)
+
+// Keystore is the encrypted secret seed of `Address`.
+type Keystore struct {
+	Version int    `json:"version"`
+	Address string `json:"address"`
+	Crypto  Crypto `json:"crypto"`
+}
+
+type Crypto struct {
+	Cipher     string    `json:"cipher"`
+	CipherText string    `json:"ciphertext"` // hex encoded
+	Nonce      string    `json:"nonce"`      // hex encoded
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdfparams"`
+}
+
+// KDFParams is the parameters of `Crypto.KDF`; `N`, `R` and `P` are for
+// scrypt, and `Time`, `Memory` and `Threads` are for argon2id.
+type KDFParams struct {
+	Salt      string `json:"salt"` // hex encoded
+	KeyLength int    `json:"keylen"`
+	N         int    `json:"n,omitempty"`
+	R         int    `json:"r,omitempty"`
+	P         int    `json:"p,omitempty"`
+	Time      uint32 `json:"time,omitempty"`
+	Memory    uint32 `json:"memory,omitempty"` // KiB
+	Threads   uint8  `json:"threads,omitempty"`
+}
+
+// DefaultKDFParams returns the parameters of `kdf` for the new keystore; the
+// salt is set by `Encrypt()`.
+func DefaultKDFParams(kdf string) (KDFParams, error) {
+	switch kdf {
+	case KDFScrypt:
+		return KDFParams{KeyLength: keyLength, N: 1 << 17, R: 8, P: 1}, nil
+	case KDFArgon2id:
+		return KDFParams{KeyLength: keyLength, Time: 3, Memory: 64 * 1024, Threads: 4}, nil
+	default:
+		return KDFParams{}, errors.InvalidKeystore.Clone().SetData("error", "unknown kdf: "+kdf)
+	}
+}
+
+func deriveKey(kdf string, params KDFParams, passphrase []byte) ([]byte, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil || len(salt) < 1 || params.KeyLength != keyLength {
+		return nil, errors.InvalidKeystore
+	}
+
+	switch kdf {
+	case KDFScrypt:
+		key, err := scrypt.Key(passphrase, salt, params.N, params.R, params.P, params.KeyLength)
+		if err != nil {
+			return nil, errors.InvalidKeystore.Clone().SetData("error", err.Error())
+		}
+		return key, nil
+	case KDFArgon2id:
+		if params.Time < 1 || params.Memory < 1 || params.Threads < 1 {
+			return nil, errors.InvalidKeystore
+		}
+		return argon2.IDKey(passphrase, salt, params.Time, params.Memory, params.Threads, uint32(params.KeyLength)), nil
+	default:
+		return nil, errors.InvalidKeystore.Clone().SetData("error", "unknown kdf: "+kdf)
+	}
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts the secret seed of `kp` by `passphrase`; the address is
+// authenticated together, so the keystore can not be used for the other
+// address.
+func Encrypt(kp *keypair.Full, passphrase []byte, kdf string, params KDFParams) (k Keystore, err error) {
+	salt := make([]byte, saltLength)
+	if _, err = rand.Read(salt); err != nil {
+		return
+	}
+	params.Salt = hex.EncodeToString(salt)
+
+	var key []byte
+	if key, err = deriveKey(kdf, params, passphrase); err != nil {
+		return
+	}
+
+	var gcm cipher.AEAD
+	if gcm, err = newGCM(key); err != nil {
+		return
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, []byte(kp.Seed()), []byte(kp.Address()))
+
+	k = Keystore{
+		Version: Version,
+		Address: kp.Address(),
+		Crypto: Crypto{
+			Cipher:     CipherAESGCM,
+			CipherText: hex.EncodeToString(ciphertext),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        kdf,
+			KDFParams:  params,
+		},
+	}
+
+	return
+}
+
+// Decrypt returns the keypair of keystore; the wrong passphrase returns
+// `errors.KeystoreDecryptionFailed`.
+func (k Keystore) Decrypt(passphrase []byte) (*keypair.Full, error) {
+	if k.Version != Version || k.Crypto.Cipher != CipherAESGCM {
+		return nil, errors.InvalidKeystore
+	}
+
+	ciphertext, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.InvalidKeystore
+	}
+	nonce, err := hex.DecodeString(k.Crypto.Nonce)
+	if err != nil {
+		return nil, errors.InvalidKeystore
+	}
+
+	key, err := deriveKey(k.Crypto.KDF, k.Crypto.KDFParams, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, errors.InvalidKeystore
+	}
+
+	seed, err := gcm.Open(nil, nonce, ciphertext, []byte(k.Address))
+	if err != nil {
+		return nil, errors.KeystoreDecryptionFailed
+	}
+
+	kp, err := keypair.Parse(string(seed))
+	if err != nil {
+		return nil, errors.InvalidKeystore
+	}
+	full, ok := kp.(*keypair.Full)
+	if !ok || full.Address() != k.Address {
+		return nil, errors.InvalidKeystore
+	}
+
+	return full, nil
+}
+
+// Save writes the keystore to `path`; the existing file is not overwritten.
+func (k Keystore) Save(path string) error {
+	b, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Load reads the keystore file of `path`.
+func Load(path string) (k Keystore, err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(b, &k); err != nil {
+		err = errors.InvalidKeystore.Clone().SetData("error", err.Error())
+		return
+	}
+	if _, err = keypair.Parse(k.Address); err != nil {
+		err = errors.InvalidKeystore.Clone().SetData("error", err.Error())
+		return
+	}
+
+	return
+}
+
+// FileName is the file name of keystore in the keystore directory.
+func FileName(address string) string {
+	return address + ".json"
+}
+
+// File is the keystore file found by `List()`.
+type File struct {
+	Path    string
+	Address string
+}
+
+// List returns the keystore files in `dir` by address; the files, which are
+// not keystore, are skipped.
+func List(dir string) (files []File, err error) {
+	var infos []os.FileInfo
+	if infos, err = ioutil.ReadDir(dir); err != nil {
+		return
+	}
+
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(dir, info.Name())
+		k, err := Load(path)
+		if err != nil {
+			continue
+		}
+		files = append(files, File{Path: path, Address: k.Address})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Address < files[j].Address })
+
+	return
+}
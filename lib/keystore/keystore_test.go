@@ -0,0 +1,92 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+// testKDFParams is cheap to derive the key in the tests.
+var testKDFParams = map[string]KDFParams{
+	KDFScrypt:   KDFParams{KeyLength: keyLength, N: 1 << 4, R: 8, P: 1},
+	KDFArgon2id: KDFParams{KeyLength: keyLength, Time: 1, Memory: 64, Threads: 1},
+}
+
+func TestKeystoreEncryptDecrypt(t *testing.T) {
+	kp := keypair.Master("test").(*keypair.Full)
+	passphrase := []byte("sebak")
+
+	for kdf, params := range testKDFParams {
+		k, err := Encrypt(kp, passphrase, kdf, params)
+		require.NoError(t, err)
+		require.Equal(t, kp.Address(), k.Address)
+		require.Equal(t, kdf, k.Crypto.KDF)
+		require.NotContains(t, k.Crypto.CipherText, kp.Seed())
+
+		decrypted, err := k.Decrypt(passphrase)
+		require.NoError(t, err)
+		require.Equal(t, kp.Seed(), decrypted.Seed())
+
+		_, err = k.Decrypt([]byte("wrong"))
+		require.Equal(t, errors.KeystoreDecryptionFailed, err)
+
+		// the address is authenticated with the secret seed
+		modified := k
+		modified.Address = keypair.Master("other").Address()
+		_, err = modified.Decrypt(passphrase)
+		require.Equal(t, errors.KeystoreDecryptionFailed, err)
+
+		modified = k
+		modified.Crypto.KDF = "unknown"
+		_, err = modified.Decrypt(passphrase)
+		require.Error(t, err)
+	}
+
+	_, err := DefaultKDFParams("unknown")
+	require.Error(t, err)
+}
+
+func TestKeystoreSaveLoadList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var addresses []string
+	for _, name := range []string{"a", "b"} {
+		kp := keypair.Master(name).(*keypair.Full)
+		k, err := Encrypt(kp, []byte(name), KDFScrypt, testKDFParams[KDFScrypt])
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, FileName(kp.Address()))
+		require.NoError(t, k.Save(path))
+
+		// the existing keystore is not overwritten
+		require.Error(t, k.Save(path))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		loaded, err := Load(path)
+		require.NoError(t, err)
+		require.Equal(t, k, loaded)
+
+		addresses = append(addresses, kp.Address())
+	}
+
+	// not keystore
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "c.json"), []byte("{}"), 0600))
+	_, err = Load(filepath.Join(dir, "c.json"))
+	require.Error(t, err)
+
+	files, err := List(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	require.ElementsMatch(t, addresses, []string{files[0].Address, files[1].Address})
+}
@@ -0,0 +1,80 @@
+// Package metrics defines the prometheus metrics of sebak node; they are
+// exported by the `network.UrlPathPrefixMetric` handler.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Namespace = "sebak"
+
+var (
+	TxPoolSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "txpool",
+		Name:      "size",
+		Help:      "Number of transactions in the transaction pool.",
+	})
+	TxPoolSources = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "txpool",
+		Name:      "sources",
+		Help:      "Number of source accounts which have transactions in the transaction pool.",
+	})
+	TxPoolEvicted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "txpool",
+		Name:      "evicted_total",
+		Help:      "Number of transactions evicted from the full transaction pool.",
+	})
+	ConsensusEquivocations = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "consensus",
+		Name:      "equivocations_total",
+		Help:      "Number of detected validators which signed the conflicting ballots.",
+	})
+	SyncCurrentBlock = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "sync",
+		Name:      "current_block",
+		Help:      "Block height where sync is at.",
+	})
+	SyncHighestBlock = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "sync",
+		Name:      "highest_block",
+		Help:      "Highest alleged block height in the network.",
+	})
+	SyncBlocksPerSecond = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "sync",
+		Name:      "blocks_per_second",
+		Help:      "Number of synced blocks per second in the current sync.",
+	})
+	SyncETASeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "sync",
+		Name:      "eta_seconds",
+		Help:      "Estimated seconds to reach the highest block.",
+	})
+	SyncFetchFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "sync",
+		Name:      "fetch_failures_total",
+		Help:      "Number of failures to fetch the headers or the blocks by peer.",
+	}, []string{"peer"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		TxPoolSize,
+		TxPoolSources,
+		TxPoolEvicted,
+		ConsensusEquivocations,
+		SyncCurrentBlock,
+		SyncHighestBlock,
+		SyncBlocksPerSecond,
+		SyncETASeconds,
+		SyncFetchFailures,
+	)
+}
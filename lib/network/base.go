@@ -17,6 +17,8 @@ type Network interface {
 	AddWatcher(func(Network, net.Conn, http.ConnState))
 	AddHandler(string, http.HandlerFunc) *mux.Route
 	AddMiddleware(string, ...mux.MiddlewareFunc) error
+	SetRequestSigner(*RequestSigner)
+	SetPeerCertificateVerifier(PeerCertificateVerifier)
 
 	// Starts network handling
 	// Blocks until finished, either because of an error
@@ -38,6 +40,7 @@ type NetworkClient interface {
 	Connect(node node.Node) ([]byte, error)
 	GetNodeInfo() ([]byte, error)
 	SendMessage(common.Serializable) ([]byte, error)
+	SendTransaction(common.Serializable) ([]byte, error)
 	SendBallot(common.Serializable) ([]byte, error)
 	GetTransactions([]string) ([]byte, error)
 }
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// CertificateAddressScheme is the scheme of URI SAN, which binds the TLS
+// certificate to the node address, like
+// `sebak:<address>?signature=<signature>`. The signature is made by the node
+// keypair over the public key of certificate, so only the owner of the node
+// address can make the bound certificate.
+const CertificateAddressScheme = "sebak"
+
+func makeCertificateAddressURI(kp *keypair.Full, publicKey []byte) (u *url.URL, err error) {
+	var signature []byte
+	if signature, err = kp.Sign(publicKey); err != nil {
+		return
+	}
+
+	u = &url.URL{
+		Scheme:   CertificateAddressScheme,
+		Opaque:   kp.Address(),
+		RawQuery: url.Values{"signature": []string{base58.Encode(signature)}}.Encode(),
+	}
+	return
+}
+
+// CertificateAddress returns the node address, which the certificate is bound
+// to.
+func CertificateAddress(cert *x509.Certificate) (address string, err error) {
+	for _, u := range cert.URIs {
+		if u.Scheme != CertificateAddressScheme {
+			continue
+		}
+
+		var kp keypair.KP
+		if kp, err = keypair.Parse(u.Opaque); err != nil {
+			break
+		}
+		signature := base58.Decode(u.Query().Get("signature"))
+		if err = kp.Verify(cert.RawSubjectPublicKeyInfo, signature); err != nil {
+			break
+		}
+
+		address = kp.Address()
+		return
+	}
+
+	err = errors.PeerCertificateNotBound
+	return
+}
+
+// PeerCertificateVerifier checks the certificates of peer in mutual TLS;
+// `endpoint` is the endpoint of peer for the outgoing connection and nil for
+// the incoming connection.
+type PeerCertificateVerifier func(endpoint *common.Endpoint, certs []*x509.Certificate) error
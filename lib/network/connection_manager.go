@@ -13,7 +13,9 @@ type ConnectionManager interface {
 	GetConnection(string) NetworkClient
 	ConnectionWatcher(Network, net.Conn, http.ConnState)
 	Broadcast(common.Message)
+	Relay(common.Message)
 	Start()
+	UpdateValidators()
 	AllConnected() []string
 	AllValidators() []string
 	CountConnected() int
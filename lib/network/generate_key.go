@@ -12,6 +12,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/stellar/go/keypair"
+
 	"boscoin.io/sebak/lib/common"
 )
 
@@ -43,6 +45,12 @@ func remove(filePath string) {
 }
 
 func NewKeyGenerator(dirPath, certPath, keyPath string) *KeyGenerator {
+	return NewKeyGeneratorWithKeypair(dirPath, certPath, keyPath, nil)
+}
+
+// NewKeyGeneratorWithKeypair generates the certificate, which is bound to the
+// address of `kp` for mutual TLS; see `CertificateAddress`.
+func NewKeyGeneratorWithKeypair(dirPath, certPath, keyPath string, kp *keypair.Full) *KeyGenerator {
 	p := &KeyGenerator{}
 
 	p.dirPath = dirPath
@@ -50,7 +58,7 @@ func NewKeyGenerator(dirPath, certPath, keyPath string) *KeyGenerator {
 	p.keyPath = fmt.Sprintf("%s/%s", dirPath, keyPath)
 
 	if !common.IsExists(p.certPath) || !common.IsExists(p.keyPath) {
-		GenerateKey(p.dirPath, p.certPath, p.keyPath)
+		GenerateKeyWithKeypair(p.dirPath, p.certPath, p.keyPath, kp)
 	}
 
 	return p
@@ -73,6 +81,10 @@ func (g *KeyGenerator) Close() {
 }
 
 func GenerateKey(dirPath, certPath, keyPath string) {
+	GenerateKeyWithKeypair(dirPath, certPath, keyPath, nil)
+}
+
+func GenerateKeyWithKeypair(dirPath, certPath, keyPath string, kp *keypair.Full) {
 	if common.IsNotExists(dirPath) {
 		os.Mkdir(dirPath, 0755)
 	}
@@ -100,7 +112,7 @@ func GenerateKey(dirPath, certPath, keyPath string) {
 		NotAfter:  notAfter,
 
 		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
 		BasicConstraintsValid: true,
 	}
 
@@ -108,6 +120,20 @@ func GenerateKey(dirPath, certPath, keyPath string) {
 	template.IsCA = true
 	template.KeyUsage |= x509.KeyUsageCertSign
 
+	if kp != nil {
+		publicKey, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+		if err != nil {
+			log.Error("failed to marshal public key", "error", err)
+			return
+		}
+		u, err := makeCertificateAddressURI(kp, publicKey)
+		if err != nil {
+			log.Error("failed to bind certificate to address", "error", err)
+			return
+		}
+		template.URIs = append(template.URIs, u)
+	}
+
 	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
 	if err != nil {
 		log.Debug("Failed to create certificate", "error", err)
@@ -1,10 +1,15 @@
 package network
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"testing"
 
-	"boscoin.io/sebak/lib/common"
+	"github.com/stellar/go/keypair"
 	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 )
 
 func TestGenerateKey(t *testing.T) {
@@ -21,3 +26,31 @@ func TestGenerateKey(t *testing.T) {
 	require.Equal(t, common.IsExists(keyPath), true)
 
 }
+
+func TestGenerateKeyWithKeypair(t *testing.T) {
+	kp, _ := keypair.Random()
+	g := NewKeyGeneratorWithKeypair("tls_tmp", "sebak-bound.cert", "sebak-bound.key", kp)
+	defer g.Close()
+
+	cert, err := tls.LoadX509KeyPair(g.GetCertPath(), g.GetKeyPath())
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	address, err := CertificateAddress(parsed)
+	require.NoError(t, err)
+	require.Equal(t, kp.Address(), address)
+
+	{ // not bound
+		g := NewKeyGenerator("tls_tmp", "sebak.cert", "sebak.key")
+		defer g.Close()
+
+		cert, err := tls.LoadX509KeyPair(g.GetCertPath(), g.GetKeyPath())
+		require.NoError(t, err)
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+
+		_, err = CertificateAddress(parsed)
+		require.Equal(t, errors.PeerCertificateNotBound, err)
+	}
+}
@@ -0,0 +1,72 @@
+package network
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultGossipSeenExpire is how long `Gossip` remembers the seen messages;
+// the ballots and transactions older than this are not relayed anyway.
+var DefaultGossipSeenExpire = 1 * time.Minute
+
+// Gossip relays the messages to the random subset of validators instead of
+// sending them to all validators. Every validator forwards the message, which
+// it has not seen before, to `fanout` validators, so the message can reach all
+// the validators even if some links between validators are down.
+type Gossip struct {
+	sync.Mutex
+
+	fanout     int
+	expire     time.Duration
+	seen       map[ /* common.Message.GetHash() */ string]time.Time
+	lastPruned time.Time
+}
+
+func NewGossip(fanout int) *Gossip {
+	return &Gossip{
+		fanout:     fanout,
+		expire:     DefaultGossipSeenExpire,
+		seen:       map[string]time.Time{},
+		lastPruned: time.Now(),
+	}
+}
+
+// Seen records the message of `hash`; it returns `true` when the message was
+// already seen.
+func (g *Gossip) Seen(hash string) bool {
+	g.Lock()
+	defer g.Unlock()
+
+	now := time.Now()
+	if now.Sub(g.lastPruned) > g.expire {
+		for h, t := range g.seen {
+			if now.Sub(t) > g.expire {
+				delete(g.seen, h)
+			}
+		}
+		g.lastPruned = now
+	}
+
+	if _, found := g.seen[hash]; found {
+		return true
+	}
+	g.seen[hash] = now
+
+	return false
+}
+
+// Peers selects `fanout` addresses randomly.
+func (g *Gossip) Peers(addresses []string) []string {
+	peers := make([]string, len(addresses))
+	copy(peers, addresses)
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+
+	if len(peers) > g.fanout {
+		peers = peers[:g.fanout]
+	}
+
+	return peers
+}
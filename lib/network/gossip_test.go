@@ -0,0 +1,37 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipSeen(t *testing.T) {
+	g := NewGossip(2)
+
+	require.False(t, g.Seen("a"))
+	require.True(t, g.Seen("a"))
+	require.False(t, g.Seen("b"))
+
+	// expired
+	g.expire = 0
+	require.False(t, g.Seen("c"))
+	require.False(t, g.Seen("a"))
+}
+
+func TestGossipPeers(t *testing.T) {
+	addresses := []string{"a", "b", "c", "d"}
+
+	g := NewGossip(2)
+	peers := g.Peers(addresses)
+	require.Equal(t, 2, len(peers))
+	require.NotEqual(t, peers[0], peers[1])
+	for _, p := range peers {
+		require.Contains(t, addresses, p)
+	}
+	require.Equal(t, []string{"a", "b", "c", "d"}, addresses)
+
+	// fanout is larger than addresses
+	g = NewGossip(10)
+	require.ElementsMatch(t, addresses, g.Peers(addresses))
+}
@@ -1,6 +1,8 @@
 package network
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	goLog "log"
@@ -65,7 +67,10 @@ type HTTP2Network struct {
 
 	config *HTTP2NetworkConfig
 	node   *node.LocalNode
+	signer *RequestSigner
 	log    logging.Logger
+
+	peerVerifier PeerCertificateVerifier
 }
 
 type HandlerFunc func(w http.ResponseWriter, r *http.Request)
@@ -118,22 +123,92 @@ func NewHTTP2Network(config *HTTP2NetworkConfig) (h2n *HTTP2Network) {
 
 	h2n.SetMessageBroker(HTTP2MessageBroker{network: h2n})
 
+	if config.MutualTLS {
+		// the clients, like wallet, and the watchers do not have certificate,
+		// so the certificate is not required in handshake; the node router
+		// refuses the signed node request without the certificate of signer
+		// by `PeerCertificateMiddleware`.
+		server.TLSConfig.ClientAuth = tls.RequestClientCert
+		server.TLSConfig.VerifyPeerCertificate = h2n.verifyPeerCertificate(nil)
+		h2n.AddMiddleware(RouterNameNode, PeerCertificateMiddleware(httpLog))
+	}
+
 	return
 }
 
+// verifyPeerCertificate verifies the certificate of peer in TLS handshake;
+// the certificate must be bound to the node address and passed by
+// `PeerCertificateVerifier`. For the outgoing connection to `endpoint`, the
+// certificate is required; for the incoming connection, `endpoint` is nil and
+// the connection without certificate is checked by the router.
+func (t *HTTP2Network) verifyPeerCertificate(endpoint *common.Endpoint) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) (err error) {
+		if len(rawCerts) < 1 {
+			if endpoint != nil {
+				return errors.PeerCertificateNotFound
+			}
+			return nil
+		}
+
+		var certs []*x509.Certificate
+		for _, raw := range rawCerts {
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(raw); err != nil {
+				return
+			}
+			certs = append(certs, cert)
+		}
+
+		if _, err = CertificateAddress(certs[0]); err != nil {
+			return
+		}
+		if t.peerVerifier != nil {
+			return t.peerVerifier(endpoint, certs)
+		}
+
+		return nil
+	}
+}
+
 // GetClient creates new keep-alive HTTP2 client
 func (t *HTTP2Network) GetClient(endpoint *common.Endpoint) NetworkClient {
-	rawClient, _ := common.NewHTTP2Client(defaultTimeout, 0, true)
+	var rawClient *common.HTTP2Client
+	if t.config.MutualTLS {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify:    true, // self-signed; the certificate is checked by `verifyPeerCertificate`
+			VerifyPeerCertificate: t.verifyPeerCertificate(endpoint),
+		}
+		if cert, err := tls.LoadX509KeyPair(t.tlsCertFile, t.tlsKeyFile); err != nil {
+			t.log.Error("failed to load certificate for client", "error", err)
+		} else {
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		rawClient, _ = common.NewHTTP2ClientWithTLSConfig(defaultTimeout, 0, true, tlsConfig)
+	} else {
+		rawClient, _ = common.NewHTTP2Client(defaultTimeout, 0, true)
+	}
 
 	client := NewHTTP2NetworkClient(endpoint, rawClient)
 
 	headers := http.Header{}
 	headers.Set("User-Agent", fmt.Sprintf("v-%s", t.config.NodeName))
 	client.SetDefaultHeaders(headers)
+	client.SetRequestSigner(t.signer)
 
 	return client
 }
 
+// SetPeerCertificateVerifier sets the `PeerCertificateVerifier` for mutual
+// TLS.
+func (t *HTTP2Network) SetPeerCertificateVerifier(verifier PeerCertificateVerifier) {
+	t.peerVerifier = verifier
+}
+
+// SetRequestSigner sets the `RequestSigner` of the clients from `GetClient`.
+func (t *HTTP2Network) SetRequestSigner(signer *RequestSigner) {
+	t.signer = signer
+}
+
 func (t *HTTP2Network) Endpoint() *common.Endpoint {
 	return t.config.Endpoint
 }
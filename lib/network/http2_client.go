@@ -16,6 +16,7 @@ type HTTP2NetworkClient struct {
 	endpoint       *common.Endpoint
 	client         *common.HTTP2Client
 	defaultHeaders http.Header
+	signer         *RequestSigner
 }
 
 var (
@@ -39,6 +40,12 @@ func (c *HTTP2NetworkClient) SetDefaultHeaders(headers http.Header) {
 	}
 }
 
+// SetRequestSigner sets the `RequestSigner`; after then, the requests to
+// the node router of the other node are sent with the signature of node.
+func (c *HTTP2NetworkClient) SetRequestSigner(signer *RequestSigner) {
+	c.signer = signer
+}
+
 func (c *HTTP2NetworkClient) DefaultHeaders() http.Header {
 	headers := http.Header{}
 	for key, values := range c.defaultHeaders {
@@ -50,6 +57,15 @@ func (c *HTTP2NetworkClient) DefaultHeaders() http.Header {
 	return headers
 }
 
+// sign signs the request to the other node, if `RequestSigner` is set.
+func (c *HTTP2NetworkClient) sign(headers http.Header, method string, u *url.URL, body []byte) error {
+	if c.signer == nil {
+		return nil
+	}
+
+	return c.signer.Sign(headers, method, u.Path, body)
+}
+
 func (c *HTTP2NetworkClient) resolvePath(path string) (u *url.URL) {
 	u = (*url.URL)(c.endpoint).ResolveReference(&url.URL{Path: path})
 	return u
@@ -81,8 +97,14 @@ func (c *HTTP2NetworkClient) Connect(n node.Node) (body []byte, err error) {
 	headers.Set("Content-Type", "application/json")
 
 	serialized, _ := n.Serialize()
+
+	u := c.resolvePath(UrlPathPrefixNode + "/connect")
+	if err = c.sign(headers, "POST", u, serialized); err != nil {
+		return
+	}
+
 	var response *http.Response
-	response, err = c.client.Post(c.resolvePath(UrlPathPrefixNode+"/connect").String(), serialized, headers)
+	response, err = c.client.Post(u.String(), serialized, headers)
 	if err != nil {
 		return
 	}
@@ -106,6 +128,38 @@ func (c *HTTP2NetworkClient) SendMessage(message common.Serializable) (retBody [
 	}
 
 	u := c.resolvePath(UrlPathPrefixNode + "/message")
+	if err = c.sign(headers, "POST", u, body); err != nil {
+		return
+	}
+
+	var response *http.Response
+	response, err = c.client.Post(u.String(), body, headers)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+	retBody, err = ioutil.ReadAll(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		err = errors.HTTPProblem.Clone().SetData("status", response.StatusCode)
+	}
+
+	return
+}
+
+// SendTransaction sends the transaction through the public API of the other
+// node like the clients; the watcher, which is not one of the validators,
+// can not send the signed node request.
+func (c *HTTP2NetworkClient) SendTransaction(message common.Serializable) (retBody []byte, err error) {
+	headers := c.DefaultHeaders()
+	headers.Set("Content-Type", "application/json")
+
+	var body []byte
+	if body, err = message.Serialize(); err != nil {
+		return
+	}
+
+	u := c.resolvePath(UrlPathPrefixAPI + "/v1/transactions")
 
 	var response *http.Response
 	response, err = c.client.Post(u.String(), body, headers)
@@ -132,6 +186,9 @@ func (c *HTTP2NetworkClient) SendBallot(message common.Serializable) (retBody []
 	}
 
 	u := c.resolvePath(UrlPathPrefixNode + "/ballot")
+	if err = c.sign(headers, "POST", u, body); err != nil {
+		return
+	}
 
 	var response *http.Response
 	response, err = c.client.Post(u.String(), body, headers)
@@ -158,6 +215,9 @@ func (c *HTTP2NetworkClient) GetTransactions(txs []string) (retBody []byte, err
 	}
 
 	u := c.resolvePath(UrlPathPrefixNode + "/transactions")
+	if err = c.sign(headers, "POST", u, body); err != nil {
+		return
+	}
 
 	var response *http.Response
 	response, err = c.client.Post(u.String(), body, headers)
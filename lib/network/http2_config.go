@@ -2,6 +2,7 @@ package network
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +21,10 @@ type HTTP2NetworkConfig struct {
 
 	TLSCertFile,
 	TLSKeyFile string
+
+	// MutualTLS requires the certificates of validators, which are bound to
+	// their addresses, between the validators.
+	MutualTLS bool
 }
 
 func NewHTTP2NetworkConfigFromEndpoint(nodeName string, endpoint *common.Endpoint) (config *HTTP2NetworkConfig, err error) {
@@ -62,6 +67,15 @@ func NewHTTP2NetworkConfigFromEndpoint(nodeName string, endpoint *common.Endpoin
 		return
 	}
 
+	var MutualTLS bool
+	if MutualTLS, err = strconv.ParseBool(common.GetUrlQuery(query, "MutualTLS", "false")); err != nil {
+		return
+	}
+	if MutualTLS && strings.ToLower(endpoint.Scheme) != "https" {
+		err = errors.New("`MutualTLS` needs HTTPS")
+		return
+	}
+
 	config = &HTTP2NetworkConfig{
 		NodeName:          nodeName,
 		Endpoint:          endpoint,
@@ -72,6 +86,7 @@ func NewHTTP2NetworkConfigFromEndpoint(nodeName string, endpoint *common.Endpoin
 		IdleTimeout:       0,
 		TLSCertFile:       TLSCertFile,
 		TLSKeyFile:        TLSKeyFile,
+		MutualTLS:         MutualTLS,
 	}
 
 	return
@@ -12,9 +12,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stellar/go/keypair"
 	"github.com/stretchr/testify/require"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/node"
 )
 
 func getPort() string {
@@ -153,3 +155,161 @@ func TestHTTP2NetworkWithoutTLS(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func makeTestHTTP2NetworkConfigForMutualTLS(t *testing.T, g *KeyGenerator) *HTTP2NetworkConfig {
+	queryValues := url.Values{}
+	queryValues.Set("TLSCertFile", g.GetCertPath())
+	queryValues.Set("TLSKeyFile", g.GetKeyPath())
+	queryValues.Set("MutualTLS", "true")
+
+	endpoint := &common.Endpoint{
+		Scheme:   "https",
+		Host:     fmt.Sprintf("localhost:%s", getPort()),
+		RawQuery: queryValues.Encode(),
+	}
+
+	config, err := NewHTTP2NetworkConfigFromEndpoint("showme", endpoint)
+	require.NoError(t, err)
+	require.True(t, config.MutualTLS)
+
+	return config
+}
+
+// TestHTTP2NetworkMutualTLS checks the connections between validators need
+// the certificates, which are bound to the validators.
+func TestHTTP2NetworkMutualTLS(t *testing.T) {
+	networkID := []byte("sebak-test-network")
+
+	kpServer, _ := keypair.Random()
+	kpClient, _ := keypair.Random()
+	kpOther, _ := keypair.Random()
+	kpUnknown, _ := keypair.Random()
+
+	gServer := NewKeyGeneratorWithKeypair("tls_tmp", "server.cert", "server.key", kpServer)
+	defer gServer.Close()
+	gClient := NewKeyGeneratorWithKeypair("tls_tmp", "client.cert", "client.key", kpClient)
+	defer gClient.Close()
+	gUnknown := NewKeyGeneratorWithKeypair("tls_tmp", "unknown.cert", "unknown.key", kpUnknown)
+	defer gUnknown.Close()
+	gNotBound := NewKeyGenerator("tls_tmp", "notbound.cert", "notbound.key")
+	defer gNotBound.Close()
+
+	serverConfig := makeTestHTTP2NetworkConfigForMutualTLS(t, gServer)
+
+	validators := map[string]*node.Validator{}
+	for _, kp := range []*keypair.Full{kpServer, kpClient, kpOther} {
+		endpoint := &common.Endpoint{}
+		if kp == kpServer {
+			endpoint = serverConfig.Endpoint
+		}
+		v, _ := node.NewValidator(kp.Address(), endpoint, "")
+		validators[kp.Address()] = v
+	}
+	cm := &ValidatorConnectionManager{validators: validators}
+
+	localNode, _ := node.NewLocalNode(kpServer, serverConfig.Endpoint, "")
+	for _, v := range validators {
+		localNode.AddValidators(v)
+	}
+
+	server, err := makeTestHTTP2NetworkForTLS(serverConfig.Endpoint)
+	require.NoError(t, err)
+	defer server.Stop()
+	server.SetPeerCertificateVerifier(cm.VerifyPeerCertificate)
+	server.AddMiddleware(RouterNameNode, NodeRequestVerifyMiddleware(
+		nil,
+		NewRequestVerifier(localNode, networkID, DefaultRequestTimeWindow),
+		UrlPathPrefixNode+"/ballot",
+	))
+	server.AddHandler(UrlPathPrefixNode+"/ballot", func(w http.ResponseWriter, r *http.Request) {})
+	server.AddHandler(UrlPathPrefixNode+"/blocks", func(w http.ResponseWriter, r *http.Request) {})
+
+	newClient := func(g *KeyGenerator, kp *keypair.Full) NetworkClient {
+		n := NewHTTP2Network(makeTestHTTP2NetworkConfigForMutualTLS(t, g))
+		n.SetPeerCertificateVerifier(cm.VerifyPeerCertificate)
+		n.SetRequestSigner(NewRequestSigner(kp, networkID))
+		return n.GetClient(serverConfig.Endpoint)
+	}
+
+	{ // validator
+		_, err := newClient(gClient, kpClient).SendBallot(NewDummyMessage("ballot"))
+		require.NoError(t, err)
+	}
+
+	{ // signed by the other validator
+		_, err := newClient(gClient, kpOther).SendBallot(NewDummyMessage("ballot"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), fmt.Sprintf(`"status":%d`, http.StatusForbidden))
+	}
+
+	{ // certificate of unknown node
+		_, err := newClient(gUnknown, kpUnknown).SendBallot(NewDummyMessage("ballot"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), fmt.Sprintf(`"status":%d`, http.StatusForbidden))
+	}
+
+	{ // certificate is not bound
+		_, err := newClient(gNotBound, kpClient).SendBallot(NewDummyMessage("ballot"))
+		require.Error(t, err)
+	}
+
+	{ // without certificate, like wallet
+		client, err := common.NewHTTP2Client(defaultTimeout, defaultIdleTimeout, false)
+		require.NoError(t, err)
+
+		_, err = client.Get(serverConfig.Endpoint.String(), http.Header{})
+		require.NoError(t, err)
+
+		// the ballot needs the signed request
+		u := (*url.URL)(serverConfig.Endpoint).ResolveReference(&url.URL{Path: UrlPathPrefixNode + "/ballot"})
+		response, err := client.Post(u.String(), []byte("{}"), http.Header{})
+		require.NoError(t, err)
+		response.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		// the signed request needs the certificate of signer
+		headers := http.Header{}
+		body := []byte("{}")
+		NewRequestSigner(kpClient, networkID).Sign(headers, "POST", u.Path, body)
+		response, err = client.Post(u.String(), body, headers)
+		require.NoError(t, err)
+		response.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	}
+
+	{ // the watcher without certificate and the new node, which is not
+		// validator, can read the blocks for sync
+		watcher, err := common.NewHTTP2Client(defaultTimeout, defaultIdleTimeout, false)
+		require.NoError(t, err)
+
+		u := (*url.URL)(serverConfig.Endpoint).ResolveReference(&url.URL{Path: UrlPathPrefixNode + "/blocks"})
+		response, err := watcher.Get(u.String(), http.Header{})
+		require.NoError(t, err)
+		response.Body.Close()
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		n := NewHTTP2Network(makeTestHTTP2NetworkConfigForMutualTLS(t, gUnknown))
+		n.SetPeerCertificateVerifier(cm.VerifyPeerCertificate)
+		response, err = n.GetClient(serverConfig.Endpoint).(*HTTP2NetworkClient).client.Get(u.String(), http.Header{})
+		require.NoError(t, err)
+		response.Body.Close()
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	}
+
+	{ // server is not the validator of endpoint
+		other := validators[kpOther.Address()]
+		validators[kpServer.Address()], _ = node.NewValidator(kpServer.Address(), &common.Endpoint{}, "")
+		validators[kpOther.Address()], _ = node.NewValidator(kpOther.Address(), serverConfig.Endpoint, "")
+		_, err := newClient(gClient, kpClient).SendBallot(NewDummyMessage("ballot"))
+		require.Error(t, err)
+
+		validators[kpServer.Address()], _ = node.NewValidator(kpServer.Address(), serverConfig.Endpoint, "")
+		validators[kpOther.Address()] = other
+	}
+
+	{ // server is not validator
+		delete(validators, kpServer.Address())
+		_, err := newClient(gClient, kpClient).SendBallot(NewDummyMessage("ballot"))
+		require.Error(t, err)
+	}
+}
@@ -142,3 +142,43 @@ func RateLimitMiddleware(logger logging.Logger, rule common.RateLimitRule) mux.M
 		})
 	}
 }
+
+// PeerCertificateMiddleware refuses the signed node request without the peer
+// certificate in mutual TLS; the signed node request must come through the
+// connection of same node, so the address of peer certificate must be the
+// signer of request. The unsigned requests, like the node info and the blocks
+// for the watchers and the new nodes, are passed and the paths, which need
+// the signature, are checked by `NodeRequestVerifyMiddleware`.
+func PeerCertificateMiddleware(logger logging.Logger) mux.MiddlewareFunc {
+	if logger == nil {
+		logger = log
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signer := r.Header.Get(HeaderNodeAddress)
+			if len(signer) < 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var err error
+			if r.TLS == nil || len(r.TLS.PeerCertificates) < 1 {
+				err = errors.PeerCertificateNotFound
+			} else {
+				var address string
+				if address, err = CertificateAddress(r.TLS.PeerCertificates[0]); err == nil && address != signer {
+					err = errors.PeerCertificateMismatch
+				}
+			}
+
+			if err != nil {
+				logger.Debug("invalid peer certificate", "path", r.URL.Path, "node", signer, "error", err)
+				httputils.WriteJSONError(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
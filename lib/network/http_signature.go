@@ -0,0 +1,190 @@
+package network
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/gorilla/mux"
+	logging "github.com/inconshreveable/log15"
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node"
+)
+
+const (
+	HeaderNodeAddress   = "X-Sebak-Node"
+	HeaderNodeTimestamp = "X-Sebak-Timestamp"
+	HeaderNodeNonce     = "X-Sebak-Nonce"
+	HeaderNodeSignature = "X-Sebak-Signature"
+)
+
+// DefaultRequestTimeWindow is the allowed difference between the timestamp of
+// signed request and the local time.
+var DefaultRequestTimeWindow = 30 * time.Second
+
+// makeRequestHash makes the hash of signed request; the signature covers the
+// method, path, signer, timestamp, nonce and body.
+func makeRequestHash(method, path, address, timestamp, nonce string, body []byte) string {
+	return common.MustMakeObjectHashString([]interface{}{method, path, address, timestamp, nonce, body})
+}
+
+// RequestSigner signs the requests between nodes with the node keypair.
+type RequestSigner struct {
+	kp        *keypair.Full
+	networkID []byte
+}
+
+func NewRequestSigner(kp *keypair.Full, networkID []byte) *RequestSigner {
+	return &RequestSigner{kp: kp, networkID: networkID}
+}
+
+// Sign sets the signature headers of request to `headers`.
+func (s *RequestSigner) Sign(headers http.Header, method, path string, body []byte) (err error) {
+	timestamp := strconv.FormatInt(time.Now().UnixNano(), 10)
+	nonce := common.GenerateUUID()
+
+	hash := makeRequestHash(method, path, s.kp.Address(), timestamp, nonce, body)
+
+	var signature []byte
+	if signature, err = common.MakeSignature(s.kp, s.networkID, hash); err != nil {
+		return
+	}
+
+	headers.Set(HeaderNodeAddress, s.kp.Address())
+	headers.Set(HeaderNodeTimestamp, timestamp)
+	headers.Set(HeaderNodeNonce, nonce)
+	headers.Set(HeaderNodeSignature, base58.Encode(signature))
+
+	return
+}
+
+// RequestVerifier verifies the signed requests from the validators of
+// `localNode`. The nonce of verified request is kept during the time window,
+// so the same request can not be replayed; the request older than the window
+// is rejected by it's timestamp. The expired nonces are pruned once in the
+// window.
+type RequestVerifier struct {
+	sync.Mutex
+
+	localNode  *node.LocalNode
+	networkID  []byte
+	window     time.Duration
+	nonces     map[ /* address + nonce */ string]time.Time
+	lastPruned time.Time
+}
+
+func NewRequestVerifier(localNode *node.LocalNode, networkID []byte, window time.Duration) *RequestVerifier {
+	return &RequestVerifier{
+		localNode:  localNode,
+		networkID:  networkID,
+		window:     window,
+		nonces:     map[string]time.Time{},
+		lastPruned: time.Now(),
+	}
+}
+
+func (v *RequestVerifier) Verify(r *http.Request, body []byte) (err error) {
+	address := r.Header.Get(HeaderNodeAddress)
+	timestamp := r.Header.Get(HeaderNodeTimestamp)
+	nonce := r.Header.Get(HeaderNodeNonce)
+	signature := r.Header.Get(HeaderNodeSignature)
+	if len(address) < 1 || len(timestamp) < 1 || len(nonce) < 1 || len(signature) < 1 {
+		return errors.NodeRequestNotSigned
+	}
+
+	if !v.localNode.HasValidators(address) {
+		return errors.NodeRequestFromUnknownNode
+	}
+
+	var kp keypair.KP
+	if kp, err = keypair.Parse(address); err != nil {
+		return errors.NodeRequestInvalidSignature
+	}
+	hash := makeRequestHash(r.Method, r.URL.Path, address, timestamp, nonce, body)
+	if err = kp.Verify(append(v.networkID, []byte(hash)...), base58.Decode(signature)); err != nil {
+		return errors.NodeRequestInvalidSignature
+	}
+
+	var unixNano int64
+	if unixNano, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return errors.NodeRequestInvalidSignature
+	}
+	signed := time.Unix(0, unixNano)
+	now := time.Now()
+	if signed.Before(now.Add(-v.window)) || signed.After(now.Add(v.window)) {
+		return errors.NodeRequestExpired
+	}
+
+	v.Lock()
+	defer v.Unlock()
+
+	if now.Sub(v.lastPruned) > v.window {
+		for key, expire := range v.nonces {
+			if expire.Before(now) {
+				delete(v.nonces, key)
+			}
+		}
+		v.lastPruned = now
+	}
+
+	key := address + nonce
+	if expire, found := v.nonces[key]; found && !expire.Before(now) {
+		return errors.NodeRequestReplayed
+	}
+	v.nonces[key] = signed.Add(v.window)
+
+	return nil
+}
+
+// NodeRequestVerifyMiddleware verifies the signed requests on the node router;
+// the requests to `requiredPaths` must be signed by the validator. The
+// unsigned requests to the other paths, like the node info and the blocks for
+// sync, are passed for the watchers, which are not the validators.
+func NodeRequestVerifyMiddleware(logger logging.Logger, verifier *RequestVerifier, requiredPaths ...string) mux.MiddlewareFunc {
+	if logger == nil {
+		logger = log
+	}
+
+	required := map[string]bool{}
+	for _, path := range requiredPaths {
+		required[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !required[r.URL.Path] && len(r.Header.Get(HeaderNodeSignature)) < 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				httputils.WriteJSONError(w, errors.HTTPServerError.Clone().SetData("error", err.Error()))
+				return
+			}
+
+			if err = verifier.Verify(r, body); err != nil {
+				logger.Debug(
+					"failed to verify node request",
+					"path", r.URL.Path,
+					"node", r.Header.Get(HeaderNodeAddress),
+					"error", err,
+				)
+				httputils.WriteJSONError(w, err)
+				return
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package network
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/gorilla/mux"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node"
+)
+
+func testSignedRequest(signer *RequestSigner, path string, body []byte) *http.Request {
+	r := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	if signer != nil {
+		signer.Sign(r.Header, "POST", path, body)
+	}
+	return r
+}
+
+func TestRequestVerifier(t *testing.T) {
+	networkID := []byte("sebak-test-network")
+	endpoint, _ := common.NewEndpointFromString("http://localhost:12345")
+
+	kp, _ := keypair.Random()
+	localNode, _ := node.NewLocalNode(kp, endpoint, "")
+
+	kpValidator, _ := keypair.Random()
+	validator, _ := node.NewValidator(kpValidator.Address(), endpoint, "")
+	localNode.AddValidators(validator)
+
+	verifier := NewRequestVerifier(localNode, networkID, DefaultRequestTimeWindow)
+	signer := NewRequestSigner(kpValidator, networkID)
+
+	path := UrlPathPrefixNode + "/ballot"
+	body := []byte(`{"ballot": 1}`)
+
+	{ // signed by validator
+		r := testSignedRequest(signer, path, body)
+		require.NoError(t, verifier.Verify(r, body))
+
+		// same request again
+		require.Equal(t, errors.NodeRequestReplayed, verifier.Verify(r, body))
+	}
+
+	{ // not signed
+		r := testSignedRequest(nil, path, body)
+		require.Equal(t, errors.NodeRequestNotSigned, verifier.Verify(r, body))
+	}
+
+	{ // signed by unknown node
+		kpUnknown, _ := keypair.Random()
+		r := testSignedRequest(NewRequestSigner(kpUnknown, networkID), path, body)
+		require.Equal(t, errors.NodeRequestFromUnknownNode, verifier.Verify(r, body))
+	}
+
+	{ // body is changed
+		r := testSignedRequest(signer, path, body)
+		require.Equal(t, errors.NodeRequestInvalidSignature, verifier.Verify(r, []byte(`{"ballot": 2}`)))
+	}
+
+	{ // different network
+		r := testSignedRequest(NewRequestSigner(kpValidator, []byte("another-network")), path, body)
+		require.Equal(t, errors.NodeRequestInvalidSignature, verifier.Verify(r, body))
+	}
+
+	{ // old request
+		r := testSignedRequest(nil, path, body)
+		timestamp := strconv.FormatInt(time.Now().Add(-2*DefaultRequestTimeWindow).UnixNano(), 10)
+		nonce := common.GenerateUUID()
+		hash := makeRequestHash("POST", path, kpValidator.Address(), timestamp, nonce, body)
+		signature, _ := common.MakeSignature(kpValidator, networkID, hash)
+
+		r.Header.Set(HeaderNodeAddress, kpValidator.Address())
+		r.Header.Set(HeaderNodeTimestamp, timestamp)
+		r.Header.Set(HeaderNodeNonce, nonce)
+		r.Header.Set(HeaderNodeSignature, base58.Encode(signature))
+		require.Equal(t, errors.NodeRequestExpired, verifier.Verify(r, body))
+	}
+}
+
+func TestRequestVerifierPruneNonces(t *testing.T) {
+	networkID := []byte("sebak-test-network")
+	endpoint, _ := common.NewEndpointFromString("http://localhost:12345")
+
+	kp, _ := keypair.Random()
+	localNode, _ := node.NewLocalNode(kp, endpoint, "")
+
+	kpValidator, _ := keypair.Random()
+	validator, _ := node.NewValidator(kpValidator.Address(), endpoint, "")
+	localNode.AddValidators(validator)
+
+	verifier := NewRequestVerifier(localNode, networkID, DefaultRequestTimeWindow)
+	signer := NewRequestSigner(kpValidator, networkID)
+
+	path := UrlPathPrefixNode + "/ballot"
+	body := []byte(`{"ballot": 1}`)
+
+	require.NoError(t, verifier.Verify(testSignedRequest(signer, path, body), body))
+	require.Equal(t, 1, len(verifier.nonces))
+
+	// the expired nonce is kept until the window passes from the last pruning
+	for key := range verifier.nonces {
+		verifier.nonces[key] = time.Now().Add(-time.Second)
+	}
+	require.NoError(t, verifier.Verify(testSignedRequest(signer, path, body), body))
+	require.Equal(t, 2, len(verifier.nonces))
+
+	verifier.lastPruned = time.Now().Add(-2 * DefaultRequestTimeWindow)
+	require.NoError(t, verifier.Verify(testSignedRequest(signer, path, body), body))
+	require.Equal(t, 2, len(verifier.nonces))
+}
+
+func TestNodeRequestVerifyMiddleware(t *testing.T) {
+	networkID := []byte("sebak-test-network")
+	endpoint, _ := common.NewEndpointFromString("http://localhost:12345")
+
+	kp, _ := keypair.Random()
+	localNode, _ := node.NewLocalNode(kp, endpoint, "")
+	localNode.AddValidators(localNode.ConvertToValidator())
+
+	ballotURL := UrlPathPrefixNode + "/ballot"
+	messageURL := UrlPathPrefixNode + "/message"
+
+	var received []byte
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		received = buf.Bytes()
+	}
+
+	router := mux.NewRouter()
+	router.Use(NodeRequestVerifyMiddleware(
+		nil,
+		NewRequestVerifier(localNode, networkID, DefaultRequestTimeWindow),
+		ballotURL,
+	))
+	router.HandleFunc(ballotURL, http.HandlerFunc(handler)).Methods("POST")
+	router.HandleFunc(messageURL, http.HandlerFunc(handler)).Methods("POST")
+
+	signer := NewRequestSigner(kp, networkID)
+	body := []byte(`{"ballot": 1}`)
+
+	{ // unsigned ballot is rejected
+		received = nil
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, testSignedRequest(nil, ballotURL, body))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.Nil(t, received)
+	}
+
+	{ // signed ballot reaches handler with the same body
+		received = nil
+		w := httptest.NewRecorder()
+		r := testSignedRequest(signer, ballotURL, body)
+		router.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, body, received)
+
+		// replayed
+		received = nil
+		w = httptest.NewRecorder()
+		replayed := testSignedRequest(nil, ballotURL, body)
+		replayed.Header = r.Header
+		router.ServeHTTP(w, replayed)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.Nil(t, received)
+	}
+
+	{ // unsigned message is passed
+		received = nil
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, testSignedRequest(nil, messageURL, body))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, body, received)
+	}
+
+	{ // signed message by unknown node is rejected
+		kpUnknown, _ := keypair.Random()
+		received = nil
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, testSignedRequest(NewRequestSigner(kpUnknown, networkID), messageURL, body))
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Nil(t, received)
+	}
+}
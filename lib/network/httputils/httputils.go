@@ -19,9 +19,20 @@ func IsEventStream(r *http.Request) bool {
 var (
 	// ErrorsToStatus defines errors.Error does not have 400 status code.
 	ErrorsToStatus = map[uint]int{
-		errors.TooManyRequests.Code:               http.StatusTooManyRequests,
-		errors.BlockTransactionDoesNotExists.Code: http.StatusNotFound,
-		errors.BlockAccountDoesNotExists.Code:     http.StatusNotFound,
+		errors.TooManyRequests.Code:                http.StatusTooManyRequests,
+		errors.BlockTransactionDoesNotExists.Code:  http.StatusNotFound,
+		errors.BlockAccountDoesNotExists.Code:      http.StatusNotFound,
+		errors.BlockNotFound.Code:                  http.StatusNotFound,
+		errors.EvidenceNotFound.Code:               http.StatusNotFound,
+		errors.NodeRequestNotSigned.Code:           http.StatusUnauthorized,
+		errors.NodeRequestInvalidSignature.Code:    http.StatusUnauthorized,
+		errors.NodeRequestFromUnknownNode.Code:     http.StatusForbidden,
+		errors.NodeRequestExpired.Code:             http.StatusUnauthorized,
+		errors.NodeRequestReplayed.Code:            http.StatusUnauthorized,
+		errors.PeerCertificateNotFound.Code:        http.StatusUnauthorized,
+		errors.PeerCertificateNotBound.Code:        http.StatusUnauthorized,
+		errors.PeerCertificateFromUnknownNode.Code: http.StatusForbidden,
+		errors.PeerCertificateMismatch.Code:        http.StatusForbidden,
 	}
 )
 
@@ -3,6 +3,7 @@ package network
 import (
 	"net"
 	"net/http"
+	"sync"
 
 	"boscoin.io/sebak/lib/common"
 	"github.com/google/uuid"
@@ -20,6 +21,9 @@ type MemoryNetwork struct {
 	peers map[ /* endpoint */ string]*MemoryNetwork
 
 	messageBroker MessageBroker
+
+	linksLock sync.RWMutex
+	downLinks map[ /* endpoint */ string]bool
 }
 
 func (t *MemoryNetwork) GetClient(endpoint *common.Endpoint) NetworkClient {
@@ -28,7 +32,31 @@ func (t *MemoryNetwork) GetClient(endpoint *common.Endpoint) NetworkClient {
 		panic("Trying to get inexistant client, this is a bug in the tests!")
 	}
 
-	return NewMemoryNetworkClient(endpoint, n)
+	client := NewMemoryNetworkClient(endpoint, n)
+	client.local = t
+
+	return client
+}
+
+// SetLinkDown breaks the link between the two networks to simulate the network
+// failure; the messages between them are not delivered.
+func (p *MemoryNetwork) SetLinkDown(peer *MemoryNetwork, down bool) {
+	p.setLinkDown(peer.endpoint, down)
+	peer.setLinkDown(p.endpoint, down)
+}
+
+func (p *MemoryNetwork) setLinkDown(endpoint *common.Endpoint, down bool) {
+	p.linksLock.Lock()
+	defer p.linksLock.Unlock()
+
+	p.downLinks[endpoint.String()] = down
+}
+
+func (p *MemoryNetwork) isLinkDown(endpoint *common.Endpoint) bool {
+	p.linksLock.RLock()
+	defer p.linksLock.RUnlock()
+
+	return p.downLinks[endpoint.String()]
 }
 
 func (p *MemoryNetwork) AddWatcher(f func(Network, net.Conn, http.ConnState)) {
@@ -118,6 +146,7 @@ func (prev *MemoryNetwork) NewMemoryNetwork() *MemoryNetwork {
 		receiveChannel: make(chan common.NetworkMessage),
 		close:          make(chan bool),
 		peers:          peers,
+		downLinks:      map[string]bool{},
 	}
 
 	n.peers[n.endpoint.String()] = n
@@ -132,3 +161,11 @@ func (p *MemoryNetwork) AddHandler(string, http.HandlerFunc) *mux.Route {
 func (p *MemoryNetwork) AddMiddleware(string, ...mux.MiddlewareFunc) error {
 	return nil
 }
+
+func (p *MemoryNetwork) SetRequestSigner(*RequestSigner) {
+	return
+}
+
+func (p *MemoryNetwork) SetPeerCertificateVerifier(PeerCertificateVerifier) {
+	return
+}
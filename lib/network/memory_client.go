@@ -10,6 +10,7 @@ type MemoryTransportClient struct {
 	endpoint *common.Endpoint
 
 	server *MemoryNetwork
+	local  *MemoryNetwork
 }
 
 func NewMemoryNetworkClient(endpoint *common.Endpoint, server *MemoryNetwork) *MemoryTransportClient {
@@ -23,17 +24,38 @@ func (m *MemoryTransportClient) Endpoint() *common.Endpoint {
 	return m.endpoint
 }
 
+// isLinkDown checks the link to the server is down by
+// `MemoryNetwork.SetLinkDown`.
+func (m *MemoryTransportClient) isLinkDown() bool {
+	return m.local != nil && m.local.isLinkDown(m.endpoint)
+}
+
 func (m *MemoryTransportClient) Connect(node node.Node) (b []byte, err error) {
+	if m.isLinkDown() {
+		err = errors.MemoryNetworkLinkDown
+		return
+	}
+
 	b = m.server.GetNodeInfo()
 	return
 }
 
 func (m *MemoryTransportClient) GetNodeInfo() (b []byte, err error) {
+	if m.isLinkDown() {
+		err = errors.MemoryNetworkLinkDown
+		return
+	}
+
 	b = m.server.GetNodeInfo()
 	return
 }
 
 func (m *MemoryTransportClient) SendMessage(message common.Serializable) (body []byte, err error) {
+	if m.isLinkDown() {
+		err = errors.MemoryNetworkLinkDown
+		return
+	}
+
 	var s []byte
 	if s, err = message.Serialize(); err != nil {
 		return
@@ -43,7 +65,16 @@ func (m *MemoryTransportClient) SendMessage(message common.Serializable) (body [
 	return
 }
 
+func (m *MemoryTransportClient) SendTransaction(message common.Serializable) ([]byte, error) {
+	return m.SendMessage(message)
+}
+
 func (m *MemoryTransportClient) SendBallot(message common.Serializable) (body []byte, err error) {
+	if m.isLinkDown() {
+		err = errors.MemoryNetworkLinkDown
+		return
+	}
+
 	var s []byte
 	if s, err = message.Serialize(); err != nil {
 		return
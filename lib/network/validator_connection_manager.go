@@ -1,13 +1,14 @@
 package network
 
 import (
-	"errors"
+	"crypto/x509"
 	"net"
 	"net/http"
 	"sync"
 	"time"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/voting"
 	logging "github.com/inconshreveable/log15"
@@ -24,6 +25,10 @@ type ValidatorConnectionManager struct {
 	clients    map[ /* node.Address() */ string]NetworkClient
 	connected  map[ /* node.Address() */ string]bool
 
+	gossip           *Gossip
+	started          bool
+	proposerSelector string
+
 	log logging.Logger
 }
 
@@ -31,6 +36,7 @@ func NewValidatorConnectionManager(
 	localNode *node.LocalNode,
 	network Network,
 	policy voting.ThresholdPolicy,
+	conf common.Config,
 ) ConnectionManager {
 	if len(localNode.GetValidators()) == 0 {
 		panic("empty validators")
@@ -42,15 +48,59 @@ func NewValidatorConnectionManager(
 		policy:     policy,
 		validators: localNode.GetValidators(),
 
-		clients:   map[string]NetworkClient{},
-		connected: map[string]bool{},
-		log:       log.New(logging.Ctx{"node": localNode.Alias()}),
+		clients:          map[string]NetworkClient{},
+		connected:        map[string]bool{},
+		proposerSelector: conf.ProposerSelector,
+		log:              log.New(logging.Ctx{"node": localNode.Alias()}),
+	}
+	// the watcher node is not one of the validators
+	if !localNode.IsWatcher() {
+		cm.connected[localNode.Address()] = true
+	}
+
+	if conf.GossipFanout > 0 {
+		cm.gossip = NewGossip(conf.GossipFanout)
+	}
+
+	if network != nil {
+		network.SetPeerCertificateVerifier(cm.VerifyPeerCertificate)
 	}
-	cm.connected[localNode.Address()] = true
 
 	return cm
 }
 
+// VerifyPeerCertificate refuses the outgoing connection in mutual TLS, when
+// the certificate of peer is not bound to the validator of `endpoint`. The
+// incoming connection from the node, which is not one of the validators, like
+// the new node for sync, is accepted; the node router refuses the signed
+// node request from it.
+func (c *ValidatorConnectionManager) VerifyPeerCertificate(endpoint *common.Endpoint, certs []*x509.Certificate) (err error) {
+	if len(certs) < 1 {
+		return errors.PeerCertificateNotFound
+	}
+
+	var address string
+	if address, err = CertificateAddress(certs[0]); err != nil {
+		return
+	}
+	if endpoint == nil {
+		return nil
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	validator, found := c.validators[address]
+	if !found {
+		return errors.PeerCertificateFromUnknownNode
+	}
+	if validator.Endpoint().String() != endpoint.String() {
+		return errors.PeerCertificateMismatch
+	}
+
+	return nil
+}
+
 func (c *ValidatorConnectionManager) GetNodeAddress() string {
 	return c.localNode.Address()
 }
@@ -79,6 +129,11 @@ func (c *ValidatorConnectionManager) GetConnection(address string) (client Netwo
 }
 
 func (c *ValidatorConnectionManager) Start() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.started = true
+
 	c.log.Debug("starting to connect to validators", "validators", c.validators)
 	for _, v := range c.validators {
 		if v.Address() == c.localNode.Address() {
@@ -88,12 +143,72 @@ func (c *ValidatorConnectionManager) Start() {
 	}
 }
 
+// UpdateValidators reloads the validators from `LocalNode` after the
+// validator set is changed; the removed validators are disconnected and the
+// new validators are connected.
+func (c *ValidatorConnectionManager) UpdateValidators() {
+	validators := c.localNode.GetValidators()
+
+	c.Lock()
+	defer c.Unlock()
+
+	for address := range c.validators {
+		if _, found := validators[address]; found {
+			continue
+		}
+		delete(c.clients, address)
+		delete(c.connected, address)
+	}
+
+	var added []*node.Validator
+	for address, v := range validators {
+		if old, found := c.validators[address]; found {
+			if old.Endpoint().String() == v.Endpoint().String() {
+				validators[address] = old
+				continue
+			}
+			delete(c.clients, address) // endpoint is changed
+		}
+		if address == c.localNode.Address() {
+			continue
+		}
+		added = append(added, v)
+	}
+
+	c.validators = validators
+	if _, found := validators[c.localNode.Address()]; found {
+		c.connected[c.localNode.Address()] = true
+	}
+	c.policy.SetConnected(c.countConnectedUnlocked())
+
+	c.log.Debug("validators updated", "validators", c.validators, "added", added)
+
+	if !c.started {
+		return
+	}
+	for _, v := range added {
+		go c.connectingValidator(v)
+	}
+}
+
+// isValidator checks the validator is still in the validator set.
+func (c *ValidatorConnectionManager) isValidator(v *node.Validator) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.validators[v.Address()] == v
+}
+
 // setConnected returns `true` when the validator is newly connected or
 // disconnected at first
 func (c *ValidatorConnectionManager) setConnected(v *node.Validator, connected bool) bool {
 	c.Lock()
 	defer c.Unlock()
 
+	if c.validators[v.Address()] != v { // removed from the validator set
+		return false
+	}
+
 	old, found := c.connected[v.Address()]
 	c.connected[v.Address()] = connected
 
@@ -118,6 +233,9 @@ func (c *ValidatorConnectionManager) AllConnected() []string {
 // Returns:
 //   A list of all validators
 func (c *ValidatorConnectionManager) AllValidators() []string {
+	c.RLock()
+	defer c.RUnlock()
+
 	var validators []string
 	for address := range c.validators {
 		validators = append(validators, address)
@@ -147,7 +265,14 @@ func (c *ValidatorConnectionManager) countConnectedUnlocked() int {
 
 func (c *ValidatorConnectionManager) connectingValidator(v *node.Validator) {
 	ticker := time.NewTicker(time.Second * 1)
+	defer ticker.Stop()
+
 	for _ = range ticker.C {
+		if !c.isValidator(v) {
+			c.log.Debug("stop connecting to the removed validator", "validator", v)
+			return
+		}
+
 		err := c.connectValidator(v)
 
 		if c.setConnected(v, err == nil) {
@@ -165,8 +290,14 @@ func (c *ValidatorConnectionManager) connectingValidator(v *node.Validator) {
 func (c *ValidatorConnectionManager) connectValidator(v *node.Validator) (err error) {
 	client := c.GetConnection(v.Address())
 
+	// the watcher is not one of the validators, so it only checks the node
+	// info of validator
 	var b []byte
-	b, err = client.Connect(c.localNode)
+	if c.localNode.IsWatcher() {
+		b, err = client.GetNodeInfo()
+	} else {
+		b, err = client.Connect(c.localNode)
+	}
 	if err != nil {
 		return
 	}
@@ -182,6 +313,43 @@ func (c *ValidatorConnectionManager) connectValidator(v *node.Validator) (err er
 		return
 	}
 
+	if !c.localNode.IsWatcher() {
+		err = c.checkProposerSelector(v, client)
+	}
+
+	return
+}
+
+// checkProposerSelector refuses the validator, which uses the different
+// proposer selector; the validators, which select the different proposers,
+// can not agree. The validator, which does not tell it's selector, is
+// accepted with warning.
+func (c *ValidatorConnectionManager) checkProposerSelector(v *node.Validator, client NetworkClient) (err error) {
+	var b []byte
+	if b, err = client.GetNodeInfo(); err != nil {
+		return
+	}
+
+	var info node.NodeInfo
+	if info, err = node.NewNodeInfoFromJSON(b); err != nil {
+		return
+	}
+
+	selector := info.Policy.ProposerSelector
+	if selector == "" {
+		c.log.Warn("validator does not tell the proposer selector", "validator", v)
+		return
+	}
+	if selector != c.proposerSelector {
+		c.log.Error(
+			"validator uses the different proposer selector",
+			"validator", v,
+			"selector", selector,
+			"expected", c.proposerSelector,
+		)
+		return errors.ProposerSelectorMismatch
+	}
+
 	return
 }
 
@@ -189,29 +357,84 @@ func (c *ValidatorConnectionManager) ConnectionWatcher(t Network, conn net.Conn,
 	return
 }
 
+// Broadcast sends the message to all the connected validators; in gossip
+// mode, the message is sent to the random subset of validators and they
+// relay it by `Relay`.
 func (c *ValidatorConnectionManager) Broadcast(message common.Message) {
+	if c.gossip == nil {
+		c.send(message, c.AllConnected())
+		return
+	}
+
+	c.gossip.Seen(message.GetHash())
+
+	addresses := c.gossip.Peers(c.connectedPeers())
+	if !c.localNode.IsWatcher() {
+		addresses = append(addresses, c.localNode.Address())
+	}
+	c.send(message, addresses)
+
+	return
+}
+
+// Relay forwards the message from the other validator to the random subset
+// of validators in gossip mode; the message, which is already seen, is not
+// relayed again.
+func (c *ValidatorConnectionManager) Relay(message common.Message) {
+	if c.gossip == nil {
+		return
+	}
+	if c.gossip.Seen(message.GetHash()) {
+		return
+	}
+
+	c.send(message, c.gossip.Peers(c.connectedPeers()))
+
+	return
+}
+
+// connectedPeers returns the connected validators except the local node.
+func (c *ValidatorConnectionManager) connectedPeers() []string {
+	var peers []string
+	for _, address := range c.AllConnected() {
+		if address == c.localNode.Address() {
+			continue
+		}
+		peers = append(peers, address)
+	}
+
+	return peers
+}
+
+func (c *ValidatorConnectionManager) send(message common.Message, addresses []string) {
 	c.RLock()
 	defer c.RUnlock()
-	for addr, connected := range c.connected {
-		if connected {
-			go func(v *node.Validator) {
-				client := c.GetConnection(v.Address())
-
-				var err error
-				var response []byte
-				if message.GetType() == common.BallotMessage {
-					response, err = client.SendBallot(message)
-				} else if message.GetType() == common.TransactionMessage {
-					response, err = client.SendMessage(message)
+	for _, addr := range addresses {
+		v, found := c.validators[addr]
+		if !found {
+			continue
+		}
+		go func(v *node.Validator) {
+			client := c.GetConnection(v.Address())
+
+			var err error
+			var response []byte
+			if message.GetType() == common.BallotMessage {
+				response, err = client.SendBallot(message)
+			} else if message.GetType() == common.TransactionMessage {
+				if c.localNode.IsWatcher() {
+					response, err = client.SendTransaction(message)
 				} else {
-					panic("invalid message")
+					response, err = client.SendMessage(message)
 				}
+			} else {
+				panic("invalid message")
+			}
 
-				if err != nil {
-					c.log.Error("failed to broadcast", "error", err, "validator", v, "message", message, "response", string(response))
-				}
-			}(c.validators[addr])
-		}
+			if err != nil {
+				c.log.Error("failed to broadcast", "error", err, "validator", v, "message", message, "response", string(response))
+			}
+		}(v)
 	}
 	return
 }
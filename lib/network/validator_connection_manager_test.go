@@ -0,0 +1,45 @@
+package network
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node"
+)
+
+type nodeInfoClient struct {
+	NetworkClient
+	info node.NodeInfo
+}
+
+func (c nodeInfoClient) GetNodeInfo() ([]byte, error) {
+	return json.Marshal(c.info)
+}
+
+func TestValidatorConnectionManagerCheckProposerSelector(t *testing.T) {
+	kp, _ := keypair.Random()
+	localNode, _ := node.NewLocalNode(kp, &common.Endpoint{}, "")
+
+	conf := common.NewConfig()
+	conf.ProposerSelector = "weighted"
+	cm := NewValidatorConnectionManager(localNode, nil, nil, conf).(*ValidatorConnectionManager)
+
+	kpOther, _ := keypair.Random()
+	v, _ := node.NewValidator(kpOther.Address(), &common.Endpoint{}, "")
+
+	client := nodeInfoClient{}
+	client.info.Policy.ProposerSelector = "weighted"
+	require.NoError(t, cm.checkProposerSelector(v, client))
+
+	client.info.Policy.ProposerSelector = "sequential"
+	require.Equal(t, errors.ProposerSelectorMismatch, cm.checkProposerSelector(v, client))
+
+	// the selector is unknown
+	client.info.Policy.ProposerSelector = ""
+	require.NoError(t, cm.checkProposerSelector(v, client))
+}
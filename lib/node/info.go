@@ -11,6 +11,7 @@ type NodeInfo struct {
 	Node   NodeInfoNode  `json:"node"`
 	Policy NodePolicy    `json:"policy"`
 	Block  NodeBlockInfo `json:"block"`
+	Sync   *NodeSyncInfo `json:"sync,omitempty"`
 }
 
 type NodeInfoNode struct {
@@ -22,6 +23,8 @@ type NodeInfoNode struct {
 	Validators map[string]*Validator `json:"validators"`
 }
 
+// NodePolicy has the policy of node and network; the fields of
+// `common.NetworkParams` can be got by `NodePolicy.NetworkParams()`.
 type NodePolicy struct {
 	NetworkID                 string        `json:"network-id"`                    // network id
 	InitialBalance            common.Amount `json:"initial-balance"`               // initial balance of genesis account
@@ -33,6 +36,49 @@ type NodePolicy struct {
 	GenesisBlockConfirmedTime string        `json:"genesis-block-confirmed-time"`  // confirmed time of genesis block; see `common.GenesisBlockConfirmedTime`
 	InflationRatio            string        `json:"inflation-ratio"`               // inflation ratio; see `common.InflationRatio`
 	BlockHeightEndOfInflation uint64        `json:"block-height-end-of-inflation"` // block height of inflation end; see `common.BlockHeightEndOfInflation`
+	ProposerSelector          string        `json:"proposer-selector"`             // proposer selector; see `consensus.ProposerSelectors`
+
+	UnfreezingPeriod                 uint64        `json:"unfreezing-period"`                    // number of blocks for unfreezing; see `common.UnfreezingPeriod`
+	BallotConfirmedTimeAllowDuration time.Duration `json:"ballot-confirmed-time-allow-duration"` // see `common.BallotConfirmedTimeAllowDuration`
+}
+
+func NewNodePolicy(networkID string, initialBalance common.Amount, conf common.Config) NodePolicy {
+	params := conf.NetworkParams
+
+	return NodePolicy{
+		NetworkID:                        networkID,
+		InitialBalance:                   initialBalance,
+		BaseReserve:                      params.BaseReserve,
+		BaseFee:                          params.BaseFee,
+		BlockTime:                        conf.BlockTime,
+		OperationsLimit:                  conf.OpsLimit,
+		TransactionsLimit:                conf.TxsLimit,
+		GenesisBlockConfirmedTime:        common.GenesisBlockConfirmedTime,
+		InflationRatio:                   params.InflationRatioString(),
+		BlockHeightEndOfInflation:        params.BlockHeightEndOfInflation,
+		ProposerSelector:                 conf.ProposerSelector,
+		UnfreezingPeriod:                 params.UnfreezingPeriod,
+		BallotConfirmedTimeAllowDuration: params.BallotConfirmedTimeAllowDuration,
+	}
+}
+
+// NetworkParams returns the network parameters of the node.
+func (p NodePolicy) NetworkParams() (params common.NetworkParams, err error) {
+	var ratio float64
+	if ratio, err = common.String2InflationRatio(p.InflationRatio); err != nil {
+		return
+	}
+
+	params = common.NetworkParams{
+		BaseFee:                          p.BaseFee,
+		BaseReserve:                      p.BaseReserve,
+		InflationRatio:                   ratio,
+		BlockHeightEndOfInflation:        p.BlockHeightEndOfInflation,
+		UnfreezingPeriod:                 p.UnfreezingPeriod,
+		BallotConfirmedTimeAllowDuration: p.BallotConfirmedTimeAllowDuration,
+	}
+
+	return
 }
 
 type NodeBlockInfo struct {
@@ -42,6 +88,19 @@ type NodeBlockInfo struct {
 	TotalOps uint64 `json:"total-ops"`
 }
 
+// NodeSyncInfo is the progress of syncing the blocks from the other nodes;
+// `Synced` is true when the node caught up the highest block of the peers or
+// the node stores the blocks by consensus.
+type NodeSyncInfo struct {
+	Synced          bool              `json:"synced"`
+	StartingBlock   uint64            `json:"starting-block"`    // block height where sync began
+	CurrentBlock    uint64            `json:"current-block"`     // block height where sync is at
+	HighestBlock    uint64            `json:"highest-block"`     // highest alleged block height in the network
+	BlocksPerSecond float64           `json:"blocks-per-second"` // number of synced blocks per second in the current sync
+	ETA             time.Duration     `json:"eta"`               // estimated time to reach `HighestBlock`
+	FetchFailures   map[string]uint64 `json:"fetch-failures"`    // number of fetch failures by peer address
+}
+
 type NodeVersion struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
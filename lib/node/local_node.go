@@ -24,6 +24,7 @@ type LocalNode struct {
 	keypair *keypair.Full
 
 	state           State
+	watcher         bool
 	alias           string
 	bindEndpoint    *common.Endpoint
 	publishEndpoint *common.Endpoint
@@ -79,6 +80,23 @@ func (n *LocalNode) SetSync() {
 	n.state = StateSYNC
 }
 
+// SetWatcher makes the node to be the watcher; the watcher follows the
+// validators, but it is not one of them and never votes.
+func (n *LocalNode) SetWatcher() {
+	n.Lock()
+	defer n.Unlock()
+
+	n.watcher = true
+	n.state = StateWATCH
+	delete(n.validators, n.keypair.Address())
+}
+
+func (n *LocalNode) IsWatcher() bool {
+	n.RLock()
+	defer n.RUnlock()
+	return n.watcher
+}
+
 func (n *LocalNode) Address() string {
 	return n.keypair.Address()
 }
@@ -104,18 +122,33 @@ func (n *LocalNode) PublishEndpoint() *common.Endpoint {
 }
 
 func (n *LocalNode) SetPublishEndpoint(endpoint *common.Endpoint) {
-	delete(n.validators, n.Address())
+	n.RemoveValidators(n.Address())
 	n.publishEndpoint = endpoint
-	n.AddValidators(n.ConvertToValidator())
+	if !n.IsWatcher() {
+		n.AddValidators(n.ConvertToValidator())
+	}
 }
 
 func (n *LocalNode) HasValidators(address string) bool {
+	n.RLock()
+	defer n.RUnlock()
+
 	_, found := n.validators[address]
 	return found
 }
 
+// GetValidators returns the copy of validators; the validators can be changed
+// by `AddValidators` and `RemoveValidators` after the node is started.
 func (n *LocalNode) GetValidators() map[string]*Validator {
-	return n.validators
+	n.RLock()
+	defer n.RUnlock()
+
+	validators := map[string]*Validator{}
+	for address, v := range n.validators {
+		validators[address] = v
+	}
+
+	return validators
 }
 
 func (n *LocalNode) AddValidators(validators ...*Validator) error {
@@ -129,13 +162,22 @@ func (n *LocalNode) AddValidators(validators ...*Validator) error {
 	return nil
 }
 
+func (n *LocalNode) RemoveValidators(addresses ...string) {
+	n.Lock()
+	defer n.Unlock()
+
+	for _, address := range addresses {
+		delete(n.validators, address)
+	}
+}
+
 func (n *LocalNode) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]interface{}{
 		"address":    n.Address(),
 		"alias":      n.Alias(),
 		"endpoint":   n.Endpoint().String(),
 		"state":      n.State().String(),
-		"validators": n.validators,
+		"validators": n.GetValidators(),
 	})
 }
 
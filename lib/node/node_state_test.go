@@ -10,6 +10,7 @@ func TestNodeStateString(t *testing.T) {
 	require.Equal(t, StateBOOTING.String(), "BOOTING")
 	require.Equal(t, StateCONSENSUS.String(), "CONSENSUS")
 	require.Equal(t, StateSYNC.String(), "SYNC")
+	require.Equal(t, StateWATCH.String(), "WATCH")
 }
 
 func TestNodeStateMarshalJSON(t *testing.T) {
@@ -25,6 +26,10 @@ func TestNodeStateMarshalJSON(t *testing.T) {
 	require.Equal(t, err, nil)
 	require.Equal(t, "\"SYNC\"", string(ret))
 
+	ret, err = StateWATCH.MarshalJSON()
+	require.Equal(t, err, nil)
+	require.Equal(t, "\"WATCH\"", string(ret))
+
 }
 
 func TestNodeStateUnmarshalJSON(t *testing.T) {
@@ -41,4 +46,8 @@ func TestNodeStateUnmarshalJSON(t *testing.T) {
 	nodeStateByteArray, _ = StateSYNC.MarshalJSON()
 	ns.UnmarshalJSON(nodeStateByteArray)
 	require.Equal(t, StateSYNC, ns)
+
+	nodeStateByteArray, _ = StateWATCH.MarshalJSON()
+	ns.UnmarshalJSON(nodeStateByteArray)
+	require.Equal(t, StateWATCH, ns)
 }
@@ -27,6 +27,26 @@ func TestNodeStateChange(t *testing.T) {
 	require.Equal(t, StateCONSENSUS, node.State())
 }
 
+func TestNodeWatcher(t *testing.T) {
+	kp, _ := keypair.Random()
+	endpoint, err := common.NewEndpointFromString(fmt.Sprintf("https://localhost:5000?NodeName=n1"))
+	require.Equal(t, nil, err)
+
+	node, _ := NewLocalNode(kp, endpoint, "")
+	require.False(t, node.IsWatcher())
+	require.True(t, node.HasValidators(node.Address()))
+
+	node.SetWatcher()
+	require.True(t, node.IsWatcher())
+	require.Equal(t, StateWATCH, node.State())
+
+	// watcher is not one of the validators
+	require.False(t, node.HasValidators(node.Address()))
+
+	node.SetPublishEndpoint(endpoint)
+	require.False(t, node.HasValidators(node.Address()))
+}
+
 func TestNodeMarshalJSON(t *testing.T) {
 	kp, _ := keypair.Random()
 	endpoint, err := common.NewEndpointFromString(fmt.Sprintf("https://localhost:5000?NodeName=n1"))
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -11,13 +12,40 @@ import (
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network/httputils"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage/statedb"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
 )
 
+// getHeightQuery parses `height` of query string; it returns 0 without
+// `height`.
+func getHeightQuery(r *http.Request) (height uint64, err error) {
+	h := r.URL.Query().Get("height")
+	if len(h) < 1 {
+		return
+	}
+	if height, err = strconv.ParseUint(h, 10, 64); err != nil {
+		err = errors.InvalidQueryString
+	}
+	return
+}
+
+// GetAccountHandler returns the account; with `height`, it returns the
+// account as of the block at `height`.
 func (api NetworkHandlerAPI) GetAccountHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	address := vars["id"]
 
+	height, err := getHeightQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	readFunc := func() (payload interface{}, err error) {
+		if height > 0 {
+			return api.getAccountAtHeight(address, height)
+		}
+
 		found, err := block.ExistsBlockAccount(api.storage, address)
 		if err != nil {
 			return nil, err
@@ -52,3 +80,65 @@ func (api NetworkHandlerAPI) GetAccountHandler(w http.ResponseWriter, r *http.Re
 
 	httputils.MustWriteJSON(w, 200, payload)
 }
+
+func (api NetworkHandlerAPI) getAccountAtHeight(address string, height uint64) (payload interface{}, err error) {
+	var found bool
+	if found, err = block.ExistsBlockByHeight(api.storage, height); err != nil {
+		return
+	} else if !found {
+		return nil, errors.BlockNotFound
+	}
+
+	bah, err := block.GetBlockAccountHistory(api.storage, address, height)
+	if err != nil {
+		return
+	}
+	payload = resource.NewAccount(&bah.BlockAccount)
+	return
+}
+
+// GetAccountProofHandler returns the account and the merkle-patricia proof of
+// it against the `StateRoot` of the block at `height`; without `height`, the
+// latest block is used.
+func (api NetworkHandlerAPI) GetAccountProofHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+
+	height, err := getHeightQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	readFunc := func() (payload interface{}, err error) {
+		var blk block.Block
+		if height < 1 {
+			blk = block.GetLatestBlock(api.storage)
+		} else {
+			var found bool
+			if found, err = block.ExistsBlockByHeight(api.storage, height); err != nil {
+				return nil, err
+			} else if !found {
+				return nil, errors.BlockNotFound
+			}
+			if blk, err = block.GetBlockByHeight(api.storage, height); err != nil {
+				return nil, err
+			}
+		}
+
+		sdb := statedb.New(blk.StateRootHash(), trie.NewEthDatabase(api.storage))
+		ba, proof, err := sdb.GetProof(address)
+		if err != nil {
+			return nil, err
+		}
+		payload = resource.NewAccountProof(ba, &blk, proof)
+		return payload, nil
+	}
+
+	payload, err := readFunc()
+	if err == nil {
+		httputils.MustWriteJSON(w, 200, payload)
+	} else {
+		httputils.WriteJSONError(w, err)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/observer"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network/httputils"
@@ -128,3 +130,132 @@ func TestGetNonExistentAccountHandler(t *testing.T) {
 		require.Equal(t, pByte, readByte)
 	}
 }
+
+func TestGetAccountProofHandler(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	genesis, err := block.GetBlockByHeight(storage, 1)
+	require.NoError(t, err)
+
+	loadProof := func(url string) (proof client.AccountProof) {
+		respBody, err := request(ts, url, false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(readByte, &proof))
+		return
+	}
+
+	url := strings.Replace(GetAccountProofHandlerPattern, "{id}", block.GenesisKP.Address(), -1)
+	for _, u := range []string{url, url + "?height=1"} {
+		proof := loadProof(u)
+		require.Equal(t, block.GenesisKP.Address(), proof.Address)
+		require.Equal(t, common.MaximumBalance.String(), proof.Balance)
+		require.Equal(t, genesis.Hash, proof.Block)
+		require.Equal(t, genesis.StateRoot, proof.StateRoot)
+		require.True(t, client.VerifyAccountProof(proof, genesis.StateRoot))
+
+		// the modified account can not be verified
+		proof.Balance = "1"
+		require.False(t, client.VerifyAccountProof(proof, genesis.StateRoot))
+	}
+
+	{ // the proof of the other account
+		proof := loadProof(url)
+		proof.Address = block.CommonKP.Address()
+		require.False(t, client.VerifyAccountProof(proof, genesis.StateRoot))
+	}
+
+	for _, u := range []string{
+		strings.Replace(GetAccountProofHandlerPattern, "{id}", "findme", -1), // unknown address
+		url + "?height=100", // unknown block
+	} {
+		req, _ := http.NewRequest("GET", ts.URL+u, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	{ // invalid height
+		req, _ := http.NewRequest("GET", ts.URL+url+"?height=showme", nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestGetAccountHandlerWithHeight(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	genesis, err := block.GetBlockByHeight(storage, 1)
+	require.NoError(t, err)
+
+	// the balance of genesis account is changed at height 3
+	var blk block.Block = genesis
+	for i := 0; i < 2; i++ {
+		blk = block.TestMakeNewBlockWithPrevBlock(blk, []string{})
+		blk.MustSave(storage)
+	}
+	ba, err := block.GetBlockAccount(storage, block.GenesisKP.Address())
+	require.NoError(t, err)
+	ba.Balance = common.Amount(100)
+	ba.SequenceID = 1
+	ba.MustSave(storage)
+	bah := block.NewBlockAccountHistory(*ba, blk.Height)
+	require.NoError(t, bah.Save(storage))
+
+	loadAccount := func(url string) (account client.Account) {
+		respBody, err := request(ts, url, false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(readByte, &account))
+		return
+	}
+
+	url := strings.Replace(GetAccountHandlerPattern, "{id}", block.GenesisKP.Address(), -1)
+	{ // without height
+		account := loadAccount(url)
+		require.Equal(t, "100", account.Balance)
+		require.Equal(t, uint64(1), account.SequenceID)
+	}
+
+	for _, height := range []string{"1", "2"} {
+		account := loadAccount(url + "?height=" + height)
+		require.Equal(t, common.MaximumBalance.String(), account.Balance)
+		require.Equal(t, uint64(0), account.SequenceID)
+	}
+
+	{
+		account := loadAccount(url + "?height=3")
+		require.Equal(t, "100", account.Balance)
+		require.Equal(t, uint64(1), account.SequenceID)
+	}
+
+	for u, status := range map[string]int{
+		url + "?height=4":      http.StatusNotFound,   // unknown block
+		url + "?height=showme": http.StatusBadRequest, // invalid height
+		strings.Replace(GetAccountHandlerPattern, "{id}", "findme", -1) + "?height=1": http.StatusNotFound,
+	} {
+		req, _ := http.NewRequest("GET", ts.URL+u, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, status, resp.StatusCode)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -19,11 +20,18 @@ const (
 	GetAccountTransactionsHandlerPattern   = "/accounts/{id}/transactions"
 	GetAccountHandlerPattern               = "/accounts/{id}"
 	GetAccountOperationsHandlerPattern     = "/accounts/{id}/operations"
+	GetAccountProofHandlerPattern          = "/accounts/{id}/proof"
 	GetTransactionsHandlerPattern          = "/transactions"
 	GetTransactionByHashHandlerPattern     = "/transactions/{id}"
 	GetTransactionOperationsHandlerPattern = "/transactions/{id}/operations"
 	PostTransactionPattern                 = "/transactions"
 	GetTransactionHistoryHandlerPattern    = "/transactions/{id}/history"
+	GetTransactionProofHandlerPattern      = "/transactions/{id}/proof"
+	GetBlocksHandlerPattern                = "/blocks"
+	GetBlockHandlerPattern                 = "/blocks/{id}"
+	GetBlockTransactionsHandlerPattern     = "/blocks/{id}/transactions"
+	GetEvidencesHandlerPattern             = "/evidences"
+	GetEvidenceHandlerPattern              = "/evidences/{id}"
 	GetNodeInfoPattern                     = "/"
 )
 
@@ -35,6 +43,7 @@ type NetworkHandlerAPI struct {
 	version        string
 	nodeInfo       node.NodeInfo
 	GetLatestBlock func() block.Block
+	GetSyncInfo    func(context.Context) (node.NodeSyncInfo, error)
 }
 
 func NewNetworkHandlerAPI(localNode *node.LocalNode, network network.Network, storage *storage.LevelDBBackend, urlPrefix string, nodeInfo node.NodeInfo) *NetworkHandlerAPI {
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// getBlockByID finds block by height or by hash; `id` is treated as height
+// when it is a number.
+func (api NetworkHandlerAPI) getBlockByID(id string) (blk block.Block, err error) {
+	var found bool
+	if height, perr := strconv.ParseUint(id, 10, 64); perr == nil {
+		if found, err = block.ExistsBlockByHeight(api.storage, height); err != nil {
+			return
+		} else if !found {
+			err = errors.BlockNotFound
+			return
+		}
+		return block.GetBlockByHeight(api.storage, height)
+	}
+
+	if found, err = block.ExistsBlock(api.storage, id); err != nil {
+		return
+	} else if !found {
+		err = errors.BlockNotFound
+		return
+	}
+	return block.GetBlock(api.storage, id)
+}
+
+func (api NetworkHandlerAPI) GetBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	options, err := storage.NewDefaultListOptionsFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var cursor []byte
+	readFunc := func() []resource.Resource {
+		var bs []resource.Resource
+		iterFunc, closeFunc := block.GetBlocksByHeight(api.storage, options)
+		for {
+			b, hasNext, c := iterFunc()
+			cursor = c
+			if !hasNext {
+				break
+			}
+			bs = append(bs, resource.NewBlock(&b))
+		}
+		closeFunc()
+		return bs
+	}
+
+	bs := readFunc()
+
+	self := r.URL.String()
+	next := resource.URLBlocks + "?" + options.SetCursor(cursor).SetReverse(false).Encode()
+	prev := resource.URLBlocks + "?" + options.SetReverse(true).Encode()
+	list := resource.NewResourceList(bs, self, next, prev)
+
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+func (api NetworkHandlerAPI) GetBlockHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	blk, err := api.getBlockByID(id)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, resource.NewBlock(&blk))
+}
+
+func (api NetworkHandlerAPI) GetTransactionsByBlockHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	options, err := storage.NewDefaultListOptionsFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+		return
+	}
+
+	blk, err := api.getBlockByID(id)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var cursor []byte
+	readFunc := func() []resource.Resource {
+		var txs []resource.Resource
+		iterFunc, closeFunc := block.GetBlockTransactionsByBlock(api.storage, blk.Hash, options)
+		for {
+			t, hasNext, c := iterFunc()
+			cursor = c
+			if !hasNext {
+				break
+			}
+			txs = append(txs, resource.NewTransaction(&t))
+		}
+		closeFunc()
+		return txs
+	}
+
+	txs := readFunc()
+
+	self := r.URL.String()
+	next := strings.Replace(resource.URLBlockTransactions, "{id}", blk.Hash, -1) + "?" + options.SetCursor(cursor).SetReverse(false).Encode()
+	prev := strings.Replace(resource.URLBlockTransactions, "{id}", blk.Hash, -1) + "?" + options.SetReverse(true).Encode()
+	list := resource.NewResourceList(txs, self, next, prev)
+
+	httputils.MustWriteJSON(w, 200, list)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/client"
+)
+
+func TestGetBlocksHandler(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		_, _, err := prepareTxs(storage, 2)
+		require.NoError(t, err)
+	}
+
+	loadPage := func(url string) (page client.BlocksPage) {
+		respBody, err := request(ts, url, false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(readByte, &page))
+		return
+	}
+
+	{
+		page := loadPage(GetBlocksHandlerPattern)
+		require.Equal(t, 4, len(page.Embedded.Records))
+		for i, b := range page.Embedded.Records {
+			expected, err := block.GetBlockByHeight(storage, uint64(i+1))
+			require.NoError(t, err)
+			require.Equal(t, expected.Hash, b.Hash)
+			require.Equal(t, expected.Height, b.Height)
+			require.Equal(t, expected.StateRoot, b.StateRoot)
+			require.Equal(t, expected.Transactions, b.Transactions)
+		}
+	}
+
+	{ // reverse with limit
+		page := loadPage(GetBlocksHandlerPattern + "?reverse=true&limit=2")
+		require.Equal(t, 2, len(page.Embedded.Records))
+		require.Equal(t, uint64(4), page.Embedded.Records[0].Height)
+		require.Equal(t, uint64(3), page.Embedded.Records[1].Height)
+	}
+}
+
+func TestGetBlockHandler(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	_, btList, err := prepareTxs(storage, 3)
+	require.NoError(t, err)
+
+	expected, err := block.GetBlock(storage, btList[0].Block)
+	require.NoError(t, err)
+
+	for _, id := range []string{expected.Hash, strconv.FormatUint(expected.Height, 10)} {
+		respBody, err := request(ts, strings.Replace(GetBlockHandlerPattern, "{id}", id, -1), false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+		var b client.Block
+		require.NoError(t, json.Unmarshal(readByte, &b))
+
+		require.Equal(t, expected.Hash, b.Hash)
+		require.Equal(t, expected.Height, b.Height)
+		require.Equal(t, expected.PrevBlockHash, b.PrevBlockHash)
+		require.Equal(t, expected.TransactionsRoot, b.TransactionsRoot)
+		require.Equal(t, "/api/v1/blocks/"+expected.Hash, b.Links.Self.Href)
+	}
+
+	for _, id := range []string{"findme", "100"} {
+		req, _ := http.NewRequest("GET", ts.URL+strings.Replace(GetBlockHandlerPattern, "{id}", id, -1), nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestGetTransactionsByBlockHandler(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	_, btList, err := prepareTxs(storage, 3)
+	require.NoError(t, err)
+	_, _, err = prepareTxs(storage, 2)
+	require.NoError(t, err)
+
+	blk, err := block.GetBlock(storage, btList[0].Block)
+	require.NoError(t, err)
+
+	for _, id := range []string{blk.Hash, strconv.FormatUint(blk.Height, 10)} {
+		respBody, err := request(ts, strings.Replace(GetBlockTransactionsHandlerPattern, "{id}", id, -1), false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+		var page client.TransactionsPage
+		require.NoError(t, json.Unmarshal(readByte, &page))
+
+		require.Equal(t, len(btList), len(page.Embedded.Records))
+		for i, bt := range btList {
+			require.Equal(t, bt.Hash, page.Embedded.Records[i].Hash)
+		}
+	}
+
+	{ // unknown block
+		req, _ := http.NewRequest("GET", ts.URL+strings.Replace(GetBlockTransactionsHandlerPattern, "{id}", "findme", -1), nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// GetEvidencesHandler returns the evidences of the validators, which signed
+// the conflicting ballots.
+func (api NetworkHandlerAPI) GetEvidencesHandler(w http.ResponseWriter, r *http.Request) {
+	options, err := storage.NewDefaultListOptionsFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var cursor []byte
+	readFunc := func() []resource.Resource {
+		var es []resource.Resource
+		iterFunc, closeFunc := consensus.GetEvidences(api.storage, options)
+		for {
+			e, hasNext, c := iterFunc()
+			cursor = c
+			if !hasNext {
+				break
+			}
+			es = append(es, resource.NewEvidence(&e))
+		}
+		closeFunc()
+		return es
+	}
+
+	es := readFunc()
+
+	self := r.URL.String()
+	next := resource.URLEvidences + "?" + options.SetCursor(cursor).SetReverse(false).Encode()
+	prev := resource.URLEvidences + "?" + options.SetReverse(true).Encode()
+	list := resource.NewResourceList(es, self, next, prev)
+
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+func (api NetworkHandlerAPI) GetEvidenceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	e, err := consensus.GetEvidence(api.storage, id)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, resource.NewEvidence(&e))
+}
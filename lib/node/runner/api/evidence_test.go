@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestGetEvidencesHandler(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	kp := keypair.Master("evidence")
+	for height := uint64(2); height < 5; height++ {
+		proposed := *ballot.NewBallot(kp.Address(), kp.Address(), voting.Basis{Height: height}, []string{})
+
+		yes, no := proposed, proposed
+		yes.SetVote(ballot.StateSIGN, voting.YES)
+		no.SetVote(ballot.StateSIGN, voting.NO)
+		require.NoError(t, consensus.NewEvidence(yes, no).Save(storage))
+	}
+
+	respBody, err := request(ts, GetEvidencesHandlerPattern+"?reverse=true&limit=2", false)
+	require.NoError(t, err)
+	defer respBody.Close()
+
+	readByte, err := ioutil.ReadAll(respBody)
+	require.NoError(t, err)
+
+	var page struct {
+		Embedded struct {
+			Records []struct {
+				Hash    string          `json:"hash"`
+				Source  string          `json:"source"`
+				Height  uint64          `json:"height"`
+				State   ballot.State    `json:"state"`
+				Ballots []ballot.Ballot `json:"ballots"`
+				Links   struct {
+					Self struct {
+						Href string `json:"href"`
+					} `json:"self"`
+				} `json:"_links"`
+			} `json:"records"`
+		} `json:"_embedded"`
+	}
+	require.NoError(t, json.Unmarshal(readByte, &page))
+
+	records := page.Embedded.Records
+	require.Equal(t, 2, len(records))
+	require.Equal(t, uint64(4), records[0].Height)
+	require.Equal(t, uint64(3), records[1].Height)
+	for _, r := range records {
+		require.Equal(t, kp.Address(), r.Source)
+		require.Equal(t, ballot.StateSIGN, r.State)
+		require.Equal(t, 2, len(r.Ballots))
+		require.Equal(t, voting.YES, r.Ballots[0].Vote())
+		require.Equal(t, voting.NO, r.Ballots[1].Vote())
+		require.Equal(t, "/api/v1/evidences/"+r.Hash, r.Links.Self.Href)
+	}
+	require.NotEqual(t, records[0].Hash, records[1].Hash)
+
+	{ // by hash
+		respBody, err := request(ts, strings.Replace(GetEvidenceHandlerPattern, "{id}", records[1].Hash, -1), false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+
+		var e struct {
+			Hash   string `json:"hash"`
+			Height uint64 `json:"height"`
+		}
+		require.NoError(t, json.Unmarshal(readByte, &e))
+		require.Equal(t, records[1].Hash, e.Hash)
+		require.Equal(t, uint64(3), e.Height)
+	}
+
+	{ // unknown hash
+		req, _ := http.NewRequest("GET", ts.URL+strings.Replace(GetEvidenceHandlerPattern, "{id}", "findme", -1), nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
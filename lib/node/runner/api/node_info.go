@@ -12,6 +12,7 @@ func (api NetworkHandlerAPI) GetNodeInfoHandler(w http.ResponseWriter, r *http.R
 	*nodeInfo = *&api.nodeInfo
 
 	nodeInfo.Node.State = api.localNode.State()
+	nodeInfo.Node.Validators = api.localNode.GetValidators()
 
 	if nodeInfo.Node.Endpoint == nil {
 		rUrl := common.RequestURLFromRequest(r)
@@ -31,6 +32,12 @@ func (api NetworkHandlerAPI) GetNodeInfoHandler(w http.ResponseWriter, r *http.R
 		}
 	}
 
+	if api.GetSyncInfo != nil {
+		if info, err := api.GetSyncInfo(r.Context()); err == nil {
+			nodeInfo.Sync = &info
+		}
+	}
+
 	var b []byte
 	var err error
 	if b, err = common.JSONMarshalIndent(nodeInfo); err != nil {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http/httptest"
@@ -67,6 +68,9 @@ func TestAPIGetNodeInfoHandler(t *testing.T) {
 		GetLatestBlock: func() block.Block {
 			return block.GetLatestBlock(st)
 		},
+		GetSyncInfo: func(context.Context) (node.NodeSyncInfo, error) {
+			return node.NodeSyncInfo{Synced: true, CurrentBlock: 1, HighestBlock: 1}, nil
+		},
 	}
 
 	router := mux.NewRouter()
@@ -97,6 +101,10 @@ func TestAPIGetNodeInfoHandler(t *testing.T) {
 	require.Equal(t, latestBlock.TotalTxs, receivedNodeInfo.Block.TotalTxs)
 	require.Equal(t, latestBlock.TotalOps, receivedNodeInfo.Block.TotalOps)
 
+	require.NotNil(t, receivedNodeInfo.Sync)
+	require.True(t, receivedNodeInfo.Sync.Synced)
+	require.Equal(t, uint64(1), receivedNodeInfo.Sync.HighestBlock)
+
 	js, _ := json.Marshal(policy)
 	rjs, _ := json.Marshal(receivedNodeInfo.Policy)
 	require.Equal(t, js, rjs)
@@ -25,6 +25,8 @@ func (a Account) GetMap() hal.Entry {
 		"sequence_id": a.ba.SequenceID,
 		"balance":     a.ba.Balance,
 		"linked":      a.ba.Linked,
+		"signers":     a.ba.Signers,
+		"thresholds":  a.ba.Thresholds,
 	}
 }
 
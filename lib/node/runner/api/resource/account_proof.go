@@ -0,0 +1,57 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+// AccountProof is the merkle-patricia proof of account against the
+// `StateRoot` of block.
+type AccountProof struct {
+	ba    *block.BlockAccount
+	blk   *block.Block
+	proof [][]byte
+}
+
+func NewAccountProof(ba *block.BlockAccount, blk *block.Block, proof [][]byte) *AccountProof {
+	a := &AccountProof{
+		ba:    ba,
+		blk:   blk,
+		proof: proof,
+	}
+	return a
+}
+
+func (a AccountProof) GetMap() hal.Entry {
+	proof := make([]string, len(a.proof))
+	for i, node := range a.proof {
+		proof[i] = base58.Encode(node)
+	}
+
+	return hal.Entry{
+		"address":      a.ba.Address,
+		"sequence_id":  a.ba.SequenceID,
+		"balance":      a.ba.Balance,
+		"linked":       a.ba.Linked,
+		"signers":      a.ba.Signers,
+		"thresholds":   a.ba.Thresholds,
+		"block":        a.blk.Hash,
+		"block_height": a.blk.Height,
+		"state_root":   a.blk.StateRoot,
+		"proof":        proof,
+	}
+}
+
+func (a AccountProof) Resource() *hal.Resource {
+	r := hal.NewResource(a, a.LinkSelf())
+	r.AddLink("account", hal.NewLink(strings.Replace(URLAccounts, "{id}", a.ba.Address, -1)))
+	return r
+}
+
+func (a AccountProof) LinkSelf() string {
+	return strings.Replace(URLAccountProof, "{id}", a.ba.Address, -1)
+}
@@ -0,0 +1,51 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+type Block struct {
+	b *block.Block
+}
+
+func NewBlock(b *block.Block) *Block {
+	return &Block{
+		b: b,
+	}
+}
+
+func (b Block) GetMap() hal.Entry {
+	return hal.Entry{
+		"hash":                 b.b.Hash,
+		"height":               b.b.Height,
+		"version":              b.b.Version,
+		"prev_block_hash":      b.b.PrevBlockHash,
+		"transactions_root":    b.b.TransactionsRoot,
+		"state_root":           b.b.StateRoot,
+		"timestamp":            b.b.Timestamp,
+		"total_txs":            b.b.TotalTxs,
+		"total_ops":            b.b.TotalOps,
+		"proposer":             b.b.Proposer,
+		"round":                b.b.Round,
+		"confirmed":            b.b.Confirmed,
+		"transactions":         b.b.Transactions,
+		"proposer_transaction": b.b.ProposerTransaction,
+	}
+}
+
+func (b Block) Resource() *hal.Resource {
+	r := hal.NewResource(b, b.LinkSelf())
+	r.AddLink("transactions", hal.NewLink(strings.Replace(URLBlockTransactions, "{id}", b.b.Hash, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
+	if len(b.b.PrevBlockHash) > 0 {
+		r.AddLink("prev", hal.NewLink(strings.Replace(URLBlock, "{id}", b.b.PrevBlockHash, -1)))
+	}
+	return r
+}
+
+func (b Block) LinkSelf() string {
+	return strings.Replace(URLBlock, "{id}", b.b.Hash, -1)
+}
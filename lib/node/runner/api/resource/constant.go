@@ -7,9 +7,16 @@ const (
 	URLAccounts              = APIPrefix + APIVersionV1 + "/accounts/{id}"
 	URLAccountTransactions   = APIPrefix + APIVersionV1 + "/accounts/{id}/transactions"
 	URLAccountOperations     = APIPrefix + APIVersionV1 + "/accounts/{id}/operations"
+	URLAccountProof          = APIPrefix + APIVersionV1 + "/accounts/{id}/proof"
 	URLTransactions          = APIPrefix + APIVersionV1 + "/transactions"
 	URLTransactionByHash     = APIPrefix + APIVersionV1 + "/transactions/{id}"
 	URLTransactionOperations = APIPrefix + APIVersionV1 + "/transactions/{id}/operations"
 	URLTransactionHistory    = APIPrefix + APIVersionV1 + "/transactions/{id}/history"
+	URLTransactionProof      = APIPrefix + APIVersionV1 + "/transactions/{id}/proof"
 	URLOperations            = APIPrefix + APIVersionV1 + "/operations/{id}"
+	URLBlocks                = APIPrefix + APIVersionV1 + "/blocks"
+	URLBlock                 = APIPrefix + APIVersionV1 + "/blocks/{id}"
+	URLBlockTransactions     = APIPrefix + APIVersionV1 + "/blocks/{id}/transactions"
+	URLEvidences             = APIPrefix + APIVersionV1 + "/evidences"
+	URLEvidence              = APIPrefix + APIVersionV1 + "/evidences/{id}"
 )
@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/consensus"
+)
+
+type Evidence struct {
+	e *consensus.Evidence
+}
+
+func NewEvidence(e *consensus.Evidence) *Evidence {
+	return &Evidence{
+		e: e,
+	}
+}
+
+func (e Evidence) GetMap() hal.Entry {
+	return hal.Entry{
+		"hash":     e.e.Hash,
+		"source":   e.e.Source,
+		"height":   e.e.VotingBasis.Height,
+		"round":    e.e.VotingBasis.Round,
+		"state":    e.e.State,
+		"ballots":  e.e.Ballots,
+		"detected": e.e.Detected,
+	}
+}
+
+func (e Evidence) Resource() *hal.Resource {
+	r := hal.NewResource(e, e.LinkSelf())
+	r.AddLink("source", hal.NewLink(strings.Replace(URLAccounts, "{id}", e.e.Source, -1)))
+	return r
+}
+
+func (e Evidence) LinkSelf() string {
+	return strings.Replace(URLEvidence, "{id}", e.e.Hash, -1)
+}
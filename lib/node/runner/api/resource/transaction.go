@@ -19,7 +19,7 @@ func NewTransaction(bt *block.BlockTransaction) *Transaction {
 }
 
 func (t Transaction) GetMap() hal.Entry {
-	return hal.Entry{
+	entry := hal.Entry{
 		"hash":            t.bt.Hash,
 		"source":          t.bt.Source,
 		"fee":             t.bt.Fee.String(),
@@ -27,6 +27,11 @@ func (t Transaction) GetMap() hal.Entry {
 		"created":         t.bt.Created,
 		"operation_count": len(t.bt.Operations),
 	}
+	if t.bt.Memo != nil {
+		entry["memo"] = t.bt.Memo
+	}
+
+	return entry
 }
 func (t Transaction) Resource() *hal.Resource {
 
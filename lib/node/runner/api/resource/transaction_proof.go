@@ -0,0 +1,49 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+)
+
+// TransactionProof is the merkle proof of transaction against the
+// `TransactionsRoot` of block.
+type TransactionProof struct {
+	hash  string
+	blk   *block.Block
+	proof common.MerkleProof
+}
+
+func NewTransactionProof(hash string, blk *block.Block, proof common.MerkleProof) *TransactionProof {
+	t := &TransactionProof{
+		hash:  hash,
+		blk:   blk,
+		proof: proof,
+	}
+	return t
+}
+
+func (t TransactionProof) GetMap() hal.Entry {
+	return hal.Entry{
+		"hash":              t.hash,
+		"block":             t.blk.Hash,
+		"block_height":      t.blk.Height,
+		"transactions_root": t.blk.TransactionsRoot,
+		"index":             t.proof.Index,
+		"total":             t.proof.Total,
+		"path":              t.proof.Path,
+	}
+}
+
+func (t TransactionProof) Resource() *hal.Resource {
+	r := hal.NewResource(t, t.LinkSelf())
+	r.AddLink("transaction", hal.NewLink(strings.Replace(URLTransactionByHash, "{id}", t.hash, -1)))
+	return r
+}
+
+func (t TransactionProof) LinkSelf() string {
+	return strings.Replace(URLTransactionProof, "{id}", t.hash, -1)
+}
@@ -27,12 +27,19 @@ func prepareAPIServer() (*httptest.Server, *storage.LevelDBBackend, error) {
 	router.HandleFunc(GetAccountHandlerPattern, apiHandler.GetAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountTransactionsHandlerPattern, apiHandler.GetTransactionsByAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountOperationsHandlerPattern, apiHandler.GetOperationsByAccountHandler).Methods("GET")
+	router.HandleFunc(GetAccountProofHandlerPattern, apiHandler.GetAccountProofHandler).Methods("GET")
 	router.HandleFunc(GetTransactionsHandlerPattern, apiHandler.GetTransactionsHandler).Methods("GET")
 	router.HandleFunc(GetTransactionByHashHandlerPattern, apiHandler.GetTransactionByHashHandler).Methods("GET")
+	router.HandleFunc(GetTransactionProofHandlerPattern, apiHandler.GetTransactionProofHandler).Methods("GET")
 	router.HandleFunc(GetAccountHandlerPattern, apiHandler.GetAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountHandlerPattern, apiHandler.GetAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountHandlerPattern, apiHandler.GetAccountHandler).Methods("GET")
 	router.HandleFunc(GetTransactionOperationsHandlerPattern, apiHandler.GetOperationsByTxHashHandler).Methods("GET")
+	router.HandleFunc(GetBlocksHandlerPattern, apiHandler.GetBlocksHandler).Methods("GET")
+	router.HandleFunc(GetBlockHandlerPattern, apiHandler.GetBlockHandler).Methods("GET")
+	router.HandleFunc(GetBlockTransactionsHandlerPattern, apiHandler.GetTransactionsByBlockHandler).Methods("GET")
+	router.HandleFunc(GetEvidencesHandlerPattern, apiHandler.GetEvidencesHandler).Methods("GET")
+	router.HandleFunc(GetEvidenceHandlerPattern, apiHandler.GetEvidenceHandler).Methods("GET")
 	ts := httptest.NewServer(router)
 	return ts, storage, nil
 }
@@ -142,3 +142,41 @@ func (api NetworkHandlerAPI) GetTransactionsByAccountHandler(w http.ResponseWrit
 
 	httputils.MustWriteJSON(w, 200, list)
 }
+
+// GetTransactionProofHandler returns the merkle proof of the confirmed
+// transaction; it can be verified with the `TransactionsRoot` of block.
+func (api NetworkHandlerAPI) GetTransactionProofHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	key := vars["id"]
+
+	readFunc := func() (payload interface{}, err error) {
+		found, err := block.ExistsBlockTransaction(api.storage, key)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.BlockTransactionDoesNotExists
+		}
+		bt, err := block.GetBlockTransaction(api.storage, key)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := block.GetBlock(api.storage, bt.Block)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := blk.TransactionProof(bt.Hash)
+		if err != nil {
+			return nil, err
+		}
+		payload = resource.NewTransactionProof(bt.Hash, &blk, proof)
+		return payload, nil
+	}
+
+	payload, err := readFunc()
+	if err == nil {
+		httputils.MustWriteJSON(w, 200, payload)
+	} else {
+		httputils.WriteJSONError(w, err)
+	}
+}
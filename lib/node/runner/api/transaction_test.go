@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/observer"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
 	"github.com/stretchr/testify/require"
@@ -397,3 +398,47 @@ func TestGetTransactionsHandlerPage(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTransactionProofHandler(t *testing.T) {
+	ts, storage, err := prepareAPIServer()
+	require.NoError(t, err)
+	defer storage.Close()
+	defer ts.Close()
+
+	_, btList, err := prepareTxs(storage, 5)
+	require.NoError(t, err)
+
+	{ // unknown transaction
+		req, _ := http.NewRequest("GET", ts.URL+GetTransactionsHandlerPattern+"/findme/proof", nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	blk, err := block.GetBlock(storage, btList[0].Block)
+	require.NoError(t, err)
+
+	for i, bt := range btList {
+		respBody, err := request(ts, strings.Replace(GetTransactionProofHandlerPattern, "{id}", bt.Hash, -1), false)
+		require.NoError(t, err)
+		defer respBody.Close()
+
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+		var recv common.MerkleProof
+		require.NoError(t, json.Unmarshal(readByte, &recv))
+
+		m := make(map[string]interface{})
+		json.Unmarshal(readByte, &m)
+		require.Equal(t, bt.Hash, m["hash"])
+		require.Equal(t, blk.Hash, m["block"])
+		require.Equal(t, blk.TransactionsRoot, m["transactions_root"])
+
+		require.Equal(t, i, recv.Index)
+		require.Equal(t, len(btList), recv.Total)
+		require.True(t, common.VerifyMerkleProof(blk.TransactionsRoot, bt.Hash, recv))
+		require.False(t, common.VerifyMerkleProof(blk.TransactionsRoot, btList[(i+1)%len(btList)].Hash, recv))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/errors"
@@ -20,6 +21,8 @@ const (
 	NodeItemBlockHeader      NodeItemDataType = "block-header"
 	NodeItemBlockTransaction NodeItemDataType = "block-transaction"
 	NodeItemTransaction      NodeItemDataType = "transaction"
+	NodeItemSnapshot         NodeItemDataType = "snapshot"
+	NodeItemSnapshotChunk    NodeItemDataType = "snapshot-chunk"
 	NodeItemError            NodeItemDataType = "error"
 )
 
@@ -106,7 +109,7 @@ func (nh NetworkHandlerNode) GetBlocksHandler(w http.ResponseWriter, r *http.Req
 
 	// set header; `X-SEBAK-xxx` indicates the basic explanation of the
 	// response.
-	w.Header().Set("X-SEBAK-RESULT-COUNT", string(len(bs)))
+	w.Header().Set("X-SEBAK-RESULT-COUNT", strconv.Itoa(len(bs)))
 
 	for _, b := range bs {
 		var itemType NodeItemDataType
@@ -177,6 +180,14 @@ func UnmarshalNodeItemResponse(d []byte) (itemType NodeItemDataType, b interface
 		var t transaction.Transaction
 		err = unmarshal(&t)
 		b = t
+	case NodeItemSnapshot:
+		var t block.Snapshot
+		err = unmarshal(&t)
+		b = t
+	case NodeItemSnapshotChunk:
+		var t block.SnapshotChunk
+		err = unmarshal(&t)
+		b = t
 	case NodeItemError:
 		var t errors.Error
 		err = unmarshal(&t)
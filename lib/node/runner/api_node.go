@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -32,6 +33,7 @@ type NetworkHandlerNode struct {
 	transactionPool *transaction.Pool
 	urlPrefix       string
 	conf            common.Config
+	GetSyncInfo     func(context.Context) (node.NodeSyncInfo, error)
 }
 
 func NewNetworkHandlerNode(localNode *node.LocalNode, network network.Network, storage *storage.LevelDBBackend, consensus *consensus.ISAAC, transactionPool *transaction.Pool, urlPrefix string, conf common.Config) *NetworkHandlerNode {
@@ -102,6 +104,17 @@ var HandleTransactionCheckerFuncs = []common.CheckerFunc{
 	BroadcastTransaction,
 }
 
+// HandleWatcherTransactionCheckerFuncs is used by the watcher node; the
+// watcher does not keep the transactions in the `Pool`, it validates and
+// forwards them to the validators.
+var HandleWatcherTransactionCheckerFuncs = []common.CheckerFunc{
+	TransactionUnmarshal,
+	HasTransaction,
+	SaveTransactionHistory,
+	MessageValidate,
+	BroadcastTransaction,
+}
+
 func (api NetworkHandlerNode) MessageHandler(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
@@ -111,9 +124,14 @@ func (api NetworkHandlerNode) MessageHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	checkerFuncs := HandleTransactionCheckerFuncs
+	if api.localNode.IsWatcher() {
+		checkerFuncs = HandleWatcherTransactionCheckerFuncs
+	}
+
 	message := common.NetworkMessage{Type: common.TransactionMessage, Data: body}
 	checker := &MessageChecker{
-		DefaultChecker:  common.DefaultChecker{Funcs: HandleTransactionCheckerFuncs},
+		DefaultChecker:  common.DefaultChecker{Funcs: checkerFuncs},
 		Consensus:       api.consensus,
 		TransactionPool: api.transactionPool,
 		Storage:         api.storage,
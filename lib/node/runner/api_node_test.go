@@ -101,6 +101,7 @@ func createNewHTTP2Network(t *testing.T) (kp *keypair.Full, n *network.HTTP2Netw
 		localNode,
 		n,
 		p,
+		common.NewConfig(),
 	)
 
 	st := block.InitTestBlockchain()
@@ -225,7 +226,7 @@ func TestGetNodeInfoHandler(t *testing.T) {
 		networkID,
 		localNode,
 		nil,
-		network.NewValidatorConnectionManager(localNode, nil, nil),
+		network.NewValidatorConnectionManager(localNode, nil, nil, common.NewConfig()),
 		st,
 		common.NewConfig(),
 		nil,
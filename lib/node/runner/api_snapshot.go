@@ -0,0 +1,74 @@
+package runner
+
+import (
+	"net/http"
+	"strconv"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+)
+
+const (
+	GetSnapshotPattern      = "/snapshot"
+	GetSnapshotChunkPattern = "/snapshot/chunk"
+)
+
+// GetSnapshotHandler returns the snapshot of `height`; without `height`, the
+// latest snapshot is returned.
+func (nh NetworkHandlerNode) GetSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	var s block.Snapshot
+	var err error
+
+	if value := r.URL.Query().Get("height"); len(value) > 0 {
+		var height uint64
+		if height, err = strconv.ParseUint(value, 10, 64); err != nil {
+			http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+			return
+		}
+		s, err = block.GetSnapshot(nh.storage, height)
+	} else {
+		s, err = block.GetLatestSnapshot(nh.storage)
+	}
+
+	if err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	nh.renderNodeItem(w, NodeItemSnapshot, s)
+}
+
+// GetSnapshotChunkHandler returns the chunk of snapshot by `height` and
+// `index`.
+func (nh NetworkHandlerNode) GetSnapshotChunkHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	height, err := strconv.ParseUint(query.Get("height"), 10, 64)
+	if err != nil {
+		http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+		return
+	}
+	index, err := strconv.ParseUint(query.Get("index"), 10, 64)
+	if err != nil {
+		http.Error(w, errors.InvalidQueryString.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := block.GetSnapshotChunk(nh.storage, height, index)
+	if err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	nh.renderNodeItem(w, NodeItemSnapshotChunk, c)
+}
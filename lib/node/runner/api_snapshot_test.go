@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+func TestGetSnapshotHandler(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	apiHandler := NetworkHandlerNode{storage: st}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetSnapshotPattern, apiHandler.GetSnapshotHandler).Methods("GET")
+	router.HandleFunc(GetSnapshotChunkPattern, apiHandler.GetSnapshotChunkHandler).Methods("GET")
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	request := func(pattern string, query url.Values) (*http.Response, NodeItemDataType, interface{}) {
+		u, _ := url.Parse(server.URL)
+		u.Path = pattern
+		u.RawQuery = query.Encode()
+
+		resp, err := http.Get(u.String())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return resp, "", nil
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		itemType, item, err := UnmarshalNodeItemResponse(body)
+		require.NoError(t, err)
+
+		return resp, itemType, item
+	}
+
+	{ // no snapshot yet
+		resp, _, _ := request(GetSnapshotPattern, url.Values{})
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	kp, _ := keypair.Random()
+	s, err := block.MakeSnapshot(st, block.GetGenesis(st), 1)
+	require.NoError(t, err)
+	s.Sign(kp, networkID)
+	require.NoError(t, s.Save(st))
+
+	{ // latest snapshot
+		resp, itemType, item := request(GetSnapshotPattern, url.Values{})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, NodeItemSnapshot, itemType)
+		require.Equal(t, s.H.Hash, item.(block.Snapshot).H.Hash)
+		require.NoError(t, item.(block.Snapshot).IsWellFormed(networkID))
+	}
+
+	{ // by height
+		resp, _, item := request(GetSnapshotPattern, url.Values{"height": []string{"1"}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, s.H.Hash, item.(block.Snapshot).H.Hash)
+
+		resp, _, _ = request(GetSnapshotPattern, url.Values{"height": []string{"2"}})
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, _, _ = request(GetSnapshotPattern, url.Values{"height": []string{"findme"}})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	{ // chunks
+		for i, hash := range s.B.Chunks {
+			query := url.Values{}
+			query.Set("height", "1")
+			query.Set("index", strconv.Itoa(i))
+			resp, itemType, item := request(GetSnapshotChunkPattern, query)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, NodeItemSnapshotChunk, itemType)
+			require.Equal(t, hash, item.(block.SnapshotChunk).MakeHashString())
+		}
+
+		resp, _, _ := request(GetSnapshotChunkPattern, url.Values{"height": []string{"1"}, "index": []string{"2"}})
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, _, _ = request(GetSnapshotChunkPattern, url.Values{"height": []string{"1"}})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
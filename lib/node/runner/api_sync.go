@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/node"
+)
+
+const GetSyncPattern = "/sync"
+
+// SyncInfoTimeout limits the time to get the progress of sync; the syncer
+// can be busy or not started yet.
+const SyncInfoTimeout = 3 * time.Second
+
+// GetSyncHandler returns the progress of syncing blocks; the deploy tools can
+// wait until `synced` becomes true.
+func (nh NetworkHandlerNode) GetSyncHandler(w http.ResponseWriter, r *http.Request) {
+	if nh.GetSyncInfo == nil {
+		http.Error(w, "syncer is not available", http.StatusNotFound)
+		return
+	}
+
+	info, err := nh.GetSyncInfo(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := common.JSONMarshalIndent(info)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// SetSyncInfoFunc sets the function, which returns the progress of syncing
+// blocks, like `sync.Syncer.SyncInfo`; it must be set before `Start()`.
+func (nr *NodeRunner) SetSyncInfoFunc(f func(context.Context) (node.NodeSyncInfo, error)) {
+	nr.syncInfoFunc = f
+}
+
+// syncInfo returns the progress of syncing blocks; the node, which stored the
+// block by consensus, is synced even though the syncer did not know the
+// height of the peers.
+func (nr *NodeRunner) syncInfo(ctx context.Context) (node.NodeSyncInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, SyncInfoTimeout)
+	defer cancel()
+
+	info, err := nr.syncInfoFunc(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	if !info.Synced && nr.localNode.State() == node.StateCONSENSUS && atomic.LoadInt32(&nr.consensusStored) == 1 {
+		info.Synced = true
+	}
+
+	return info, nil
+}
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/node"
+)
+
+func TestGetSyncHandler(t *testing.T) {
+	apiHandler := NetworkHandlerNode{}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetSyncPattern, apiHandler.GetSyncHandler).Methods("GET")
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	{ // without syncer
+		resp, err := http.Get(server.URL + GetSyncPattern)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	expected := node.NodeSyncInfo{
+		StartingBlock:   2,
+		CurrentBlock:    50,
+		HighestBlock:    100,
+		BlocksPerSecond: 10,
+		ETA:             5 * time.Second,
+		FetchFailures:   map[string]uint64{"GABC": 1},
+	}
+	apiHandler.GetSyncInfo = func(context.Context) (node.NodeSyncInfo, error) {
+		return expected, nil
+	}
+	router = mux.NewRouter()
+	router.HandleFunc(GetSyncPattern, apiHandler.GetSyncHandler).Methods("GET")
+	server.Config.Handler = router
+
+	resp, err := http.Get(server.URL + GetSyncPattern)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var received node.NodeSyncInfo
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&received))
+	require.Equal(t, expected, received)
+}
+
+func TestNodeRunnerSyncInfo(t *testing.T) {
+	kp, _ := keypair.Random()
+	localNode, _ := node.NewLocalNode(kp, &common.Endpoint{}, "")
+
+	nr := &NodeRunner{localNode: localNode}
+	nr.SetSyncInfoFunc(func(context.Context) (node.NodeSyncInfo, error) {
+		return node.NodeSyncInfo{CurrentBlock: 1, HighestBlock: 1}, nil
+	})
+
+	// the height of the peers is not known yet
+	info, err := nr.syncInfo(context.Background())
+	require.NoError(t, err)
+	require.False(t, info.Synced)
+
+	// the block is stored by consensus
+	nr.consensusStored = 1
+	info, err = nr.syncInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, info.Synced)
+
+	// still syncing
+	localNode.SetSync()
+	info, err = nr.syncInfo(context.Background())
+	require.NoError(t, err)
+	require.False(t, info.Synced)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/errors"
@@ -46,7 +47,7 @@ func (nh NetworkHandlerNode) GetNodeTransactionsHandler(w http.ResponseWriter, r
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-SEBAK-RESULT-COUNT", string(len(hashes)))
+	w.Header().Set("X-SEBAK-RESULT-COUNT", strconv.Itoa(len(hashes)))
 
 	// check in `block.TransactionPool`
 	for _, hash := range hashes {
@@ -62,7 +62,7 @@ func (p *HelperTestGetNodeTransactionsHandler) Prepare() {
 		nil,
 	)
 	p.consensus = isaac
-	p.TransactionPool = transaction.NewPool()
+	p.TransactionPool = transaction.NewPool(common.NewConfig())
 
 	apiHandler := NetworkHandlerNode{storage: p.st, consensus: isaac, transactionPool: p.TransactionPool}
 
@@ -49,18 +49,11 @@ func (p *ballotCheckerProposedTransaction) MakeBallot(numberOfTxs int) (blt *bal
 	p.txHashes = []string{}
 	p.keys = map[string]*keypair.Full{}
 
-	rd := voting.Basis{
-		Round:     0,
-		Height:    p.genesisBlock.Height,
-		BlockHash: p.genesisBlock.Hash,
-		TotalTxs:  p.genesisBlock.TotalTxs,
-		TotalOps:  p.genesisBlock.TotalOps,
-	}
-
+	var accounts []*block.BlockAccount
 	for i := 0; i < numberOfTxs; i++ {
 		kpA, _ := keypair.Random()
 		accountA := block.NewBlockAccount(kpA.Address(), common.Amount(common.BaseReserve))
-		accountA.MustSave(p.nr.Storage())
+		accounts = append(accounts, accountA)
 
 		kpB, _ := keypair.Random()
 
@@ -76,10 +69,24 @@ func (p *ballotCheckerProposedTransaction) MakeBallot(numberOfTxs int) (blt *bal
 		p.nr.TransactionPool.Add(tx)
 	}
 
+	// the accounts are stored into the state trie of the new latest block
+	latestBlock := block.GetLatestBlock(p.nr.Storage())
+	if len(accounts) > 0 {
+		latestBlock = block.TestMakeNewBlockWithAccounts(p.nr.Storage(), latestBlock, accounts...)
+	}
+
+	rd := voting.Basis{
+		Round:     0,
+		Height:    latestBlock.Height,
+		BlockHash: latestBlock.Hash,
+		TotalTxs:  latestBlock.TotalTxs,
+		TotalOps:  latestBlock.TotalOps,
+	}
+
 	blt = ballot.NewBallot(p.proposerNode.Address(), p.proposerNode.Address(), rd, p.txHashes)
 
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*blt, p.commonAccount.Address, p.txs...)
-	opi, _ := ballot.NewInflationFromBallot(*blt, p.commonAccount.Address, p.initialBalance)
+	opi, _ := ballot.NewInflationFromBallot(*blt, p.commonAccount.Address, p.initialBalance, common.InflationRatio)
 
 	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
 	if err != nil {
@@ -87,6 +94,7 @@ func (p *ballotCheckerProposedTransaction) MakeBallot(numberOfTxs int) (blt *bal
 	}
 
 	blt.SetProposerTransaction(ptx)
+	blt.SetStateRoot(MakeTestStateRoot(p.nr.Storage(), ptx, p.txs...))
 	blt.SetVote(ballot.StateINIT, voting.YES)
 	blt.Sign(p.proposerNode.Keypair(), networkID)
 
@@ -216,6 +224,50 @@ func TestProposedTransactionWithTransactions(t *testing.T) {
 	require.Equal(t, voting.YES, checker.VotingHole)
 }
 
+func TestProposedTransactionWithWrongStateRoot(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+
+	blt := p.MakeBallot(3)
+	blt.SetStateRoot(p.genesisBlock.StateRoot)
+	blt.Sign(p.proposerNode.Keypair(), networkID)
+
+	var ballotMessage common.NetworkMessage
+	{
+		b, _ := blt.Serialize()
+		ballotMessage = common.NetworkMessage{
+			Type: common.BallotMessage,
+			Data: b,
+		}
+	}
+
+	baseChecker := &BallotChecker{
+		DefaultChecker: common.DefaultChecker{Funcs: DefaultHandleBaseBallotCheckerFuncs},
+		NodeRunner:     p.nr,
+		LocalNode:      p.nr.Node(),
+		NetworkID:      p.nr.NetworkID(),
+		Message:        ballotMessage,
+		Log:            p.nr.Log(),
+		VotingHole:     voting.NOTYET,
+	}
+	err := common.RunChecker(baseChecker, common.DefaultDeferFunc)
+	require.NoError(t, err)
+
+	checker := &BallotChecker{
+		DefaultChecker: common.DefaultChecker{Funcs: DefaultHandleINITBallotCheckerFuncs},
+		NodeRunner:     p.nr,
+		LocalNode:      p.nr.Node(),
+		NetworkID:      p.nr.NetworkID(),
+		Message:        ballotMessage,
+		Ballot:         baseChecker.Ballot,
+		VotingHole:     voting.NOTYET,
+		Log:            p.nr.Log(),
+	}
+	err = common.RunChecker(checker, common.DefaultDeferFunc)
+	require.NoError(t, err)
+	require.Equal(t, voting.NO, checker.VotingHole)
+}
+
 // TestProposedTransactionDifferentSigning checks this rule,
 // `ProposerTransaction.Source()` must be same with `Ballot.Proposer()`, it
 // means, `ProposerTransaction` must be signed by same KP of ballot
@@ -722,7 +774,7 @@ func TestProposedTransactionStoreWithZeroAmount(t *testing.T) {
 
 	afterCommonAccount, _ := block.GetBlockAccount(p.nr.Storage(), p.commonAccount.Address)
 
-	inflationAmount, err := common.CalculateInflation(p.initialBalance)
+	inflationAmount, err := common.CalculateInflation(p.initialBalance, common.InflationRatio)
 	require.NoError(t, err)
 
 	require.Equal(t, previousCommonAccount.Balance+inflationAmount, afterCommonAccount.Balance)
@@ -804,7 +856,7 @@ func TestProposedTransactionStoreWithAmount(t *testing.T) {
 
 	afterCommonAccount, _ := block.GetBlockAccount(p.nr.Storage(), p.commonAccount.Address)
 
-	inflationAmount, err := common.CalculateInflation(p.initialBalance)
+	inflationAmount, err := common.CalculateInflation(p.initialBalance, common.InflationRatio)
 	require.NoError(t, err)
 	require.Equal(t, previousCommonAccount.Balance+opb.Amount+inflationAmount, afterCommonAccount.Balance)
 }
@@ -930,7 +982,7 @@ func TestCheckInflationBlockIncrease(t *testing.T) {
 		common.InflationRatioString,
 	)
 
-	inflationAmount, err := common.CalculateInflation(nr.InitialBalance)
+	inflationAmount, err := common.CalculateInflation(nr.InitialBalance, common.InflationRatio)
 	require.NoError(t, err)
 
 	var previous common.Amount
@@ -945,8 +997,10 @@ func TestProposedTransactionReachedBlockHeightEndOfInflation(t *testing.T) {
 	p.Prepare()
 
 	{ // Height = common.BlockHeightEndOfInflation
+		// `MakeBallot()` saves the next block of the accounts, so the ballot
+		// has the height of the block after this one
 		genesisBlock := p.genesisBlock
-		genesisBlock.Height = common.BlockHeightEndOfInflation
+		genesisBlock.Height = common.BlockHeightEndOfInflation - 1
 		genesisBlock.Hash = base58.Encode(common.MustMakeObjectHash(genesisBlock))
 		p.genesisBlock = genesisBlock
 
@@ -990,12 +1044,8 @@ func TestProposedTransactionReachedBlockHeightEndOfInflation(t *testing.T) {
 	}
 
 	{ // Height = common.BlockHeightEndOfInflation + 1
-		genesisBlock := p.genesisBlock
-		genesisBlock.Height = common.BlockHeightEndOfInflation + 1
-		genesisBlock.Hash = base58.Encode(common.MustMakeObjectHash(genesisBlock))
-		p.genesisBlock = genesisBlock
-		p.genesisBlock.Save(p.nr.Storage())
-
+		// the latest block is the block of the accounts of the previous
+		// ballot, at `common.BlockHeightEndOfInflation`
 		blt := p.MakeBallot(4)
 
 		var ballotMessage common.NetworkMessage
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"sync/atomic"
 
+	"github.com/btcsuite/btcutil/base58"
 	logging "github.com/inconshreveable/log15"
 
 	"boscoin.io/sebak/lib/ballot"
@@ -14,6 +16,8 @@ import (
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/storage/statedb"
+	"boscoin.io/sebak/lib/storage/statedb/trie"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
@@ -121,14 +125,15 @@ func BallotValidateOperationBodyInflation(c common.Checker, args ...interface{})
 		return
 	}
 
-	if opb.Ratio != common.InflationRatioString {
+	params := checker.NodeRunner.Conf.NetworkParams
+	if opb.Ratio != params.InflationRatioString() {
 		err = errors.InvalidOperation
 		return
 	}
 
 	var expectedInflation common.Amount
-	if checker.NodeRunner.Consensus().LatestBlock().Height <= common.BlockHeightEndOfInflation {
-		expectedInflation, err = common.CalculateInflation(checker.NodeRunner.InitialBalance)
+	if checker.NodeRunner.Consensus().LatestBlock().Height <= params.BlockHeightEndOfInflation {
+		expectedInflation, err = common.CalculateInflation(checker.NodeRunner.InitialBalance, params.InflationRatio)
 		if err != nil {
 			return
 		}
@@ -156,6 +161,15 @@ func BallotNotFromKnownValidators(c common.Checker, args ...interface{}) (err er
 	return
 }
 
+// BallotRelay relays the incoming ballot to the other validators in gossip
+// mode; see `network.Gossip`.
+func BallotRelay(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotChecker)
+	checker.NodeRunner.ConnectionManager().Relay(checker.Ballot)
+
+	return
+}
+
 // BallotCheckSYNC performs sync by considering sync condition.
 // And to participate in the consensus,
 // update the latestblock by referring to the database.
@@ -174,7 +188,10 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 		return nil
 	}
 
-	if !hasBallotValidProposer(is, b) {
+	// the weighted proposer can not be selected before the block of the
+	// voting basis is stored; such ballot still counts for the sync height
+	// and it's proposer is checked again before finishing.
+	if valid, err := checkBallotProposer(is, b); !valid && err != errors.StorageRecordDoesNotExist {
 		return nil
 	}
 
@@ -192,7 +209,7 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 	}
 
 	defer func() {
-		if b.VotingBasis().Height == syncHeight {
+		if err != errors.BallotHasInvalidProposer && b.VotingBasis().Height == syncHeight {
 			is.LatestBallot = b
 		}
 	}()
@@ -203,6 +220,11 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 		return NewCheckerStopCloseConsensus(checker, "ballot makes node in sync")
 	} else {
 		if latestHeight == syncHeight-1 { // finish previous and current height ballot
+			if valid, _ := checkBallotProposer(is, is.LatestBallot); !valid {
+				is.LatestBallot = ballot.Ballot{}
+				err = errors.BallotHasInvalidProposer
+				return err
+			}
 			_, err = finishBallot(
 				checker.NodeRunner.Storage(),
 				is.LatestBallot,
@@ -215,6 +237,10 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 			}
 		}
 
+		if valid, _ := checkBallotProposer(is, b); !valid {
+			err = errors.BallotHasInvalidProposer
+			return err
+		}
 		_, err = finishBallot(
 			checker.NodeRunner.Storage(),
 			checker.Ballot,
@@ -225,6 +251,9 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 		if err != nil {
 			return err
 		}
+		if err = checker.NodeRunner.updateValidators(); err != nil {
+			return err
+		}
 
 		checker.LocalNode.SetConsensus()
 		checker.NodeRunner.TransitISAACState(b.VotingBasis(), ballot.StateALLCONFIRM)
@@ -236,8 +265,15 @@ func isBallotAcceptYes(b ballot.Ballot) bool {
 	return b.State() == ballot.StateACCEPT && b.Vote() == voting.YES
 }
 
-func hasBallotValidProposer(is *consensus.ISAAC, b ballot.Ballot) bool {
-	return b.Proposer() == is.SelectProposer(b.VotingBasis().Height, b.VotingBasis().Round)
+// checkBallotProposer checks the proposer of ballot is the selected proposer
+// of it's voting basis. It returns error when the proposer can not be selected,
+// like the weighted selector without the block of the voting basis.
+func checkBallotProposer(is *consensus.ISAAC, b ballot.Ballot) (bool, error) {
+	proposer, err := is.SelectProposer(b.VotingBasis())
+	if err != nil {
+		return false, err
+	}
+	return b.Proposer() == proposer, nil
 }
 
 // BallotAlreadyFinished checks the incoming ballot in
@@ -480,8 +516,48 @@ func INITBallotValidateTransactions(c common.Checker, args ...interface{}) (err
 
 	if transactionsChecker.VotingHole == voting.NO {
 		checker.VotingHole = voting.NO
-	} else {
-		checker.VotingHole = voting.YES
+		return
+	}
+
+	if err = checkBallotStateRoot(checker); err != nil {
+		checker.VotingHole = voting.NO
+		checker.Log.Debug("failed to check state root of ballot", "error", err)
+		err = nil
+		return
+	}
+
+	checker.VotingHole = voting.YES
+
+	return
+}
+
+// checkBallotStateRoot applies the transactions of ballot to the state of
+// latest block without storing and compares the result with the state root,
+// which is expected by proposer.
+func checkBallotStateRoot(checker *BallotChecker) (err error) {
+	var txs []*transaction.Transaction
+	for _, hash := range checker.Ballot.Transactions() {
+		tx, found := checker.NodeRunner.TransactionPool.Get(hash)
+		if !found {
+			return errors.TransactionNotFound
+		}
+		txs = append(txs, &tx)
+	}
+
+	var root string
+	_, root, err = ApplyBlockState(
+		checker.NodeRunner.Storage(),
+		block.GetLatestBlock(checker.NodeRunner.Storage()),
+		txs,
+		checker.Ballot.ProposerTransaction(),
+		checker.Log,
+	)
+	if err != nil {
+		return
+	}
+	if root != checker.Ballot.StateRoot() {
+		err = errors.StateRootDoesNotMatch
+		return
 	}
 
 	return
@@ -501,7 +577,9 @@ func SIGNBallotBroadcast(c common.Checker, args ...interface{}) (err error) {
 		return
 
 	}
-	checker.NodeRunner.ConnectionManager().Broadcast(newBallot)
+	if err = checker.NodeRunner.castVote(newBallot); err != nil {
+		return
+	}
 	checker.Log.Debug("ballot will be broadcasted", "newBallot", newBallot)
 
 	return
@@ -533,7 +611,9 @@ func ACCEPTBallotBroadcast(c common.Checker, args ...interface{}) (err error) {
 		return
 
 	}
-	checker.NodeRunner.ConnectionManager().Broadcast(newBallot)
+	if err = checker.NodeRunner.castVote(newBallot); err != nil {
+		return
+	}
 	checker.Log.Debug("ballot will be broadcasted", "newBallot", newBallot)
 
 	return
@@ -593,6 +673,11 @@ func FinishedBallotStore(c common.Checker, args ...interface{}) (err error) {
 		}
 
 		checker.Log.Debug("ballot was stored", "block", *theBlock)
+		atomic.StoreInt32(&checker.NodeRunner.consensusStored, 1)
+		if verr := checker.NodeRunner.updateValidators(); verr != nil {
+			checker.Log.Error("failed to update validators", "error", verr)
+		}
+		checker.NodeRunner.makeSnapshot(*theBlock)
 		checker.NodeRunner.TransitISAACState(ballotRound, ballot.StateALLCONFIRM)
 
 		err = NewCheckerStopCloseConsensus(checker, "ballot got consensus and will be stored")
@@ -601,12 +686,20 @@ func FinishedBallotStore(c common.Checker, args ...interface{}) (err error) {
 		err = NewCheckerStopCloseConsensus(checker, "ballot got consensus")
 	}
 
-	checker.NodeRunner.Consensus().CloseConsensus(
+	if cerr := checker.NodeRunner.Consensus().CloseConsensus(
 		checker.Ballot.Proposer(),
 		ballotRound,
 		checker.FinishedVotingHole,
 		checker.NodeRunner.TransactionPool,
-	)
+	); cerr != nil {
+		checker.Log.Error("failed to close consensus", "error", cerr)
+	}
+
+	if checker.FinishedVotingHole == voting.YES {
+		// the new block is `ballotRound.Height + 1`, so the remaining
+		// transactions can be included from the next one.
+		checker.NodeRunner.evictExpiredTransactions(ballotRound.Height + 2)
+	}
 
 	return
 }
@@ -618,15 +711,35 @@ func finishBallot(st *storage.LevelDBBackend, b ballot.Ballot, transactionPool *
 		return nil, err
 	}
 
+	pTxHashes := b.B.Proposed.Transactions
+	proposedTransactions := make([]*transaction.Transaction, 0, len(pTxHashes))
 	var nOps int
-	for _, hash := range b.B.Proposed.Transactions {
+	for _, hash := range pTxHashes {
 		tx, found := transactionPool.Get(hash)
 		if !found {
-			return nil, errors.TransactionNotFound
+			var tp block.TransactionPool
+			if tp, err = block.GetTransactionPool(st, hash); err != nil {
+				err = errors.TransactionNotFound
+				return nil, err
+			}
+			tx = tp.Transaction()
 		}
+		proposedTransactions = append(proposedTransactions, &tx)
 		nOps += len(tx.B.Operations)
 	}
 
+	var sdb *statedb.StateDB
+	var stateRoot string
+	sdb, stateRoot, err = ApplyBlockState(st, block.GetLatestBlock(st), proposedTransactions, b.ProposerTransaction(), log)
+	if err != nil {
+		log.Error("failed to apply transactions to state", "error", err)
+		return nil, err
+	}
+	if stateRoot != b.StateRoot() {
+		log.Error("state root does not match", "in ballot", b.StateRoot(), "state root", stateRoot)
+		return nil, errors.StateRootDoesNotMatch
+	}
+
 	r := b.VotingBasis()
 	r.Height++                                      // next block
 	r.TotalTxs += uint64(len(b.Transactions()) + 1) // + 1 for ProposerTransaction
@@ -637,6 +750,7 @@ func finishBallot(st *storage.LevelDBBackend, b ballot.Ballot, transactionPool *
 		r,
 		b.ProposerTransaction().GetHash(),
 		b.Transactions(),
+		stateRoot,
 		b.ProposerConfirmed(),
 	)
 
@@ -653,23 +767,9 @@ func finishBallot(st *storage.LevelDBBackend, b ballot.Ballot, transactionPool *
 		"total-txs", blk.TotalTxs,
 		"total-ops", blk.TotalOps,
 		"proposer", blk.Proposer,
+		"state-root", blk.StateRoot,
 	)
 
-	pTxHashes := b.B.Proposed.Transactions
-	proposedTransactions := make([]*transaction.Transaction, 0, len(pTxHashes))
-	for _, hash := range pTxHashes {
-		tx, found := transactionPool.Get(hash)
-		if !found {
-			var tp block.TransactionPool
-			if tp, err = block.GetTransactionPool(st, hash); err != nil {
-				err = errors.TransactionNotFound
-				return nil, err
-			}
-			tx = tp.Transaction()
-		}
-		proposedTransactions = append(proposedTransactions, &tx)
-	}
-
 	if err = FinishTransactions(*blk, proposedTransactions, st); err != nil {
 		return nil, err
 	}
@@ -679,6 +779,11 @@ func finishBallot(st *storage.LevelDBBackend, b ballot.Ballot, transactionPool *
 		return nil, err
 	}
 
+	if err = CommitBlockState(st, *blk, sdb); err != nil {
+		log.Error("failed to store state", "block", blk, "error", err)
+		return nil, err
+	}
+
 	return blk, nil
 }
 
@@ -704,52 +809,110 @@ func isValidRound(st *storage.LevelDBBackend, r voting.Basis, log logging.Logger
 	return true, nil
 }
 
-func FinishTransactions(blk block.Block, transactions []*transaction.Transaction, st *storage.LevelDBBackend) (err error) {
+// NewStateDB opens the account state of the given block.
+func NewStateDB(st *storage.LevelDBBackend, blk block.Block) *statedb.StateDB {
+	return statedb.New(blk.StateRootHash(), trie.NewEthDatabase(st))
+}
+
+// ApplyBlockState applies the transactions and the proposer transaction of
+// the next block of `prevBlock` to the account state and returns the new
+// state root. The changed state is kept in memory until
+// `statedb.StateDB.CommitDB()` is called, so it can be used to check the
+// state root of ballot without storing it.
+func ApplyBlockState(st *storage.LevelDBBackend, prevBlock block.Block, transactions []*transaction.Transaction, ptx ballot.ProposerTransaction, log logging.Logger) (sdb *statedb.StateDB, root string, err error) {
+	sdb = NewStateDB(st, prevBlock)
+
 	for _, tx := range transactions {
-		bt := block.NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.Confirmed, *tx)
-		if err = bt.Save(st); err != nil {
-			return
-		}
 		for _, op := range tx.B.Operations {
-			if err = finishOperation(st, tx.B.Source, op, log); err != nil {
-				log.Error("failed to finish operation", "block", blk, "bt", bt, "op", op, "error", err)
-				return err
+			if err = finishOperation(sdb, tx.B.Source, op, log); err != nil {
+				log.Error("failed to finish operation", "tx", tx.GetHash(), "op", op, "error", err)
+				return
 			}
 		}
 
-		var baSource *block.BlockAccount
-		if baSource, err = block.GetBlockAccount(st, tx.B.Source); err != nil {
-			err = errors.BlockAccountDoesNotExists
+		var sequenceID uint64
+		if sequenceID, err = sdb.GetCheckPoint(tx.B.Source); err != nil {
+			return
+		}
+		if err = sdb.SubBalanceWithSequenceID(tx.B.Source, tx.TotalAmount(true), sequenceID+1); err != nil {
 			return
 		}
+	}
+
+	if err = finishProposerTransactionState(sdb, ptx, log); err != nil {
+		return
+	}
+
+	var hash common.Hash
+	if hash, err = sdb.CommitTrie(); err != nil {
+		return
+	}
+	root = base58.Encode(hash[:])
+
+	return
+}
+
+// CommitBlockState stores the account state applied by `ApplyBlockState()`
+// and the history of the changed accounts at the height of `blk`.
+func CommitBlockState(st *storage.LevelDBBackend, blk block.Block, sdb *statedb.StateDB) (err error) {
+	accounts := sdb.ChangedAccounts()
+	if err = sdb.CommitDB(blk.StateRootHash()); err != nil {
+		return
+	}
 
-		if err = baSource.Withdraw(tx.TotalAmount(true)); err != nil {
+	for _, ba := range accounts {
+		bah := block.NewBlockAccountHistory(ba, blk.Height)
+		if err = bah.Save(st); err != nil {
 			return
 		}
+	}
+
+	return
+}
 
-		if err = baSource.Save(st); err != nil {
+// FinishTransactions stores the transactions of block; the operations of
+// transactions are applied to the account state by `ApplyBlockState()`. The
+// changes of validator set are stored to be applied at their activation
+// height.
+func FinishTransactions(blk block.Block, transactions []*transaction.Transaction, st *storage.LevelDBBackend) (err error) {
+	for i, tx := range transactions {
+		bt := block.NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.Confirmed, *tx)
+		if err = bt.Save(st); err != nil {
 			return
 		}
 
+		for j, op := range tx.B.Operations {
+			if !block.IsValidatorSetChange(op) {
+				continue
+			}
+
+			var vsc block.ValidatorSetChange
+			if vsc, err = block.NewValidatorSetChange(blk.Height, tx.GetHash(), i, j, op); err != nil {
+				return
+			}
+			if err = vsc.Save(st); err != nil {
+				return
+			}
+		}
 	}
 	return
 }
 
 // finishOperation do finish the task after consensus by the type of each operation.
-func finishOperation(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+func finishOperation(sdb *statedb.StateDB, source string, op operation.Operation, log logging.Logger) (err error) {
 	switch op.H.Type {
 	case operation.TypeCreateAccount:
 		pop, ok := op.B.(operation.CreateAccount)
 		if !ok {
 			return errors.UnknownOperationType
 		}
-		return finishCreateAccount(st, source, pop, log)
+		return finishCreateAccount(sdb, source, pop, log)
 	case operation.TypePayment:
 		pop, ok := op.B.(operation.Payment)
 		if !ok {
 			return errors.UnknownOperationType
 		}
-		return finishPayment(st, source, pop, log)
+		return finishPayment(sdb, source, pop, log)
 	case operation.TypeCongressVoting, operation.TypeCongressVotingResult:
 		//Nothing to do
 		return
@@ -758,72 +921,85 @@ func finishOperation(st *storage.LevelDBBackend, source string, op operation.Ope
 		if !ok {
 			return errors.UnknownOperationType
 		}
-		return finishUnfreezeRequest(st, source, pop, log)
+		return finishUnfreezeRequest(sdb, source, pop, log)
+	case operation.TypeSetSigners:
+		pop, ok := op.B.(operation.SetSigners)
+		if !ok {
+			return errors.UnknownOperationType
+		}
+		return finishSetSigners(sdb, source, pop, log)
+	case operation.TypeAddValidator, operation.TypeRemoveValidator:
+		// the validator set is changed at the activation height by
+		// `NodeRunner`; nothing to do with the account state
+		return
 	default:
 		err = errors.UnknownOperationType
 		return
 	}
 }
 
-func finishCreateAccount(st *storage.LevelDBBackend, source string, op operation.CreateAccount, log logging.Logger) (err error) {
-
-	var baSource, baTarget *block.BlockAccount
-	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+func finishCreateAccount(sdb *statedb.StateDB, source string, op operation.CreateAccount, log logging.Logger) (err error) {
+	if !sdb.ExistAccount(source) {
 		err = errors.BlockAccountDoesNotExists
 		return
 	}
-	if baTarget, err = block.GetBlockAccount(st, op.TargetAddress()); err == nil {
+	if sdb.ExistAccount(op.TargetAddress()) {
 		err = errors.BlockAccountAlreadyExists
 		return
-	} else {
-		err = nil
 	}
 
-	baTarget = block.NewBlockAccountLinked(
-		op.TargetAddress(),
-		op.GetAmount(),
-		op.Linked,
-	)
-	if err = baTarget.Save(st); err != nil {
+	sdb.CreateAccount(op.TargetAddress())
+	if err = sdb.AddBalance(op.TargetAddress(), op.GetAmount()); err != nil {
 		return
 	}
+	sdb.SetLinked(op.TargetAddress(), op.Linked)
 
-	log.Debug("new account created", "source", baSource, "target", baTarget)
+	log.Debug("new account created", "source", source, "target", op.TargetAddress(), "amount", op.GetAmount())
 
 	return
 }
 
-func finishPayment(st *storage.LevelDBBackend, source string, op operation.Payment, log logging.Logger) (err error) {
-
-	var baSource, baTarget *block.BlockAccount
-	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+func finishPayment(sdb *statedb.StateDB, source string, op operation.Payment, log logging.Logger) (err error) {
+	if !sdb.ExistAccount(source) {
 		err = errors.BlockAccountDoesNotExists
 		return
 	}
-	if baTarget, err = block.GetBlockAccount(st, op.TargetAddress()); err != nil {
+	if !sdb.ExistAccount(op.TargetAddress()) {
 		err = errors.BlockAccountDoesNotExists
 		return
 	}
 
-	if err = baTarget.Deposit(op.GetAmount()); err != nil {
+	if err = sdb.AddBalance(op.TargetAddress(), op.GetAmount()); err != nil {
 		return
 	}
-	if err = baTarget.Save(st); err != nil {
+
+	log.Debug("payment done", "source", source, "target", op.TargetAddress(), "amount", op.GetAmount())
+
+	return
+}
+
+// FinishProposerTransaction stores the proposer transaction of block; the
+// operations are applied to the account state by `ApplyBlockState()`.
+func FinishProposerTransaction(st *storage.LevelDBBackend, blk block.Block, ptx ballot.ProposerTransaction, log logging.Logger) (err error) {
+	bt := block.NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.Confirmed, ptx.Transaction)
+	if err = bt.Save(st); err != nil {
 		return
 	}
 
-	log.Debug("payment done", "source", baSource, "target", baTarget, "amount", op.GetAmount())
+	if _, err = block.SaveTransactionPool(st, ptx.Transaction); err != nil {
+		return
+	}
 
 	return
 }
 
-func FinishProposerTransaction(st *storage.LevelDBBackend, blk block.Block, ptx ballot.ProposerTransaction, log logging.Logger) (err error) {
+func finishProposerTransactionState(sdb *statedb.StateDB, ptx ballot.ProposerTransaction, log logging.Logger) (err error) {
 	{
 		var opb operation.CollectTxFee
 		if opb, err = ptx.CollectTxFee(); err != nil {
 			return
 		}
-		if err = finishCollectTxFee(st, opb, log); err != nil {
+		if err = finishCollectTxFee(sdb, opb, log); err != nil {
 			return
 		}
 	}
@@ -833,68 +1009,64 @@ func FinishProposerTransaction(st *storage.LevelDBBackend, blk block.Block, ptx
 		if opb, err = ptx.Inflation(); err != nil {
 			return
 		}
-		if err = finishInflation(st, opb, log); err != nil {
+		if err = finishInflation(sdb, opb, log); err != nil {
 			return
 		}
 	}
 
-	bt := block.NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.Confirmed, ptx.Transaction)
-	if err = bt.Save(st); err != nil {
-		return
-	}
-
-	if _, err = block.SaveTransactionPool(st, ptx.Transaction); err != nil {
-		return
-	}
-
 	return
 }
 
-func finishCollectTxFee(st *storage.LevelDBBackend, opb operation.CollectTxFee, log logging.Logger) (err error) {
+func finishCollectTxFee(sdb *statedb.StateDB, opb operation.CollectTxFee, log logging.Logger) (err error) {
 	if opb.Amount < 1 {
 		return
 	}
 
-	var commonAccount *block.BlockAccount
-	if commonAccount, err = block.GetBlockAccount(st, opb.TargetAddress()); err != nil {
-		return
-	}
-
-	if err = commonAccount.Deposit(opb.GetAmount()); err != nil {
+	if !sdb.ExistAccount(opb.TargetAddress()) {
+		err = errors.BlockAccountDoesNotExists
 		return
 	}
 
-	if err = commonAccount.Save(st); err != nil {
+	if err = sdb.AddBalance(opb.TargetAddress(), opb.GetAmount()); err != nil {
 		return
 	}
 
 	return
 }
 
-func finishInflation(st *storage.LevelDBBackend, opb operation.Inflation, log logging.Logger) (err error) {
+func finishInflation(sdb *statedb.StateDB, opb operation.Inflation, log logging.Logger) (err error) {
 	if opb.Amount < 1 {
 		return
 	}
 
-	var commonAccount *block.BlockAccount
-	if commonAccount, err = block.GetBlockAccount(st, opb.TargetAddress()); err != nil {
-		return
-	}
-
-	if err = commonAccount.Deposit(opb.GetAmount()); err != nil {
+	if !sdb.ExistAccount(opb.TargetAddress()) {
+		err = errors.BlockAccountDoesNotExists
 		return
 	}
 
-	if err = commonAccount.Save(st); err != nil {
+	if err = sdb.AddBalance(opb.TargetAddress(), opb.GetAmount()); err != nil {
 		return
 	}
 
 	return
 }
 
-func finishUnfreezeRequest(st *storage.LevelDBBackend, source string, opb operation.UnfreezeRequest, log logging.Logger) (err error) {
+func finishUnfreezeRequest(sdb *statedb.StateDB, source string, opb operation.UnfreezeRequest, log logging.Logger) (err error) {
 
 	log.Debug("UnfreezeRequest done")
 
 	return
 }
+
+func finishSetSigners(sdb *statedb.StateDB, source string, opb operation.SetSigners, log logging.Logger) (err error) {
+	if !sdb.ExistAccount(source) {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	sdb.SetSigners(source, opb.Signers, opb.Thresholds)
+
+	log.Debug("signers set", "source", source, "signers", opb.Signers, "thresholds", opb.Thresholds)
+
+	return
+}
@@ -127,7 +127,10 @@ func BallotTransactionsSourceCheck(c common.Checker, args ...interface{}) (err e
 	for _, hash := range checker.ValidTransactions {
 		tx, _ := checker.NodeRunner.TransactionPool.Get(hash)
 
-		if err = ValidateTx(checker.NodeRunner.Storage(), tx); err != nil {
+		if err = ValidateTxHeight(checker.NodeRunner.Storage(), tx); err == nil {
+			err = ValidateTx(checker.NodeRunner.Storage(), checker.NodeRunner.Conf, tx)
+		}
+		if err != nil {
 			if !checker.CheckTransactionsOnly {
 				return
 			}
@@ -210,11 +213,9 @@ func BallotTransactionsAllValid(c common.Checker, args ...interface{}) (err erro
 //        Only ever read from, never written to.
 //   tx = Transaction to check
 //
-func ValidateTx(st *storage.LevelDBBackend, tx transaction.Transaction) (err error) {
-	// check, source exists
+func ValidateTx(st *storage.LevelDBBackend, conf common.Config, tx transaction.Transaction) (err error) {
 	var ba *block.BlockAccount
-	if ba, err = block.GetBlockAccount(st, tx.B.Source); err != nil {
-		err = errors.BlockAccountDoesNotExists
+	if ba, err = validateTxSource(st, tx); err != nil {
 		return
 	}
 
@@ -239,8 +240,94 @@ func ValidateTx(st *storage.LevelDBBackend, tx transaction.Transaction) (err err
 		return
 	}
 
+	return validateTxOperations(st, conf, ba, tx)
+}
+
+// ValidateQueuedTx validates the transaction, which waits behind the
+// transactions of the same source in `transaction.Pool`; the sequenceID of
+// transaction is greater than the latest sequenceID of source account, so the
+// current balance should cover the queued transactions of source and the
+// transaction together. The transaction is validated again by `ValidateTx`
+// when it is proposed.
+func ValidateQueuedTx(st *storage.LevelDBBackend, conf common.Config, tp *transaction.Pool, tx transaction.Transaction) (err error) {
+	var ba *block.BlockAccount
+	if ba, err = validateTxSource(st, tx); err != nil {
+		return
+	}
+
+	if tx.IsValidSequenceID(ba.SequenceID) {
+		return ValidateTx(st, conf, tx)
+	} else if tx.B.SequenceID < ba.SequenceID {
+		err = errors.TransactionInvalidSequenceID
+		return
+	}
+
+	var totalAmount common.Amount
+	if totalAmount, err = tp.QueuedAmount(tx.B.Source).Add(tx.TotalAmount(true)); err != nil {
+		return
+	}
+	if ba.GetBalance() < totalAmount {
+		err = errors.TransactionExcessAbilityToPay
+		return
+	}
+
+	return validateTxOperations(st, conf, ba, tx)
+}
+
+// ValidatePoolTx validates the transaction, which will be put into `Pool`;
+// if the source already has transactions in `Pool`, the transaction can wait
+// behind them.
+func ValidatePoolTx(st *storage.LevelDBBackend, conf common.Config, tp *transaction.Pool, tx transaction.Transaction) error {
+	if next, found := tp.NextSequenceID(tx.Source()); found && tx.B.SequenceID == next {
+		return ValidateQueuedTx(st, conf, tp, tx)
+	}
+
+	return ValidateTx(st, conf, tx)
+}
+
+// ValidateTxHeight checks the next block is in the height bounds of
+// transaction; the transactions of ballot and block should be valid at the
+// height.
+func ValidateTxHeight(st *storage.LevelDBBackend, tx transaction.Transaction) (err error) {
+	if tx.B.MinHeight == 0 && tx.B.MaxHeight == 0 {
+		return
+	}
+
+	height := block.GetLatestBlock(st).Height + 1
+	if tx.IsExpired(height) {
+		err = errors.TransactionExpired
+	} else if !tx.IsValidHeight(height) {
+		err = errors.TransactionNotYetValid
+	}
+
+	return
+}
+
+func validateTxSource(st *storage.LevelDBBackend, tx transaction.Transaction) (ba *block.BlockAccount, err error) {
+	// check, the transaction is not expired; the transaction, which is not
+	// valid yet, waits in `transaction.Pool` until `min_height`.
+	if tx.B.MaxHeight > 0 && tx.IsExpired(block.GetLatestBlock(st).Height+1) {
+		err = errors.TransactionExpired
+		return
+	}
+
+	// check, source exists
+	if ba, err = block.GetBlockAccount(st, tx.B.Source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	// check, the signatures reach the threshold of source account
+	if err = ValidateTxSigners(ba, tx); err != nil {
+		return
+	}
+
+	return
+}
+
+func validateTxOperations(st *storage.LevelDBBackend, conf common.Config, ba *block.BlockAccount, tx transaction.Transaction) (err error) {
 	for _, op := range tx.B.Operations {
-		if err = ValidateOp(st, ba, op); err != nil {
+		if err = ValidateOp(st, conf, ba, op); err != nil {
 
 			key := block.GetBlockTransactionHistoryKey(tx.H.Hash)
 			st.Remove(key)
@@ -252,6 +339,39 @@ func ValidateTx(st *storage.LevelDBBackend, tx transaction.Transaction) (err err
 	return
 }
 
+//
+// Validate the signers of a transaction against the source account
+//
+// If the source account has no signers, the signature of the source itself is
+// required; it is already verified by `transaction.CheckVerifySignature`.
+// Otherwise the sum of weights of the signers must reach the threshold
+// required by the operations of transaction.
+//
+// Params:
+//   source = Account from where the transaction comes from
+//   tx = Transaction to check
+//
+func ValidateTxSigners(source *block.BlockAccount, tx transaction.Transaction) (err error) {
+	if !source.HasSigners() {
+		if len(tx.H.Signature) < 1 && len(tx.H.Signatures) > 0 {
+			err = errors.TransactionNotEnoughSignatureWeight
+		}
+		return
+	}
+
+	threshold := int(source.Thresholds.Get(tx.ThresholdLevel()))
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	if source.SignerWeight(tx.Signers()...) < threshold {
+		err = errors.TransactionNotEnoughSignatureWeight
+		return
+	}
+
+	return
+}
+
 //
 // Validate an operation
 //
@@ -264,7 +384,7 @@ func ValidateTx(st *storage.LevelDBBackend, tx transaction.Transaction) (err err
 //   source = Account from where the transaction (and ops) come from
 //   tx = Transaction to check
 //
-func ValidateOp(st *storage.LevelDBBackend, source *block.BlockAccount, op operation.Operation) (err error) {
+func ValidateOp(st *storage.LevelDBBackend, conf common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
 	switch op.H.Type {
 	case operation.TypeCreateAccount:
 		var ok bool
@@ -287,7 +407,7 @@ func ValidateOp(st *storage.LevelDBBackend, source *block.BlockAccount, op opera
 			}
 			lastblock := block.GetLatestBlock(st)
 			// unfreezing period is 241920.
-			if lastblock.Height-bo.Height < common.UnfreezingPeriod {
+			if lastblock.Height-bo.Height < conf.NetworkParams.UnfreezingPeriod {
 				return errors.UnfreezingNotReachedExpiration
 			}
 			// If it's a frozen account we check that only whole units are frozen
@@ -312,7 +432,7 @@ func ValidateOp(st *storage.LevelDBBackend, source *block.BlockAccount, op opera
 		if source.Linked != "" {
 			// If it's a frozen account, everything must be withdrawn
 			var expected common.Amount
-			expected, err = source.Balance.Sub(common.BaseFee)
+			expected, err = source.Balance.Sub(conf.NetworkParams.BaseFee)
 			if casted.Amount != expected {
 				return errors.FrozenAccountMustWithdrawEverything
 			}
@@ -326,7 +446,7 @@ func ValidateOp(st *storage.LevelDBBackend, source *block.BlockAccount, op opera
 			}
 			lastblock := block.GetLatestBlock(st)
 			// unfreezing period is 241920.
-			if lastblock.Height-bo.Height < common.UnfreezingPeriod {
+			if lastblock.Height-bo.Height < conf.NetworkParams.UnfreezingPeriod {
 				return errors.UnfreezingNotReachedExpiration
 			}
 		}
@@ -345,6 +465,21 @@ func ValidateOp(st *storage.LevelDBBackend, source *block.BlockAccount, op opera
 		if bo.Type == operation.TypeUnfreezingRequest {
 			return errors.UnfreezingRequestAlreadyReceived
 		}
+	case operation.TypeSetSigners:
+		if _, ok := op.B.(operation.SetSigners); !ok {
+			return errors.TypeOperationBodyNotMatched
+		}
+	case operation.TypeAddValidator, operation.TypeRemoveValidator:
+		var activationHeight uint64
+		switch casted := op.B.(type) {
+		case operation.AddValidator:
+			activationHeight = casted.ActivationHeight
+		case operation.RemoveValidator:
+			activationHeight = casted.ActivationHeight
+		default:
+			return errors.TypeOperationBodyNotMatched
+		}
+		return validateValidatorSetChange(st, source, activationHeight)
 	case operation.TypeCongressVoting, operation.TypeCongressVotingResult:
 		// Nothing to do
 		return
@@ -354,3 +489,22 @@ func ValidateOp(st *storage.LevelDBBackend, source *block.BlockAccount, op opera
 	}
 	return
 }
+
+// validateValidatorSetChange checks the change of validator set comes from
+// the genesis account and it will be activated after the block, which
+// includes it.
+func validateValidatorSetChange(st *storage.LevelDBBackend, source *block.BlockAccount, activationHeight uint64) (err error) {
+	var genesis *block.BlockAccount
+	if genesis, err = GetGenesisAccount(st); err != nil {
+		return
+	}
+	if source.Address != genesis.Address {
+		return errors.ValidatorSetChangeNotAllowed
+	}
+
+	if activationHeight <= block.GetLatestBlock(st).Height+1 {
+		return errors.InvalidActivationHeight
+	}
+
+	return
+}
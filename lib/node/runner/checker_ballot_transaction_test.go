@@ -50,7 +50,7 @@ func TestValidateTxPaymentMissingBlockAccount(t *testing.T) {
 		},
 	}
 	tx.H.Hash = tx.B.MakeHashString()
-	require.Equal(t, ValidateTx(st, tx), errors.BlockAccountDoesNotExists)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.BlockAccountDoesNotExists)
 
 	// Now add the source account but not the target
 	bas := block.BlockAccount{
@@ -58,7 +58,7 @@ func TestValidateTxPaymentMissingBlockAccount(t *testing.T) {
 		Balance: common.Amount(1 * common.AmountPerCoin),
 	}
 	bas.MustSave(st)
-	require.Equal(t, ValidateTx(st, tx), errors.BlockAccountDoesNotExists)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.BlockAccountDoesNotExists)
 
 	// Now just the target
 	st1 := storage.NewTestStorage()
@@ -68,14 +68,14 @@ func TestValidateTxPaymentMissingBlockAccount(t *testing.T) {
 		Balance: common.Amount(1 * common.AmountPerCoin),
 	}
 	bat.MustSave(st1)
-	require.Equal(t, ValidateTx(st1, tx), errors.BlockAccountDoesNotExists)
+	require.Equal(t, ValidateTx(st1, common.NewConfig(), tx), errors.BlockAccountDoesNotExists)
 
 	// And finally, bot
 	st2 := storage.NewTestStorage()
 	defer st2.Close()
 	bas.MustSave(st2)
 	bat.MustSave(st2)
-	require.Nil(t, ValidateTx(st2, tx))
+	require.Nil(t, ValidateTx(st2, common.NewConfig(), tx))
 }
 
 // Check for correct sequence ID
@@ -114,11 +114,11 @@ func TestValidateTxWrongSequenceID(t *testing.T) {
 		},
 	}
 	tx.H.Hash = tx.B.MakeHashString()
-	require.Equal(t, ValidateTx(st, tx), errors.TransactionInvalidSequenceID)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.TransactionInvalidSequenceID)
 	tx.B.SequenceID = 2
-	require.Equal(t, ValidateTx(st, tx), errors.TransactionInvalidSequenceID)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.TransactionInvalidSequenceID)
 	tx.B.SequenceID = 1
-	require.Nil(t, ValidateTx(st, tx))
+	require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
 }
 
 // Check sending the whole balance
@@ -158,10 +158,10 @@ func TestValidateTxOverBalance(t *testing.T) {
 		},
 	}
 	tx.H.Hash = tx.B.MakeHashString()
-	require.Equal(t, ValidateTx(st, tx), errors.TransactionExcessAbilityToPay)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.TransactionExcessAbilityToPay)
 	opbody.Amount = bas.Balance.MustSub(common.BaseFee)
 	tx.B.Operations[0].B = opbody
-	require.Nil(t, ValidateTx(st, tx))
+	require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
 
 	// Also test multiple operations
 	// Note: The account balance is 1 BOS (10M units), so we make 4 ops of 2,5M
@@ -170,12 +170,12 @@ func TestValidateTxOverBalance(t *testing.T) {
 	opbody.Amount = common.Amount(2500000)
 	op.B = opbody
 	tx.B.Operations = []operation.Operation{op, op, op, op}
-	require.Equal(t, ValidateTx(st, tx), errors.TransactionExcessAbilityToPay)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.TransactionExcessAbilityToPay)
 
 	// Now the total amount of the ops + balance is equal to the balance
 	opbody.Amount = opbody.Amount.MustSub(common.BaseFee.MustMult(len(tx.B.Operations)))
 	tx.B.Operations[0].B = opbody
-	require.Nil(t, ValidateTx(st, tx))
+	require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
 }
 
 // Test creating an already existing account
@@ -214,10 +214,136 @@ func TestValidateOpCreateExistsAccount(t *testing.T) {
 		},
 	}
 	tx.H.Hash = tx.B.MakeHashString()
-	require.Equal(t, ValidateTx(st, tx), errors.BlockAccountAlreadyExists)
+	require.Equal(t, ValidateTx(st, common.NewConfig(), tx), errors.BlockAccountAlreadyExists)
 
 	st1 := storage.NewTestStorage()
 	defer st1.Close()
 	bas.MustSave(st1)
-	require.Nil(t, ValidateTx(st1, tx))
+	require.Nil(t, ValidateTx(st1, common.NewConfig(), tx))
+}
+
+// Check the signatures against the signers of source account
+func TestValidateTxSigners(t *testing.T) {
+	kps, _ := keypair.Random()
+	kpt, _ := keypair.Random()
+	kpSigner0, _ := keypair.Random()
+	kpSigner1, _ := keypair.Random()
+
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	bas := block.BlockAccount{
+		Address: kps.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.SetSigners(
+		[]operation.Signer{
+			{Address: kps.Address(), Weight: 1},
+			{Address: kpSigner0.Address(), Weight: 1},
+			{Address: kpSigner1.Address(), Weight: 2},
+		},
+		operation.Thresholds{Low: 1, Medium: 2, High: 3},
+	)
+	bat := block.BlockAccount{
+		Address: kpt.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.MustSave(st)
+	bat.MustSave(st)
+
+	tx := transaction.Transaction{
+		H: transaction.Header{
+			Created: common.NowISO8601(),
+		},
+		B: transaction.Body{
+			Source:     kps.Address(),
+			Fee:        common.BaseFee,
+			SequenceID: 0,
+			Operations: []operation.Operation{
+				operation.Operation{
+					H: operation.Header{Type: operation.TypePayment},
+					B: operation.Payment{Target: kpt.Address(), Amount: common.Amount(10000)},
+				},
+			},
+		},
+	}
+
+	{ // only source signed; weight 1 is under medium threshold
+		tx.Sign(kps, networkID)
+		require.Equal(t, errors.TransactionNotEnoughSignatureWeight, ValidateTx(st, common.NewConfig(), tx))
+	}
+
+	{ // source and signer0 reach medium threshold
+		tx.AddSignature(kpSigner0, networkID)
+		require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
+	}
+
+	{ // signer1 alone reaches medium threshold
+		tx.H.Signature = ""
+		tx.H.Signatures = nil
+		tx.AddSignature(kpSigner1, networkID)
+		require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
+	}
+
+	{ // unknown signer does not count
+		kpUnknown, _ := keypair.Random()
+		tx.H.Signatures = nil
+		tx.AddSignature(kpSigner0, networkID)
+		tx.AddSignature(kpUnknown, networkID)
+		require.Equal(t, errors.TransactionNotEnoughSignatureWeight, ValidateTx(st, common.NewConfig(), tx))
+	}
+
+	{ // `SetSigners` requires high threshold
+		txSetSigners := tx
+		txSetSigners.B.Operations = []operation.Operation{
+			operation.Operation{
+				H: operation.Header{Type: operation.TypeSetSigners},
+				B: operation.NewSetSigners(nil, operation.Thresholds{}),
+			},
+		}
+		txSetSigners.H.Signatures = nil
+		txSetSigners.Sign(kps, networkID)
+		txSetSigners.AddSignature(kpSigner0, networkID)
+		require.Equal(t, errors.TransactionNotEnoughSignatureWeight, ValidateTx(st, common.NewConfig(), txSetSigners))
+
+		txSetSigners.AddSignature(kpSigner1, networkID)
+		require.Nil(t, ValidateTx(st, common.NewConfig(), txSetSigners))
+	}
+
+	{ // account without signers does not accept the other signers
+		tx.B.Source = kpt.Address()
+		tx.B.Operations[0].B = operation.Payment{Target: kps.Address(), Amount: common.Amount(10000)}
+		tx.H.Signature = ""
+		tx.H.Signatures = nil
+		tx.AddSignature(kpSigner0, networkID)
+		require.Equal(t, errors.TransactionNotEnoughSignatureWeight, ValidateTx(st, common.NewConfig(), tx))
+	}
+}
+
+func TestValidateTxHeightBounds(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	// the latest block is genesis, so the transaction will be in block 2
+	tx, _ := GetTransaction()
+	require.Nil(t, ValidateTxHeight(st, tx))
+	require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
+
+	// the transaction, which is not valid yet, can wait in the pool
+	tx.B.MinHeight = 3
+	tx.Sign(block.GenesisKP, networkID)
+	require.Equal(t, errors.TransactionNotYetValid, ValidateTxHeight(st, tx))
+	require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
+
+	tx.B.MinHeight = 2
+	tx.B.MaxHeight = 2
+	tx.Sign(block.GenesisKP, networkID)
+	require.Nil(t, ValidateTxHeight(st, tx))
+	require.Nil(t, ValidateTx(st, common.NewConfig(), tx))
+
+	tx.B.MinHeight = 0
+	tx.B.MaxHeight = 1
+	tx.Sign(block.GenesisKP, networkID)
+	require.Equal(t, errors.TransactionExpired, ValidateTxHeight(st, tx))
+	require.Equal(t, errors.TransactionExpired, ValidateTx(st, common.NewConfig(), tx))
 }
@@ -102,12 +102,13 @@ func SaveTransactionHistory(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
-// SameSource checks there are transactions which has same source in the
-// `Pool`.
+// SameSource checks there are transactions which has same source and same
+// sequence ID in the `Pool`.
 func MessageHasSameSource(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*MessageChecker)
 
-	if checker.TransactionPool.IsSameSource(checker.Transaction.Source()) {
+	tx := checker.Transaction
+	if checker.TransactionPool.HasSequenceID(tx.Source(), tx.B.SequenceID) {
 		err = errors.TransactionSameSource
 		return
 	}
@@ -115,11 +116,12 @@ func MessageHasSameSource(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
-// MessageValidate validates.
+// MessageValidate validates. If the source already has transactions in the
+// `Pool`, the transaction can wait behind them.
 func MessageValidate(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*MessageChecker)
 
-	if err = ValidateTx(checker.Storage, checker.Transaction); err != nil {
+	if err = ValidatePoolTx(checker.Storage, checker.Conf, checker.TransactionPool, checker.Transaction); err != nil {
 		return
 	}
 
@@ -132,12 +134,41 @@ func PushIntoTransactionPool(c common.Checker, args ...interface{}) (err error)
 	checker := c.(*MessageChecker)
 
 	tx := checker.Transaction
-	checker.TransactionPool.Add(tx)
+
+	var evicted []transaction.Transaction
+	if evicted, err = checker.TransactionPool.Add(tx); err != nil {
+		return
+	}
+
+	// if the transaction is not stored, it is removed from `Pool` and the
+	// evicted transactions are put back.
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if rerr := checker.TransactionPool.Remove(tx.GetHash()); rerr != nil {
+			checker.Log.Error("failed to remove transaction from TransactionPool", "transaction", tx.GetHash(), "error", rerr)
+			return
+		}
+		for _, etx := range evicted {
+			if _, rerr := checker.TransactionPool.Add(etx); rerr != nil {
+				checker.Log.Error("failed to restore evicted transaction", "transaction", etx.GetHash(), "error", rerr)
+			}
+		}
+	}()
 
 	if _, err = block.SaveTransactionPool(checker.Storage, tx); err != nil {
 		return
 	}
 
+	for _, etx := range evicted {
+		checker.Log.Debug("transaction evicted from full TransactionPool", "transaction", etx.GetHash())
+		if err = block.SaveTransactionHistory(checker.Storage, etx, block.TransactionHistoryStatusRejected); err != nil {
+			return
+		}
+	}
+
 	checker.Log.Debug("push transaction into TransactionPool")
 
 	return
@@ -10,9 +10,11 @@ import (
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -87,6 +89,47 @@ func TestOnlyValidTransactionInTransactionPool(t *testing.T) {
 		)
 	}
 
+	{ // valid transaction: queued after the transaction of same source in Pool
+		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
+		targetAccount.MustSave(nodeRunner.Storage())
+
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, rootKP, targetKP)
+		tx.B.SequenceID = rootAccount.SequenceID + 1
+		tx.Sign(rootKP, networkID)
+
+		runChecker(tx, nil)
+
+		require.True(t, nodeRunner.TransactionPool.Has(tx.GetHash()), "queued transaction must be in `Pool`")
+		require.Equal(t, 1, len(nodeRunner.TransactionPool.AvailableTransactions(10, 1)))
+	}
+
+	{ // invalid transaction: balance does not cover the queued transactions of same source
+		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
+		targetAccount.MustSave(nodeRunner.Storage())
+
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, rootKP, targetKP)
+		tx.B.SequenceID = rootAccount.SequenceID + 2
+		tx.B.Operations[0].B = operation.NewPayment(targetKP.Address(), rootAccount.Balance-tx.B.Fee)
+		tx.Sign(rootKP, networkID)
+
+		runChecker(tx, errors.TransactionExcessAbilityToPay)
+
+		require.False(t, nodeRunner.TransactionPool.Has(tx.GetHash()))
+	}
+
+	{ // invalid transaction: sequence id is not next to the transactions of same source in Pool
+		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
+		targetAccount.MustSave(nodeRunner.Storage())
+
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, rootKP, targetKP)
+		tx.B.SequenceID = rootAccount.SequenceID + 3
+		tx.Sign(rootKP, networkID)
+
+		runChecker(tx, errors.TransactionInvalidSequenceID)
+
+		require.False(t, nodeRunner.TransactionPool.Has(tx.GetHash()))
+	}
+
 	{ // invalid transaction: source account does not exists
 		_, sourceKP := TestMakeBlockAccount(common.Amount(10000000000000))
 		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
@@ -220,10 +263,11 @@ func (g *getMissingTransactionTesting) MakeBallot(numberOfTxs int) (blt *ballot.
 	blt = ballot.NewBallot(g.proposerNR.Node().Address(), g.proposerNR.Node().Address(), rd, txHashes)
 
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*blt, g.commonAccount.Address, txs...)
-	opi, _ := ballot.NewInflationFromBallot(*blt, g.commonAccount.Address, g.initialBalance)
+	opi, _ := ballot.NewInflationFromBallot(*blt, g.commonAccount.Address, g.initialBalance, common.InflationRatio)
 
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
 	blt.SetProposerTransaction(ptx)
+	blt.SetStateRoot(MakeTestStateRoot(g.proposerNR.Storage(), ptx, txs...))
 	blt.SetVote(ballot.StateINIT, voting.YES)
 	blt.Sign(g.proposerNR.Node().Keypair(), networkID)
 
@@ -454,10 +498,11 @@ func (p *irregularIncomingBallot) makeBallot(state ballot.State) (blt *ballot.Ba
 	blt = ballot.NewBallot(p.nr.Node().Address(), p.nr.Node().Address(), rd, []string{tx.GetHash()})
 
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*blt, p.commonAccount.Address, tx)
-	opi, _ := ballot.NewInflationFromBallot(*blt, p.commonAccount.Address, p.initialBalance)
+	opi, _ := ballot.NewInflationFromBallot(*blt, p.commonAccount.Address, p.initialBalance, common.InflationRatio)
 
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
 	blt.SetProposerTransaction(ptx)
+	blt.SetStateRoot(MakeTestStateRoot(p.nr.Storage(), ptx, tx))
 	blt.SetVote(state, voting.YES)
 	blt.Sign(p.nr.Node().Keypair(), networkID)
 
@@ -518,3 +563,33 @@ func TestIrregularIncomingBallots(t *testing.T) {
 	require.Equal(t, initBallot.H.ProposerSignature, received.H.ProposerSignature)
 	require.Equal(t, ballot.StateSIGN, received.State())
 }
+
+// TestCheckBallotProposer checks the proposer of the incoming ballot is
+// checked by the voting basis of ballot; the weighted selector can not select
+// the proposer before the block of the voting basis is stored.
+func TestCheckBallotProposer(t *testing.T) {
+	p := &irregularIncomingBallot{}
+	p.prepare()
+
+	blt := p.makeBallot(ballot.StateACCEPT)
+	is := p.nr.Consensus()
+
+	valid, err := checkBallotProposer(is, *blt)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	is.SetProposerSelector(FixedSelector{p.nodes[1].Address()})
+	valid, err = checkBallotProposer(is, *blt)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	is.SetProposerSelector(consensus.NewWeightedSelector(p.nr.ConnectionManager(), p.nr.Storage()))
+	_, err = checkBallotProposer(is, *blt)
+	require.NoError(t, err)
+
+	blt.B.Proposed.VotingBasis.Height = p.genesisBlock.Height + 1
+	blt.Sign(p.nr.Node().Keypair(), networkID)
+	valid, err = checkBallotProposer(is, *blt)
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+	require.False(t, valid)
+}
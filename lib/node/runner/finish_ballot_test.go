@@ -14,6 +14,7 @@ import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
@@ -95,17 +96,25 @@ func testFinishBallotWithBatch(withBatch bool, numberOfTransactions, numberOfOpe
 		var txs []transaction.Transaction
 		var txHashes []string
 
+		var kps []*keypair.Full
+		var accounts []*block.BlockAccount
+		for i := 0; i < numberOfTransactions; i++ {
+			kpA, _ := keypair.Random()
+			kps = append(kps, kpA)
+			accounts = append(accounts, block.NewBlockAccount(kpA.Address(), common.Amount(common.BaseReserve)))
+		}
+		latestBlock := block.TestMakeNewBlockWithAccounts(nr.Storage(), genesisBlock, accounts...)
+
 		rd := voting.Basis{
 			Round:     0,
-			Height:    genesisBlock.Height,
-			BlockHash: genesisBlock.Hash,
-			TotalTxs:  genesisBlock.TotalTxs,
+			Height:    latestBlock.Height,
+			BlockHash: latestBlock.Hash,
+			TotalTxs:  latestBlock.TotalTxs,
+			TotalOps:  latestBlock.TotalOps,
 		}
 
-		for i := 0; i < numberOfTransactions; i++ {
-			kpA, _ := keypair.Random()
-			accountA := block.NewBlockAccount(kpA.Address(), common.Amount(common.BaseReserve))
-			accountA.MustSave(nr.Storage())
+		for i, kpA := range kps {
+			accountA := accounts[i]
 
 			kpB, _ := keypair.Random()
 			tx := transaction.MakeTransactionCreateAccount(kpA, kpB.Address(), common.Amount(1))
@@ -135,10 +144,11 @@ func testFinishBallotWithBatch(withBatch bool, numberOfTransactions, numberOfOpe
 		blt = ballot.NewBallot(proposerNode.Address(), proposerNode.Address(), rd, txHashes)
 
 		opc, _ := ballot.NewCollectTxFeeFromBallot(*blt, commonAccount.Address, txs...)
-		opi, _ := ballot.NewInflationFromBallot(*blt, commonAccount.Address, initialBalance)
+		opi, _ := ballot.NewInflationFromBallot(*blt, commonAccount.Address, initialBalance, common.InflationRatio)
 		ptx, _ := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
 
 		blt.SetProposerTransaction(ptx)
+		blt.SetStateRoot(MakeTestStateRoot(nr.Storage(), ptx, txs...))
 		blt.SetVote(ballot.StateINIT, voting.YES)
 		blt.Sign(proposerNode.Keypair(), networkID)
 	}
@@ -315,3 +325,63 @@ func TestBenchmarkFinishBallot(t *testing.T) {
 	err = testFinishBallotWithBatch(true, 100, 100)
 	require.NoError(t, err)
 }
+
+func TestFinishBallotStateRoot(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+
+	blt := p.MakeBallot(3)
+	blk, err := finishBallot(p.nr.Storage(), *blt, p.nr.TransactionPool, p.nr.Log(), p.nr.Log())
+	require.NoError(t, err)
+	require.Equal(t, blt.StateRoot(), blk.StateRoot)
+	require.NotEqual(t, p.genesisBlock.StateRoot, blk.StateRoot)
+
+	// the state of block has the same accounts with storage
+	sdb := NewStateDB(p.nr.Storage(), *blk)
+	var addresses []string
+	for _, tx := range p.txs {
+		addresses = append(addresses, tx.B.Source)
+		for _, op := range tx.B.Operations {
+			addresses = append(addresses, op.B.(operation.Payable).TargetAddress())
+		}
+	}
+	addresses = append(addresses, p.commonAccount.Address)
+
+	for _, address := range addresses {
+		ba, err := block.GetBlockAccount(p.nr.Storage(), address)
+		require.NoError(t, err)
+		require.True(t, sdb.ExistAccount(address))
+		require.Equal(t, ba.Balance, sdb.GetBalance(address))
+		sequenceID, err := sdb.GetCheckPoint(address)
+		require.NoError(t, err)
+		require.Equal(t, ba.SequenceID, sequenceID)
+
+		// the changed accounts are kept in the history of block height
+		bah, err := block.GetBlockAccountHistory(p.nr.Storage(), address, blk.Height)
+		require.NoError(t, err)
+		require.Equal(t, blk.Height, bah.Height)
+		require.Equal(t, ba.Balance, bah.Balance)
+		require.Equal(t, ba.SequenceID, bah.SequenceID)
+	}
+}
+
+func TestFinishBallotWithWrongStateRoot(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+
+	blt := p.MakeBallot(3)
+	blt.SetStateRoot(p.genesisBlock.StateRoot)
+	blt.Sign(p.proposerNode.Keypair(), networkID)
+
+	latestBlock := block.GetLatestBlock(p.nr.Storage())
+	_, err := finishBallot(p.nr.Storage(), *blt, p.nr.TransactionPool, p.nr.Log(), p.nr.Log())
+	require.Equal(t, errors.StateRootDoesNotMatch, err)
+
+	// block and accounts are not stored
+	require.Equal(t, latestBlock.Hash, block.GetLatestBlock(p.nr.Storage()).Hash)
+	for _, tx := range p.txs {
+		exists, err := block.ExistsBlockAccount(p.nr.Storage(), tx.B.Operations[0].B.(operation.Payable).TargetAddress())
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+}
@@ -0,0 +1,224 @@
+/*
+	In this file, there are unittests for the consensus under the network faults
+	and the byzantine validators; the nodes are connected by `FaultyNetwork`.
+*/
+
+package runner
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
+)
+
+// waitForAnyHeight waits until one of the nodes stores the block of `height`;
+// without syncer, the node, which missed the ballots, can not follow the others.
+func waitForAnyHeight(nodeRunners []*NodeRunner, height uint64, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		for _, nr := range nodeRunners {
+			if nr.Consensus().LatestBlock().Height >= height {
+				return true
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return false
+}
+
+// testFaultSeed returns the random seed of `FaultInjector` and logs it; the
+// failed run can be repeated by setting the logged seed to `SEBAK_TEST_SEED`.
+func testFaultSeed(t *testing.T) int64 {
+	t.Helper()
+
+	seed := time.Now().UnixNano()
+	if s := os.Getenv("SEBAK_TEST_SEED"); len(s) > 0 {
+		var err error
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			t.Fatalf("invalid SEBAK_TEST_SEED: %v", err)
+		}
+	}
+	t.Logf("fault injector seed=%d", seed)
+
+	return seed
+}
+
+/*
+TestISAACSimulationFaultyNetwork indicates the following:
+	1. There are 4 nodes in faulty network.
+	2. The messages are delayed, reordered and dropped randomly by the seed.
+	3. The nodes make blocks, and no two nodes store different blocks at the
+	same height.
+*/
+func TestISAACSimulationFaultyNetwork(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		seed := testFaultSeed(t)
+
+		conf := common.NewConfig()
+		conf.BlockTime = 200 * time.Millisecond
+
+		injector := NewFaultInjector(seed)
+		injector.Delay = 5 * time.Millisecond
+		injector.Jitter = 50 * time.Millisecond
+		injector.DropRate = 0.02
+
+		nodeRunners, _ := MakeFaultyNodeRunners(4, conf, injector)
+		stop := startTestNodeRunners(nodeRunners)
+
+		require.True(t, waitForAnyHeight(nodeRunners, 4, 30*time.Second), "seed=%d", seed)
+		stop()
+
+		require.NoError(t, CheckSafety(nodeRunners), "seed=%d", seed)
+	}
+}
+
+/*
+TestISAACSimulationPartition indicates the following:
+	1. There are 4 nodes in faulty network.
+	2. The network is partitioned into 2 and 2 nodes; no group can reach the
+	threshold, so no block is made.
+	3. After the partition is healed, the nodes make blocks without the
+	different blocks at the same height.
+*/
+func TestISAACSimulationPartition(t *testing.T) {
+	conf := common.NewConfig()
+	conf.BlockTime = 200 * time.Millisecond
+
+	injector := NewFaultInjector(testFaultSeed(t))
+	nodeRunners, networks := MakeFaultyNodeRunners(4, conf, injector)
+	injector.Partition(networks[:2], networks[2:])
+
+	stop := startTestNodeRunners(nodeRunners)
+	defer stop()
+
+	require.False(t, waitForAnyHeight(nodeRunners, 2, 2*time.Second))
+
+	injector.Heal()
+	require.True(t, waitForHeight(nodeRunners, 3, 30*time.Second))
+	require.NoError(t, CheckSafety(nodeRunners))
+}
+
+/*
+TestISAACSimulationByzantine indicates the following:
+	1. There are 4 nodes in faulty network, and node0 is byzantine.
+	2. node0 equivocates the proposals or the votes, proposes the invalid
+	proposer transaction or withholds it's votes.
+	3. The honest nodes make blocks, and no two honest nodes store different
+	blocks at the same height.
+*/
+func TestISAACSimulationByzantine(t *testing.T) {
+	cases := map[string]func(nr *NodeRunner) Byzantine{
+		"equivocating-proposer": func(nr *NodeRunner) Byzantine {
+			return NewEquivocatingProposer(nr.Node().Keypair(), networkID)
+		},
+		"equivocating-voter": func(nr *NodeRunner) Byzantine {
+			return NewEquivocatingVoter(nr.Node().Keypair(), networkID)
+		},
+		"invalid-proposer-transaction": func(nr *NodeRunner) Byzantine {
+			return NewInvalidProposerTransaction(nr.Node().Keypair(), networkID)
+		},
+		"vote-withholder": func(nr *NodeRunner) Byzantine {
+			return NewVoteWithholder(nr.Node().Keypair())
+		},
+	}
+
+	for name, byzantine := range cases {
+		conf := common.NewConfig()
+		conf.BlockTime = 200 * time.Millisecond
+		conf.TimeoutINIT = 1 * time.Second
+
+		injector := NewFaultInjector(testFaultSeed(t))
+		injector.Jitter = 20 * time.Millisecond
+
+		nodeRunners, networks := MakeFaultyNodeRunners(4, conf, injector)
+		networks[0].SetByzantine(byzantine(nodeRunners[0]))
+
+		stop := startTestNodeRunners(nodeRunners)
+
+		require.True(t, waitForAnyHeight(nodeRunners[1:], 3, 60*time.Second), name)
+		stop()
+
+		require.NoError(t, CheckSafety(nodeRunners[1:]), name)
+	}
+}
+
+// hasEvidence checks one of the nodes stores the evidence of `source`.
+func hasEvidence(nodeRunners []*NodeRunner, source string) bool {
+	for _, nr := range nodeRunners {
+		iterFunc, closeFunc := consensus.GetEvidences(nr.Storage(), nil)
+		for {
+			e, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			if e.Source == source {
+				closeFunc()
+				return true
+			}
+		}
+		closeFunc()
+	}
+
+	return false
+}
+
+/*
+TestISAACSimulationEquivocationEvidence indicates the following:
+	1. There are 4 nodes in faulty network with gossip, and node0 is byzantine.
+	2. node0 equivocates the proposals or the votes.
+	3. The honest nodes receive the both ballots of node0 by relay, and store
+	the evidence of node0.
+*/
+func TestISAACSimulationEquivocationEvidence(t *testing.T) {
+	cases := map[string]func(nr *NodeRunner) Byzantine{
+		"equivocating-proposer": func(nr *NodeRunner) Byzantine {
+			return NewEquivocatingProposer(nr.Node().Keypair(), networkID)
+		},
+		"equivocating-voter": func(nr *NodeRunner) Byzantine {
+			return NewEquivocatingVoter(nr.Node().Keypair(), networkID)
+		},
+	}
+
+	for name, byzantine := range cases {
+		conf := common.NewConfig()
+		conf.BlockTime = 200 * time.Millisecond
+		conf.TimeoutINIT = 1 * time.Second
+		conf.GossipFanout = 3
+
+		injector := NewFaultInjector(testFaultSeed(t))
+
+		// the honest nodes must be in the both halves to get the different
+		// ballots of node0.
+		var nodeRunners []*NodeRunner
+		var networks []*FaultyNetwork
+		for {
+			nodeRunners, networks = MakeFaultyNodeRunners(4, conf, injector)
+			if isOtherHalf(networks[1].Endpoint()) != isOtherHalf(networks[2].Endpoint()) ||
+				isOtherHalf(networks[1].Endpoint()) != isOtherHalf(networks[3].Endpoint()) {
+				break
+			}
+		}
+		networks[0].SetByzantine(byzantine(nodeRunners[0]))
+
+		stop := startTestNodeRunners(nodeRunners)
+
+		detected := false
+		for deadline := time.Now().Add(30 * time.Second); time.Now().Before(deadline); {
+			if detected = hasEvidence(nodeRunners[1:], nodeRunners[0].Node().Address()); detected {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		stop()
+
+		require.True(t, detected, name)
+		require.NoError(t, CheckSafety(nodeRunners[1:]), name)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+	In this file, there are unittests to compare the gossip mode with the direct
+	broadcast in memory network; all the nodes run the consensus by themselves.
+*/
+
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/network"
+)
+
+func startTestNodeRunners(nodeRunners []*NodeRunner) (stop func()) {
+	for _, nr := range nodeRunners {
+		go nr.Start()
+	}
+
+	return func() {
+		for _, nr := range nodeRunners {
+			nr.Stop()
+		}
+	}
+}
+
+// waitForHeight waits until all the nodes store the block of `height`.
+func waitForHeight(nodeRunners []*NodeRunner, height uint64, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		reached := true
+		for _, nr := range nodeRunners {
+			if nr.Consensus().LatestBlock().Height < height {
+				reached = false
+				break
+			}
+		}
+		if reached {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return false
+}
+
+/*
+TestISAACSimulationGossip indicates the following:
+	1. There are 4 nodes in memory network.
+	2. With the direct broadcast and the gossip mode, the nodes make blocks.
+*/
+func TestISAACSimulationGossip(t *testing.T) {
+	for _, fanout := range []int{0, 2} {
+		conf := common.NewConfig()
+		conf.BlockTime = 200 * time.Millisecond
+		conf.GossipFanout = fanout
+
+		nodeRunners := createTestNodeRunner(4, conf)
+		stop := startTestNodeRunners(nodeRunners)
+
+		require.True(t, waitForHeight(nodeRunners, 4, 30*time.Second), "fanout=%d", fanout)
+		stop()
+	}
+}
+
+/*
+TestISAACSimulationGossipLinkDown indicates the following:
+	1. There are 4 nodes in memory network and gossip mode is on.
+	2. The links from node0 to node1 and node2 are down; node0 can connect only to node3.
+	3. The ballots between node0 and the others are relayed by node3, so all the
+	nodes make blocks.
+	4. The fanout is the number of peers, so node3 always relays to node0.
+*/
+func TestISAACSimulationGossipLinkDown(t *testing.T) {
+	conf := common.NewConfig()
+	conf.BlockTime = 200 * time.Millisecond
+	conf.GossipFanout = 3
+
+	nodeRunners := createTestNodeRunner(4, conf)
+
+	n0 := nodeRunners[0].Network().(*network.MemoryNetwork)
+	n0.SetLinkDown(nodeRunners[1].Network().(*network.MemoryNetwork), true)
+	n0.SetLinkDown(nodeRunners[2].Network().(*network.MemoryNetwork), true)
+
+	stop := startTestNodeRunners(nodeRunners)
+	defer stop()
+
+	require.True(t, waitForHeight(nodeRunners, 4, 30*time.Second))
+}
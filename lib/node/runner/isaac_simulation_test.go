@@ -35,7 +35,7 @@ func TestISAACSimulationProposer(t *testing.T) {
 
 	// Generate proposed ballot in nr
 	roundNumber := uint64(0)
-	_, err = nr.proposeNewBallot(roundNumber)
+	proposed, err := nr.proposeNewBallot(roundNumber)
 	require.NoError(t, err)
 
 	b := nr.Consensus().LatestBlock()
@@ -49,38 +49,38 @@ func TestISAACSimulationProposer(t *testing.T) {
 
 	conf := common.NewConfig()
 
-	ballotSIGN1 := GenerateBallot(proposer, round, tx, ballot.StateSIGN, nodes[1], conf)
+	ballotSIGN1 := GenerateVote(proposed, ballot.StateSIGN, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotSIGN1)
 	require.NoError(t, err)
 
-	ballotSIGN2 := GenerateBallot(proposer, round, tx, ballot.StateSIGN, nodes[2], conf)
+	ballotSIGN2 := GenerateVote(proposed, ballot.StateSIGN, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotSIGN2)
 	require.NoError(t, err)
 
-	ballotSIGN3 := GenerateBallot(proposer, round, tx, ballot.StateSIGN, nodes[3], conf)
+	ballotSIGN3 := GenerateVote(proposed, ballot.StateSIGN, nodes[3], conf)
 	err = ReceiveBallot(nr, ballotSIGN3)
 	require.NoError(t, err)
 
-	ballotSIGN4 := GenerateBallot(proposer, round, tx, ballot.StateSIGN, nodes[4], conf)
+	ballotSIGN4 := GenerateVote(proposed, ballot.StateSIGN, nodes[4], conf)
 	err = ReceiveBallot(nr, ballotSIGN4)
 	require.NoError(t, err)
 
 	rr := nr.Consensus().RunningRounds[round.Index()]
 	require.Equal(t, 4, len(rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)))
 
-	ballotACCEPT0 := GenerateBallot(proposer, round, tx, ballot.StateACCEPT, nodes[0], conf)
+	ballotACCEPT0 := GenerateVote(proposed, ballot.StateACCEPT, nodes[0], conf)
 	err = ReceiveBallot(nr, ballotACCEPT0)
 	require.NoError(t, err)
 
-	ballotACCEPT1 := GenerateBallot(proposer, round, tx, ballot.StateACCEPT, nodes[1], conf)
+	ballotACCEPT1 := GenerateVote(proposed, ballot.StateACCEPT, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotACCEPT1)
 	require.NoError(t, err)
 
-	ballotACCEPT2 := GenerateBallot(proposer, round, tx, ballot.StateACCEPT, nodes[2], conf)
+	ballotACCEPT2 := GenerateVote(proposed, ballot.StateACCEPT, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotACCEPT2)
 	require.NoError(t, err)
 
-	ballotACCEPT3 := GenerateBallot(proposer, round, tx, ballot.StateACCEPT, nodes[3], conf)
+	ballotACCEPT3 := GenerateVote(proposed, ballot.StateACCEPT, nodes[3], conf)
 	err = ReceiveBallot(nr, ballotACCEPT3)
 
 	_, ok := err.(CheckerStopCloseConsensus)
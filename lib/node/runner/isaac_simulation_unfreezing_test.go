@@ -74,7 +74,9 @@ func TestUnfreezingSimulation(t *testing.T) {
 	tx6, _ := GetUnfreezingTransaction(kpFrozenAccount, kpNewAccount, uint64(1), uint64(99999980000))
 
 	nr.TransactionPool.Add(tx6)
-	roundNumber := uint64(0)
+	// the round 0 is proposed by the next `MakeConsensusAndBlock()`; the
+	// proposer can not propose again for the same round.
+	roundNumber := uint64(1)
 	_, err := nr.proposeNewBallot(roundNumber)
 	require.NoError(t, err)
 
@@ -102,10 +104,15 @@ func TestUnfreezingSimulation(t *testing.T) {
 	// Generate unfreezing transaction
 	tx10, _ := GetUnfreezingTransaction(kpFrozenAccount, kpNewAccount, uint64(1), uint64(99999980000))
 
-	b10, _ := MakeConsensusAndBlock(t, tx10, nr, nodes, proposer)
+	nr.TransactionPool.Add(tx10)
+	_, err = nr.proposeNewBallot(roundNumber)
+	require.NoError(t, err)
+
+	require.False(t, nr.TransactionPool.Has(tx10.GetHash()))
+
 	ba, _ = block.GetBlockAccount(st, kpFrozenAccount.Address())
 
-	require.Equal(t, b10.Height, uint64(9))
+	require.Equal(t, nr.Consensus().LatestBlock().Height, uint64(9))
 	require.Equal(t, uint64(ba.Balance), uint64(99999990000))
 }
 
@@ -115,7 +122,7 @@ func MakeConsensusAndBlock(t *testing.T, tx transaction.Transaction, nr *NodeRun
 
 	// Generate proposed ballot in nodeRunner
 	round := uint64(0)
-	_, err := nr.proposeNewBallot(round)
+	proposed, err := nr.proposeNewBallot(round)
 	require.NoError(t, err)
 
 	b := nr.Consensus().LatestBlock()
@@ -130,22 +137,22 @@ func MakeConsensusAndBlock(t *testing.T, tx transaction.Transaction, nr *NodeRun
 
 	// Check that the transaction is in RunningRounds
 
-	ballotSIGN1 := GenerateBallot(proposer, basis, tx, ballot.StateSIGN, nodes[1], conf)
+	ballotSIGN1 := GenerateVote(proposed, ballot.StateSIGN, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotSIGN1)
 	require.NoError(t, err)
 
-	ballotSIGN2 := GenerateBallot(proposer, basis, tx, ballot.StateSIGN, nodes[2], conf)
+	ballotSIGN2 := GenerateVote(proposed, ballot.StateSIGN, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotSIGN2)
 	require.NoError(t, err)
 
 	rr := nr.Consensus().RunningRounds[basis.Index()]
 	require.Equal(t, 2, len(rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)))
 
-	ballotACCEPT1 := GenerateBallot(proposer, basis, tx, ballot.StateACCEPT, nodes[1], conf)
+	ballotACCEPT1 := GenerateVote(proposed, ballot.StateACCEPT, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotACCEPT1)
 	require.NoError(t, err)
 
-	ballotACCEPT2 := GenerateBallot(proposer, basis, tx, ballot.StateACCEPT, nodes[2], conf)
+	ballotACCEPT2 := GenerateVote(proposed, ballot.StateACCEPT, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotACCEPT2)
 
 	require.Equal(t, 2, len(rr.Voted[proposer.Address()].GetResult(ballot.StateACCEPT)))
@@ -206,13 +206,22 @@ func (sm *ISAACStateManager) broadcastExpiredBallot(state consensus.ISAACState)
 		TotalOps:  b.TotalOps,
 	}
 
-	proposerAddr := sm.nr.consensus.SelectProposer(b.Height, state.Round)
+	proposerAddr, err := sm.nr.consensus.SelectProposer(basis)
+	if err != nil {
+		sm.nr.Log().Error("failed to select proposer", "height", b.Height, "round", state.Round, "error", err)
+		return
+	}
 
 	newExpiredBallot := ballot.NewBallot(sm.nr.localNode.Address(), proposerAddr, basis, []string{})
 	newExpiredBallot.SetVote(state.BallotState.Next(), voting.EXP)
 
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*newExpiredBallot, sm.nr.CommonAccountAddress)
-	opi, _ := ballot.NewInflationFromBallot(*newExpiredBallot, sm.nr.CommonAccountAddress, sm.nr.InitialBalance)
+	opi, _ := ballot.NewInflationFromBallot(
+		*newExpiredBallot,
+		sm.nr.CommonAccountAddress,
+		sm.nr.InitialBalance,
+		sm.nr.Conf.NetworkParams.InflationRatio,
+	)
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*newExpiredBallot, opc, opi)
 
 	newExpiredBallot.SetProposerTransaction(ptx)
@@ -220,7 +229,9 @@ func (sm *ISAACStateManager) broadcastExpiredBallot(state consensus.ISAACState)
 	newExpiredBallot.Sign(sm.nr.localNode.Keypair(), sm.nr.networkID)
 
 	sm.nr.Log().Debug("broadcast", "ballot", *newExpiredBallot)
-	sm.nr.ConnectionManager().Broadcast(*newExpiredBallot)
+	if err := sm.nr.castVote(*newExpiredBallot); err != nil {
+		sm.nr.Log().Debug("expired ballot is not broadcasted", "error", err)
+	}
 }
 
 func (sm *ISAACStateManager) resetTimer(timer *time.Timer, state ballot.State) {
@@ -239,7 +250,11 @@ func (sm *ISAACStateManager) resetTimer(timer *time.Timer, state ballot.State) {
 // but if not, it waits for receiving ballot from the other proposer.
 func (sm *ISAACStateManager) proposeOrWait(timer *time.Timer, state consensus.ISAACState) {
 	timer.Reset(time.Duration(1 * time.Hour))
-	proposer := sm.nr.Consensus().SelectProposer(state.Height, state.Round)
+	b := sm.nr.consensus.LatestBlock()
+	proposer, err := sm.nr.Consensus().SelectProposer(voting.Basis{Height: state.Height, BlockHash: b.Hash, Round: state.Round})
+	if err != nil {
+		log.Error("failed to select proposer", "height", state.Height, "round", state.Round, "error", err)
+	}
 	log.Debug("selected proposer", "proposer", proposer)
 
 	if proposer == sm.nr.localNode.Address() {
@@ -80,7 +80,7 @@ func TestStateINITTimeoutNotProposer(t *testing.T) {
 	cm, ok := nr.Consensus().ConnectionManager().(*TestConnectionManager)
 	require.True(t, ok)
 
-	proposer := nr.Consensus().SelectProposer(0, 0)
+	proposer, _ := nr.Consensus().SelectProposer(voting.Basis{Height: 0, Round: 0})
 
 	require.NotEqual(t, nr.localNode.Address(), proposer)
 
@@ -127,7 +127,7 @@ func TestStateSIGNTimeoutProposer(t *testing.T) {
 	recv := make(chan struct{})
 	nr, _, cm := createNodeRunnerForTesting(3, conf, recv)
 
-	proposer := nr.Consensus().SelectProposer(0, 0)
+	proposer, _ := nr.Consensus().SelectProposer(voting.Basis{Height: 0, Round: 0})
 
 	require.Equal(t, nr.localNode.Address(), proposer)
 
@@ -189,7 +189,7 @@ func TestStateSIGNTimeoutNotProposer(t *testing.T) {
 	cm, ok := nr.Consensus().ConnectionManager().(*TestConnectionManager)
 	require.True(t, ok)
 
-	proposer := nr.Consensus().SelectProposer(0, 0)
+	proposer, _ := nr.Consensus().SelectProposer(voting.Basis{Height: 0, Round: 0})
 
 	require.NotEqual(t, nr.localNode.Address(), proposer)
 
@@ -243,10 +243,10 @@ func TestStateACCEPTTimeoutProposerThenNotProposer(t *testing.T) {
 	cm, ok := nr.Consensus().ConnectionManager().(*TestConnectionManager)
 	require.True(t, ok)
 
-	proposer := nr.Consensus().SelectProposer(0, 0)
+	proposer, _ := nr.Consensus().SelectProposer(voting.Basis{Height: 0, Round: 0})
 	require.Equal(t, nr.localNode.Address(), proposer)
 
-	proposer = nr.Consensus().SelectProposer(0, 1)
+	proposer, _ = nr.Consensus().SelectProposer(voting.Basis{Height: 0, Round: 1})
 	require.NotEqual(t, nr.localNode.Address(), proposer)
 
 	nr.StartStateManager()
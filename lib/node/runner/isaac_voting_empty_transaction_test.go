@@ -40,7 +40,7 @@ func TestISAACBallotWithEmptyTransactionVoting(t *testing.T) {
 	require.Equal(t, uint64(1), latestBlock.TotalTxs)
 
 	// Generate proposed ballot in nr
-	_, err := nr.proposeNewBallot(0)
+	proposed, err := nr.proposeNewBallot(0)
 	require.NoError(t, err)
 
 	round := voting.Basis{
@@ -53,15 +53,15 @@ func TestISAACBallotWithEmptyTransactionVoting(t *testing.T) {
 	b := ballot.NewBallot(nr.localNode.Address(), nr.localNode.Address(), round, []string{})
 	b.SetVote(ballot.StateINIT, voting.YES)
 
-	ballotSIGN1 := GenerateEmptyTxBallot(proposer, round, ballot.StateSIGN, nodes[1], conf)
+	ballotSIGN1 := GenerateVote(proposed, ballot.StateSIGN, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotSIGN1)
 	require.NoError(t, err)
 
-	ballotSIGN2 := GenerateEmptyTxBallot(proposer, round, ballot.StateSIGN, nodes[2], conf)
+	ballotSIGN2 := GenerateVote(proposed, ballot.StateSIGN, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotSIGN2)
 	require.NoError(t, err)
 
-	ballotSIGN3 := GenerateEmptyTxBallot(proposer, round, ballot.StateSIGN, nodes[3], conf)
+	ballotSIGN3 := GenerateVote(proposed, ballot.StateSIGN, nodes[3], conf)
 	err = ReceiveBallot(nr, ballotSIGN3)
 	require.NoError(t, err)
 
@@ -74,19 +74,19 @@ func TestISAACBallotWithEmptyTransactionVoting(t *testing.T) {
 	result := rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)
 	require.Equal(t, 3, len(result))
 
-	ballotACCEPT1 := GenerateEmptyTxBallot(proposer, round, ballot.StateACCEPT, nodes[1], conf)
+	ballotACCEPT1 := GenerateVote(proposed, ballot.StateACCEPT, nodes[1], conf)
 	err = ReceiveBallot(nr, ballotACCEPT1)
 	require.NoError(t, err)
 
-	ballotACCEPT2 := GenerateEmptyTxBallot(proposer, round, ballot.StateACCEPT, nodes[2], conf)
+	ballotACCEPT2 := GenerateVote(proposed, ballot.StateACCEPT, nodes[2], conf)
 	err = ReceiveBallot(nr, ballotACCEPT2)
 	require.NoError(t, err)
 
-	ballotACCEPT3 := GenerateEmptyTxBallot(proposer, round, ballot.StateACCEPT, nodes[3], conf)
+	ballotACCEPT3 := GenerateVote(proposed, ballot.StateACCEPT, nodes[3], conf)
 	err = ReceiveBallot(nr, ballotACCEPT3)
 	require.NoError(t, err)
 
-	ballotACCEPT4 := GenerateEmptyTxBallot(proposer, round, ballot.StateACCEPT, nodes[4], conf)
+	ballotACCEPT4 := GenerateVote(proposed, ballot.StateACCEPT, nodes[4], conf)
 	err = ReceiveBallot(nr, ballotACCEPT4)
 	require.EqualError(t, err, "ballot got consensus and will be stored")
 
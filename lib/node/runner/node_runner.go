@@ -8,8 +8,11 @@
 package runner
 
 import (
+	"context"
 	"net/http"
 	"net/http/pprof"
+	"sort"
+	"sync"
 	"time"
 
 	"boscoin.io/sebak/lib/ballot"
@@ -31,6 +34,7 @@ import (
 var DefaultHandleBaseBallotCheckerFuncs = []common.CheckerFunc{
 	BallotUnmarshal,
 	BallotNotFromKnownValidators,
+	BallotRelay,
 	BallotCheckSYNC,
 	BallotAlreadyFinished,
 }
@@ -74,6 +78,7 @@ type NodeRunner struct {
 	connectionManager network.ConnectionManager
 	storage           *storage.LevelDBBackend
 	isaacStateManager *ISAACStateManager
+	voteJournal       *consensus.VoteJournal
 
 	handleBaseBallotCheckerFuncs   []common.CheckerFunc
 	handleINITBallotCheckerFuncs   []common.CheckerFunc
@@ -89,6 +94,17 @@ type NodeRunner struct {
 
 	Conf     common.Config
 	nodeInfo node.NodeInfo
+
+	validatorSetLock   sync.Mutex
+	validatorSetHeight uint64 // the validator set is applied until this height
+
+	snapshotting int32 // 1 while the snapshot is made; see `makeSnapshot()`
+
+	syncInfoFunc    func(context.Context) (node.NodeSyncInfo, error)
+	consensusStored int32 // 1 after the block is stored by consensus
+
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 func NewNodeRunner(
@@ -106,20 +122,36 @@ func NewNodeRunner(
 		policy:          policy,
 		network:         n,
 		consensus:       c,
-		TransactionPool: transaction.NewPool(),
+		TransactionPool: transaction.NewPool(conf),
 		storage:         storage,
+		voteJournal:     consensus.NewVoteJournal(storage),
 		log:             log.New(logging.Ctx{"node": localNode.Alias()}),
 		Conf:            conf,
+		stop:            make(chan struct{}),
+	}
+	if !nr.localNode.IsWatcher() {
+		nr.localNode.SetBooting()
 	}
-	nr.localNode.SetBooting()
 
 	nr.isaacStateManager = NewISAACStateManager(nr, conf)
 
 	nr.policy.SetValidators(len(nr.localNode.GetValidators()))
 
+	// the validators accept only the signed requests from the validators, so
+	// the watcher sends the requests without signature like the clients.
+	if !nr.localNode.IsWatcher() {
+		nr.network.SetRequestSigner(network.NewRequestSigner(nr.localNode.Keypair(), nr.networkID))
+	}
+
 	nr.connectionManager = c.ConnectionManager()
 	nr.network.AddWatcher(nr.connectionManager.ConnectionWatcher)
 
+	// apply the validator set changes, which were activated before the node
+	// is started
+	if err = nr.updateValidators(); err != nil {
+		return
+	}
+
 	nr.SetHandleBaseBallotCheckerFuncs(DefaultHandleBaseBallotCheckerFuncs...)
 	nr.SetHandleINITBallotCheckerFuncs(DefaultHandleINITBallotCheckerFuncs...)
 	nr.SetHandleSIGNBallotCheckerFuncs(DefaultHandleSIGNBallotCheckerFuncs...)
@@ -142,11 +174,62 @@ func NewNodeRunner(
 		nr.InitialBalance.Invariant()
 	}
 
+	if err = nr.restoreTransactionPool(block.NewTransactionPoolJournal(nr.storage)); err != nil {
+		return
+	}
+
 	nr.nodeInfo = NewNodeInfo(nr)
 
 	return
 }
 
+// restoreTransactionPool reloads the pending transactions of the last run from
+// the journal and validates them again; the transactions, which became invalid
+// while the node was stopped, are dropped.
+func (nr *NodeRunner) restoreTransactionPool(journal *block.TransactionPoolJournal) (err error) {
+	var txs []transaction.Transaction
+	if txs, err = journal.Transactions(); err != nil {
+		return
+	}
+
+	// the transactions of same source are queued by the order of sequence id
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].B.SequenceID < txs[j].B.SequenceID
+	})
+
+	var dropped []transaction.Transaction
+	for _, tx := range txs {
+		if verr := ValidatePoolTx(nr.storage, nr.Conf, nr.TransactionPool, tx); verr != nil {
+			nr.log.Debug("invalid transaction dropped from journal", "transaction", tx.GetHash(), "error", verr)
+			dropped = append(dropped, tx)
+			continue
+		}
+
+		var evicted []transaction.Transaction
+		if evicted, err = nr.TransactionPool.Add(tx); err == errors.TransactionPoolFull {
+			dropped = append(dropped, tx)
+			continue
+		} else if err != nil {
+			return
+		}
+		dropped = append(dropped, evicted...)
+	}
+
+	for _, tx := range dropped {
+		if err = journal.Remove(tx.GetHash()); err != nil {
+			return
+		}
+		if err = block.SaveTransactionHistory(nr.storage, tx, block.TransactionHistoryStatusRejected); err != nil {
+			return
+		}
+	}
+
+	nr.TransactionPool.SetJournal(journal)
+	nr.log.Debug("transaction pool restored", "transactions", nr.TransactionPool.Len(), "dropped", len(dropped))
+
+	return
+}
+
 func (nr *NodeRunner) Ready() {
 	rateLimitMiddlewareAPI := network.RateLimitMiddleware(nr.log, nr.Conf.RateLimitRuleAPI)
 	if err := nr.network.AddMiddleware(network.RouterNameAPI, rateLimitMiddlewareAPI); err != nil {
@@ -158,6 +241,21 @@ func (nr *NodeRunner) Ready() {
 		nr.log.Error("`network.RateLimitMiddleware` for `RouterNameNode` has an error", "err", err)
 		return
 	}
+	// the requests between validators must be signed by the validators; the
+	// node info and the blocks and snapshots for sync can be read by the
+	// watchers and the new nodes, which are not validators.
+	verifyMiddlewareNode := network.NodeRequestVerifyMiddleware(
+		nr.log,
+		network.NewRequestVerifier(nr.localNode, nr.networkID, network.DefaultRequestTimeWindow),
+		network.UrlPathPrefixNode+ConnectHandlerPattern,
+		network.UrlPathPrefixNode+MessageHandlerPattern,
+		network.UrlPathPrefixNode+BallotHandlerPattern,
+		network.UrlPathPrefixNode+GetTransactionPattern,
+	)
+	if err := nr.network.AddMiddleware(network.RouterNameNode, verifyMiddlewareNode); err != nil {
+		nr.log.Error("`network.NodeRequestVerifyMiddleware` for `RouterNameNode` has an error", "err", err)
+		return
+	}
 	if err := nr.network.AddMiddleware(network.RouterNameMetric, rateLimitMiddlewareAPI); err != nil {
 		nr.log.Error("`network.RateLimitMiddleware` for `RouterNameMetric` router has an error", "err", err)
 		return
@@ -204,15 +302,27 @@ func (nr *NodeRunner) Ready() {
 	nr.network.AddHandler(nodeHandler.HandlerURLPattern(MessageHandlerPattern), nodeHandler.MessageHandler).
 		Methods("POST").
 		Headers("Content-Type", "application/json")
-	nr.network.AddHandler(nodeHandler.HandlerURLPattern(BallotHandlerPattern), nodeHandler.BallotHandler).
-		Methods("POST").
-		Headers("Content-Type", "application/json")
+	// the watcher never votes
+	if !nr.localNode.IsWatcher() {
+		nr.network.AddHandler(nodeHandler.HandlerURLPattern(BallotHandlerPattern), nodeHandler.BallotHandler).
+			Methods("POST").
+			Headers("Content-Type", "application/json")
+	}
 	nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetBlocksPattern), nodeHandler.GetBlocksHandler).
 		Methods("GET", "POST").
 		MatcherFunc(common.PostAndJSONMatcher)
 	nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetTransactionPattern), nodeHandler.GetNodeTransactionsHandler).
 		Methods("GET", "POST").
 		MatcherFunc(common.PostAndJSONMatcher)
+	nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetSnapshotPattern), nodeHandler.GetSnapshotHandler).
+		Methods("GET")
+	nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetSnapshotChunkPattern), nodeHandler.GetSnapshotChunkHandler).
+		Methods("GET")
+	if nr.syncInfoFunc != nil {
+		nodeHandler.GetSyncInfo = nr.syncInfo
+		nr.network.AddHandler(nodeHandler.HandlerURLPattern(GetSyncPattern), nodeHandler.GetSyncHandler).
+			Methods("GET")
+	}
 
 	nr.network.AddHandler(network.UrlPathPrefixMetric, promhttp.Handler().ServeHTTP)
 
@@ -225,6 +335,9 @@ func (nr *NodeRunner) Ready() {
 		nr.nodeInfo,
 	)
 	apiHandler.GetLatestBlock = nr.Consensus().LatestBlock
+	if nr.syncInfoFunc != nil {
+		apiHandler.GetSyncInfo = nr.syncInfo
+	}
 
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountHandlerPattern),
@@ -238,6 +351,10 @@ func (nr *NodeRunner) Ready() {
 		apiHandler.HandlerURLPattern(api.GetAccountOperationsHandlerPattern),
 		apiHandler.GetOperationsByAccountHandler,
 	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAccountProofHandlerPattern),
+		apiHandler.GetAccountProofHandler,
+	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionByHashHandlerPattern),
 		apiHandler.GetTransactionByHashHandler,
@@ -250,6 +367,30 @@ func (nr *NodeRunner) Ready() {
 		apiHandler.HandlerURLPattern(api.GetTransactionHistoryHandlerPattern),
 		apiHandler.GetTransactionHistoryHandler,
 	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetTransactionProofHandlerPattern),
+		apiHandler.GetTransactionProofHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetBlocksHandlerPattern),
+		apiHandler.GetBlocksHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetBlockHandlerPattern),
+		apiHandler.GetBlockHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetBlockTransactionsHandlerPattern),
+		apiHandler.GetTransactionsByBlockHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetEvidencesHandlerPattern),
+		apiHandler.GetEvidencesHandler,
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetEvidenceHandlerPattern),
+		apiHandler.GetEvidenceHandler,
+	).Methods("GET", "OPTIONS")
 
 	TransactionsHandler := func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" {
@@ -286,7 +427,14 @@ func (nr *NodeRunner) Start() (err error) {
 
 	go nr.handleMessages()
 	go nr.ConnectValidators()
-	go nr.InitRound()
+
+	// the watcher follows the validators by `sync.Watcher` instead of
+	// participating in consensus.
+	if !nr.localNode.IsWatcher() {
+		go nr.InitRound()
+	} else {
+		go nr.watchValidatorSet(nr.Conf.BlockTime)
+	}
 
 	if err = nr.network.Start(); err != nil {
 		return
@@ -296,6 +444,7 @@ func (nr *NodeRunner) Start() (err error) {
 }
 
 func (nr *NodeRunner) Stop() {
+	nr.stopOnce.Do(func() { close(nr.stop) })
 	nr.network.Stop()
 	nr.isaacStateManager.Stop()
 }
@@ -515,6 +664,23 @@ var NewBallotTransactionCheckerFuncs = []common.CheckerFunc{
 	BallotTransactionsSourceCheck,
 }
 
+// evictExpiredTransactions removes the transactions, which can not be
+// included in the block of `height` any more, from `TransactionPool` and
+// records them as rejected.
+func (nr *NodeRunner) evictExpiredTransactions(height uint64) {
+	expired, err := nr.TransactionPool.RemoveExpired(height)
+	if err != nil {
+		nr.log.Error("failed to remove expired transactions", "height", height, "error", err)
+	}
+
+	for _, tx := range expired {
+		nr.log.Debug("expired transaction evicted", "transaction", tx.GetHash(), "height", height)
+		if err := block.SaveTransactionHistory(nr.storage, tx, block.TransactionHistoryStatusRejected); err != nil {
+			nr.log.Error("failed to save transaction history", "transaction", tx.GetHash(), "error", err)
+		}
+	}
+}
+
 func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 	b := nr.consensus.LatestBlock()
 	basis := voting.Basis{
@@ -526,7 +692,8 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 	}
 
 	// collect incoming transactions from `Pool`
-	availableTransactions := nr.TransactionPool.AvailableTransactions(nr.Conf.TxsLimit)
+	nr.evictExpiredTransactions(b.Height + 1)
+	availableTransactions := nr.TransactionPool.AvailableTransactions(nr.Conf.TxsLimit, b.Height+1)
 	nr.log.Debug("new round proposed", "block-basis", basis, "transactions", availableTransactions)
 
 	transactionsChecker := &BallotTransactionChecker{
@@ -546,9 +713,14 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 	}
 
 	// remove invalid transactions
-	nr.TransactionPool.Remove(transactionsChecker.InvalidTransactions()...)
+	if err := nr.TransactionPool.Remove(transactionsChecker.InvalidTransactions()...); err != nil {
+		nr.log.Error("failed to remove invalid transactions", "error", err)
+	}
 
-	proposerAddr := nr.consensus.SelectProposer(b.Height, round)
+	proposerAddr, err := nr.consensus.SelectProposer(basis)
+	if err != nil {
+		return ballot.Ballot{}, err
+	}
 	theBallot := ballot.NewBallot(nr.localNode.Address(), proposerAddr, basis, transactionsChecker.ValidTransactions)
 	theBallot.SetVote(ballot.StateINIT, voting.YES)
 
@@ -566,7 +738,12 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 		return ballot.Ballot{}, err
 	}
 
-	opi, err := ballot.NewInflationFromBallot(*theBallot, nr.CommonAccountAddress, nr.InitialBalance)
+	opi, err := ballot.NewInflationFromBallot(
+		*theBallot,
+		nr.CommonAccountAddress,
+		nr.InitialBalance,
+		nr.Conf.NetworkParams.InflationRatio,
+	)
 	if err != nil {
 		return ballot.Ballot{}, err
 	}
@@ -577,11 +754,24 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 	}
 
 	theBallot.SetProposerTransaction(ptx)
+
+	var txs []*transaction.Transaction
+	for i := range validTransactions {
+		txs = append(txs, &validTransactions[i])
+	}
+	_, stateRoot, err := ApplyBlockState(nr.storage, b, txs, ptx, nr.log)
+	if err != nil {
+		return ballot.Ballot{}, err
+	}
+	theBallot.SetStateRoot(stateRoot)
+
 	theBallot.Sign(nr.localNode.Keypair(), nr.networkID)
 
 	nr.log.Debug("new ballot created", "ballot", theBallot)
 
-	nr.ConnectionManager().Broadcast(*theBallot)
+	if err = nr.castVote(*theBallot); err != nil {
+		return ballot.Ballot{}, err
+	}
 
 	return *theBallot, nil
 }
@@ -14,8 +14,10 @@ import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -58,6 +60,7 @@ func createTestNodeRunner(n int, conf common.Config) []*NodeRunner {
 			localNode,
 			ns[i],
 			policy,
+			conf,
 		)
 
 		st := block.InitTestBlockchain()
@@ -143,13 +146,14 @@ func createTestNodeRunnersHTTP2Network(n int) (nodeRunners []*NodeRunner, rootKP
 		networkConfig, _ := network.NewHTTP2NetworkConfigFromEndpoint(node.Alias(), node.Endpoint())
 		n := network.NewHTTP2Network(networkConfig)
 
+		conf := common.NewConfig()
 		connectionManager := network.NewValidatorConnectionManager(
 			node,
 			n,
 			policy,
+			conf,
 		)
 
-		conf := common.NewConfig()
 		st := block.InitTestBlockchain()
 		is, _ := consensus.NewISAAC(networkID, node, policy, connectionManager, st, conf, nil)
 		nodeRunner, _ := NewNodeRunner(string(networkID), node, policy, n, is, st, conf)
@@ -257,7 +261,7 @@ func TestNodeRunnerSaveBlock(t *testing.T) {
 // We can make sure to check the proposer of the expired ballot.
 // If the proposer of a ballot is different from the node, the node votes with VotingNo
 func TestExpiredBallotCheckProposer(t *testing.T) {
-	nr, nodes, _ := createNodeRunnerForTesting(2, common.NewConfig(), nil)
+	nr, nodes, _ := createNodeRunnerForTesting(3, common.NewConfig(), nil)
 
 	_, ok := nr.Consensus().ConnectionManager().(*TestConnectionManager)
 	require.True(t, ok)
@@ -272,7 +276,7 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 	}
 
 	// The createNodeRunnerForTesting has FixedSelector{localNode.Address()} so the proposer is always nr(nodes[0]).
-	validBallot := GenerateEmptyTxBallot(nr.localNode, basis, ballot.StateSIGN, nodes[1], common.NewConfig())
+	validBallot := GenerateEmptyTxBallot(nr.storage, nr.localNode, basis, ballot.StateSIGN, nodes[1], common.NewConfig())
 	validBallot.SetVote(ballot.StateSIGN, voting.EXP)
 
 	checker := &BallotChecker{
@@ -292,8 +296,9 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 	require.NoError(t, err)
 
 	// The createNodeRunnerForTesting has FixedSelector{localNode.Address()} so the proposer is always nr(nodes[0]).
-	// The invalidBallot has nodes[1] as a proposer so it is invalid.
-	invalidBallot := GenerateEmptyTxBallot(nodes[1], basis, ballot.StateSIGN, nodes[1], common.NewConfig())
+	// The invalidBallot has nodes[1] as a proposer so it is invalid. It is sent
+	// by nodes[2]; the other ballot of nodes[1] for the same basis is equivocation.
+	invalidBallot := GenerateEmptyTxBallot(nr.storage, nodes[1], basis, ballot.StateSIGN, nodes[2], common.NewConfig())
 	invalidBallot.SetVote(ballot.StateSIGN, voting.EXP)
 
 	checker = &BallotChecker{
@@ -313,3 +318,111 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 
 	require.Equal(t, voting.NO, checker.VotingHole)
 }
+
+// The expired transactions are evicted from `TransactionPool` and the
+// transactions which are not valid yet remain in `TransactionPool` when the
+// new ballot is proposed.
+func TestProposeNewBallotHeightBounds(t *testing.T) {
+	nr, _, _ := createNodeRunnerForTesting(2, common.NewConfig(), nil)
+
+	// the latest block is genesis, so the proposed block is 2
+	txExpired, _ := GetTransaction()
+	txExpired.B.MaxHeight = 1
+	txExpired.Sign(block.GenesisKP, networkID)
+
+	txFuture, _, _ := GetCreateAccountTransaction(0, uint64(common.BaseReserve))
+	txFuture.B.SequenceID = txExpired.B.SequenceID + 1
+	txFuture.B.MinHeight = 3
+	txFuture.Sign(block.GenesisKP, networkID)
+
+	nr.TransactionPool.Add(txExpired)
+	nr.TransactionPool.Add(txFuture)
+
+	b, err := nr.proposeNewBallot(0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(b.Transactions()))
+
+	require.False(t, nr.TransactionPool.Has(txExpired.GetHash()))
+	require.True(t, nr.TransactionPool.Has(txFuture.GetHash()))
+
+	bth, err := block.GetBlockTransactionHistory(nr.Storage(), txExpired.GetHash())
+	require.NoError(t, err)
+	require.Equal(t, block.TransactionHistoryStatusRejected, bth.Status)
+}
+
+// The pending transactions are restored after the node restarts, except the
+// transactions which became invalid.
+func TestNodeRunnerRestoreTransactionPool(t *testing.T) {
+	nr, _, _ := createNodeRunnerForTesting(1, common.NewConfig(), nil)
+
+	txValid, _, _ := GetCreateAccountTransaction(0, uint64(common.BaseReserve))
+	txQueued, _, _ := GetCreateAccountTransaction(1, uint64(common.BaseReserve))
+
+	_, txInvalid := transaction.TestMakeTransaction(networkID, 1) // source does not exist
+
+	for _, tx := range []transaction.Transaction{txValid, txQueued, txInvalid} {
+		_, err := nr.TransactionPool.Add(tx)
+		require.NoError(t, err)
+	}
+
+	txRemovedLater, _, _ := GetCreateAccountTransaction(2, uint64(common.BaseReserve))
+	_, err := nr.TransactionPool.Add(txRemovedLater)
+	require.NoError(t, err)
+	require.NoError(t, nr.TransactionPool.Remove(txRemovedLater.GetHash()))
+
+	restarted, err := NewNodeRunner(string(networkID), nr.localNode, nr.policy, nr.network, nr.consensus, nr.storage, nr.Conf)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, restarted.TransactionPool.Len())
+	require.True(t, restarted.TransactionPool.Has(txValid.GetHash()))
+	require.True(t, restarted.TransactionPool.Has(txQueued.GetHash()))
+	require.Equal(t, []string{txValid.GetHash()}, restarted.TransactionPool.AvailableTransactions(10, 2))
+
+	txs, err := block.NewTransactionPoolJournal(nr.storage).Transactions()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(txs))
+
+	bth, err := block.GetBlockTransactionHistory(nr.storage, txInvalid.GetHash())
+	require.NoError(t, err)
+	require.Equal(t, block.TransactionHistoryStatusRejected, bth.Status)
+}
+
+// After the node restarts, it does not vote differently for the round and
+// state, which it already voted; the journaled ballot is broadcasted again.
+func TestNodeRunnerRefuseConflictingVote(t *testing.T) {
+	conf := common.NewConfig()
+	nr, _, cm := createNodeRunnerForTesting(5, conf, nil)
+
+	proposed, err := nr.proposeNewBallot(0)
+	require.NoError(t, err)
+
+	signed := GenerateVote(proposed, ballot.StateSIGN, nr.localNode, conf)
+	require.NoError(t, nr.castVote(*signed))
+
+	restarted, err := NewNodeRunner(string(networkID), nr.localNode, nr.policy, nr.network, nr.consensus, nr.storage, nr.Conf)
+	require.NoError(t, err)
+
+	// same vote
+	require.NoError(t, restarted.castVote(*signed))
+
+	// different vote for the same proposal
+	opposite := *signed
+	opposite.SetVote(ballot.StateSIGN, voting.NO)
+	opposite.Sign(nr.localNode.Keypair(), networkID)
+	require.Equal(t, errors.ConflictingVote, restarted.castVote(opposite))
+
+	messages := cm.Messages()
+	replayed := messages[len(messages)-1].(ballot.Ballot)
+	require.Equal(t, signed.GetHash(), replayed.GetHash())
+
+	// the proposer can not propose again for the same round
+	_, err = restarted.proposeNewBallot(0)
+	require.Equal(t, errors.ConflictingVote, err)
+
+	// the next state and round can be voted
+	accepted := GenerateVote(proposed, ballot.StateACCEPT, nr.localNode, conf)
+	require.NoError(t, restarted.castVote(*accepted))
+
+	_, err = restarted.proposeNewBallot(1)
+	require.NoError(t, err)
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+)
+
+func createWatcherNodeRunnerForTesting(validators int) (*NodeRunner, *TestConnectionManager) {
+	var net *network.MemoryNetwork
+	var nodes []*node.LocalNode
+	for i := 0; i < validators+1; i++ {
+		_, s, v := network.CreateMemoryNetwork(net)
+		net = s
+		nodes = append(nodes, v)
+	}
+
+	localNode := nodes[0]
+	for _, v := range nodes[1:] {
+		localNode.AddValidators(v.ConvertToValidator())
+	}
+	localNode.SetWatcher()
+
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+	connectionManager := NewTestConnectionManager(localNode, net, policy, nil)
+
+	st := block.InitTestBlockchain()
+	conf := common.NewConfig()
+	is, _ := consensus.NewISAAC(networkID, localNode, policy, connectionManager, st, conf, nil)
+
+	nr, err := NewNodeRunner(string(networkID), localNode, policy, net, is, st, conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return nr, connectionManager
+}
+
+func TestNodeRunnerWatcher(t *testing.T) {
+	nr, _ := createWatcherNodeRunnerForTesting(3)
+
+	require.Equal(t, node.StateWATCH, nr.Node().State())
+	require.False(t, nr.Node().HasValidators(nr.Node().Address()))
+	require.Equal(t, 3, nr.Policy().Validators())
+	require.Empty(t, nr.ConnectionManager().AllConnected())
+}
+
+// TestNodeRunnerWatcherForwardTransaction checks the watcher forwards the
+// transactions to the validators without keeping them in `Pool`.
+func TestNodeRunnerWatcherForwardTransaction(t *testing.T) {
+	nr, cm := createWatcherNodeRunnerForTesting(3)
+
+	nodeHandler := NewNetworkHandlerNode(
+		nr.Node(),
+		nr.Network(),
+		nr.Storage(),
+		nr.Consensus(),
+		nr.TransactionPool,
+		network.UrlPathPrefixNode,
+		nr.Conf,
+	)
+
+	tx, body := GetTransaction()
+
+	r := httptest.NewRequest("POST", network.UrlPathPrefixNode+MessageHandlerPattern, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	nodeHandler.MessageHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.False(t, nr.TransactionPool.Has(tx.GetHash()))
+
+	messages := cm.Messages()
+	require.Equal(t, 1, len(messages))
+	require.Equal(t, tx.GetHash(), messages[0].GetHash())
+
+	{ // same transaction is not forwarded again
+		r := httptest.NewRequest("POST", network.UrlPathPrefixNode+MessageHandlerPattern, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		nodeHandler.MessageHandler(w, r)
+		require.NotEqual(t, http.StatusOK, w.Code)
+		require.Equal(t, 1, len(cm.Messages()))
+	}
+}
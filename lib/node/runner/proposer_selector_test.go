@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,9 +14,11 @@ func TestProposerSelector(t *testing.T) {
 
 	nodeRunner := nodeRunners[0]
 
-	require.Equal(t, nodeRunner.localNode.Address(), nodeRunner.Consensus().SelectProposer(1, 0))
-	require.Equal(t, nodeRunner.localNode.Address(), nodeRunner.Consensus().SelectProposer(2, 0))
-	require.Equal(t, nodeRunner.localNode.Address(), nodeRunner.Consensus().SelectProposer(2, 1))
+	for _, hr := range [][2]uint64{{1, 0}, {2, 0}, {2, 1}} {
+		proposer, err := nodeRunner.Consensus().SelectProposer(voting.Basis{Height: hr[0], Round: hr[1]})
+		require.NoError(t, err)
+		require.Equal(t, nodeRunner.localNode.Address(), proposer)
+	}
 }
 
 // All 3 nodes have the same proposer at each round
@@ -37,9 +40,9 @@ func TestNodesHaveSameProposers(t *testing.T) {
 
 	for i := uint64(0); i < maximumBlockHeight; i++ {
 		for j := uint64(0); j < maximumRoundNumber; j++ {
-			proposers0[i*maximumRoundNumber] = nr0.Consensus().SelectProposer(i, j)
-			proposers1[i*maximumRoundNumber] = nr1.Consensus().SelectProposer(i, j)
-			proposers2[i*maximumRoundNumber] = nr2.Consensus().SelectProposer(i, j)
+			proposers0[i*maximumRoundNumber], _ = nr0.Consensus().SelectProposer(voting.Basis{Height: i, Round: j})
+			proposers1[i*maximumRoundNumber], _ = nr1.Consensus().SelectProposer(voting.Basis{Height: i, Round: j})
+			proposers2[i*maximumRoundNumber], _ = nr2.Consensus().SelectProposer(voting.Basis{Height: i, Round: j})
 		}
 	}
 
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"sync/atomic"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+// SnapshotKeep is the number of snapshots kept by the validator; the previous
+// snapshot is kept for the nodes, which are downloading it.
+const SnapshotKeep int = 2
+
+// makeSnapshot makes the snapshot at `blk` in background, when the height of
+// block is the multiple of `Config.SnapshotInterval`. If the previous snapshot
+// is not finished yet, the new one is skipped.
+func (nr *NodeRunner) makeSnapshot(blk block.Block) {
+	interval := nr.Conf.SnapshotInterval
+	if interval < 1 || blk.Height%interval != 0 {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&nr.snapshotting, 0, 1) {
+		nr.log.Debug("previous snapshot is not finished; skip snapshot", "height", blk.Height)
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&nr.snapshotting, 0)
+
+		s, err := nr.saveSnapshot(blk)
+		if err != nil {
+			nr.log.Error("failed to make snapshot", "height", blk.Height, "error", err)
+			return
+		}
+
+		nr.log.Info(
+			"snapshot made",
+			"height", blk.Height,
+			"accounts", s.B.TotalAccounts,
+			"chunks", len(s.B.Chunks),
+		)
+	}()
+}
+
+// saveSnapshot makes the snapshot signed by the local node and removes the old
+// snapshots.
+func (nr *NodeRunner) saveSnapshot(blk block.Block) (s block.Snapshot, err error) {
+	if s, err = block.MakeSnapshot(nr.storage, blk, block.SnapshotChunkSize); err != nil {
+		return
+	}
+
+	s.Sign(nr.localNode.Keypair(), nr.networkID)
+	if err = s.Save(nr.storage); err != nil {
+		return
+	}
+
+	err = block.PruneSnapshots(nr.storage, SnapshotKeep)
+
+	return
+}
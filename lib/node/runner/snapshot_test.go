@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// TestNodeRunnerSaveSnapshot checks the snapshot is signed by the local node
+// and only the latest `SnapshotKeep` snapshots are kept.
+func TestNodeRunnerSaveSnapshot(t *testing.T) {
+	nr, localNode := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	genesis := block.GetGenesis(st)
+	for _, height := range []uint64{10, 20, 30} {
+		blk := block.NewBlock(
+			localNode.Address(),
+			voting.Basis{Height: height, BlockHash: genesis.Hash},
+			"",
+			nil,
+			genesis.StateRoot,
+			common.NowISO8601(),
+		)
+
+		s, err := nr.saveSnapshot(*blk)
+		require.NoError(t, err)
+		require.Equal(t, localNode.Address(), s.B.Source)
+	}
+
+	latest, err := block.GetLatestSnapshot(st)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), latest.Height())
+	require.NoError(t, latest.IsWellFormed(networkID))
+
+	_, err = block.GetSnapshot(st, 20)
+	require.NoError(t, err)
+	_, err = block.GetSnapshot(st, 10)
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+}
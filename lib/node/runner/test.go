@@ -7,6 +7,7 @@ import (
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/voting"
 	"github.com/stellar/go/keypair"
@@ -20,13 +21,14 @@ func MakeNodeRunner() (*NodeRunner, *node.LocalNode) {
 	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
 
 	localNode.AddValidators(localNode.ConvertToValidator())
+	conf := common.NewConfig()
 	connectionManager := network.NewValidatorConnectionManager(
 		localNode,
 		n,
 		policy,
+		conf,
 	)
 
-	conf := common.NewConfig()
 	st := block.InitTestBlockchain()
 	is, _ := consensus.NewISAAC(networkID, localNode, policy, connectionManager, st, conf, nil)
 	nodeRunner, _ := NewNodeRunner(string(networkID), localNode, policy, n, is, st, conf)
@@ -102,14 +104,15 @@ func GetUnfreezingTransaction(kpSource *keypair.Full, kpTarget *keypair.Full, se
 	}
 }
 
-func GenerateBallot(proposer *node.LocalNode, basis voting.Basis, tx transaction.Transaction, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
+func GenerateBallot(st *storage.LevelDBBackend, proposer *node.LocalNode, basis voting.Basis, tx transaction.Transaction, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
 	b := ballot.NewBallot(sender.Address(), proposer.Address(), basis, []string{tx.GetHash()})
 	b.SetVote(ballot.StateINIT, voting.YES)
 
-	opi, _ := ballot.NewInflationFromBallot(*b, block.CommonKP.Address(), common.BaseReserve)
+	opi, _ := ballot.NewInflationFromBallot(*b, block.CommonKP.Address(), common.BaseReserve, conf.NetworkParams.InflationRatio)
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*b, block.CommonKP.Address(), tx)
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*b, opc, opi)
 	b.SetProposerTransaction(ptx)
+	b.SetStateRoot(MakeTestStateRoot(st, ptx, tx))
 	b.Sign(proposer.Keypair(), networkID)
 
 	b.SetVote(ballotState, voting.YES)
@@ -122,14 +125,15 @@ func GenerateBallot(proposer *node.LocalNode, basis voting.Basis, tx transaction
 	return b
 }
 
-func GenerateEmptyTxBallot(proposer *node.LocalNode, basis voting.Basis, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
+func GenerateEmptyTxBallot(st *storage.LevelDBBackend, proposer *node.LocalNode, basis voting.Basis, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
 	b := ballot.NewBallot(sender.Address(), proposer.Address(), basis, []string{})
 	b.SetVote(ballot.StateINIT, voting.YES)
 
-	opi, _ := ballot.NewInflationFromBallot(*b, block.CommonKP.Address(), common.BaseReserve)
+	opi, _ := ballot.NewInflationFromBallot(*b, block.CommonKP.Address(), common.BaseReserve, conf.NetworkParams.InflationRatio)
 	opc, _ := ballot.NewCollectTxFeeFromBallot(*b, block.CommonKP.Address())
 	ptx, _ := ballot.NewProposerTransactionFromBallot(*b, opc, opi)
 	b.SetProposerTransaction(ptx)
+	b.SetStateRoot(MakeTestStateRoot(st, ptx))
 	b.Sign(proposer.Keypair(), networkID)
 
 	b.SetVote(ballotState, voting.YES)
@@ -142,6 +146,38 @@ func GenerateEmptyTxBallot(proposer *node.LocalNode, basis voting.Basis, ballotS
 	return b
 }
 
+// GenerateVote makes the ballot of `sender`, which votes for the proposal of
+// `proposed`; the validators must vote for the same proposal of proposer.
+func GenerateVote(proposed ballot.Ballot, ballotState ballot.State, sender *node.LocalNode, conf common.Config) *ballot.Ballot {
+	b := proposed
+	b.SetSource(sender.Address())
+	b.SetVote(ballotState, voting.YES)
+	b.Sign(sender.Keypair(), networkID)
+
+	if err := b.IsWellFormed(networkID, conf); err != nil {
+		panic(err)
+	}
+
+	return &b
+}
+
+// MakeTestStateRoot returns the state root, which is expected after the
+// transactions and the proposer transaction are applied to the latest block
+// of `st`. If they can not be applied, empty string is returned.
+func MakeTestStateRoot(st *storage.LevelDBBackend, ptx ballot.ProposerTransaction, txs ...transaction.Transaction) string {
+	var pTxs []*transaction.Transaction
+	for i := range txs {
+		pTxs = append(pTxs, &txs[i])
+	}
+
+	_, root, err := ApplyBlockState(st, block.GetLatestBlock(st), pTxs, ptx, common.NopLogger())
+	if err != nil {
+		return ""
+	}
+
+	return root
+}
+
 func ReceiveBallot(nodeRunner *NodeRunner, ballot *ballot.Ballot) error {
 	data, err := ballot.Serialize()
 	if err != nil {
@@ -24,7 +24,7 @@ func NewTestConnectionManager(
 	r chan struct{},
 ) *TestConnectionManager {
 	p := &TestConnectionManager{
-		ConnectionManager: network.NewValidatorConnectionManager(localNode, n, policy),
+		ConnectionManager: network.NewValidatorConnectionManager(localNode, n, policy, common.NewConfig()),
 	}
 	p.messages = []common.Message{}
 	p.recv = r
@@ -54,18 +54,18 @@ type FixedSelector struct {
 	address string
 }
 
-func (s FixedSelector) Select(_ uint64, _ uint64) string {
-	return s.address
+func (s FixedSelector) Select(_ voting.Basis) (string, error) {
+	return s.address, nil
 }
 
 type OtherSelector struct {
 	cm network.ConnectionManager
 }
 
-func (s OtherSelector) Select(_ uint64, _ uint64) string {
+func (s OtherSelector) Select(_ voting.Basis) (string, error) {
 	for _, v := range s.cm.AllValidators() {
 		if v != s.cm.GetNodeAddress() {
-			return v
+			return v, nil
 		}
 	}
 	panic("There is no the other validators")
@@ -75,13 +75,13 @@ type SelfThenOtherSelector struct {
 	cm network.ConnectionManager
 }
 
-func (s SelfThenOtherSelector) Select(blockHeight uint64, roundNumber uint64) string {
-	if blockHeight < 2 && roundNumber == 0 {
-		return s.cm.GetNodeAddress()
+func (s SelfThenOtherSelector) Select(basis voting.Basis) (string, error) {
+	if basis.Height < 2 && basis.Round == 0 {
+		return s.cm.GetNodeAddress(), nil
 	} else {
 		for _, v := range s.cm.AllValidators() {
 			if v != s.cm.GetNodeAddress() {
-				return v
+				return v, nil
 			}
 		}
 	}
@@ -0,0 +1,401 @@
+package runner
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// FaultInjector decides the network faults of the messages between the
+// `FaultyNetwork`s. The random faults are made by the random source of the
+// given seed, so the failed run can be investigated with the same seed.
+type FaultInjector struct {
+	sync.Mutex
+
+	Delay    time.Duration // every message is delayed
+	Jitter   time.Duration // random extra delay; the messages are reordered by it
+	DropRate float64       // ratio of the dropped messages, from 0 to 1
+
+	rand       *rand.Rand
+	partitions map[ /* endpoint */ string]int
+}
+
+func NewFaultInjector(seed int64) *FaultInjector {
+	return &FaultInjector{
+		rand:       rand.New(rand.NewSource(seed)),
+		partitions: map[string]int{},
+	}
+}
+
+// Partition splits the networks into the groups; the messages between the
+// different groups are not delivered until `Heal()`. The networks, which are
+// not in the groups, make one group together.
+func (f *FaultInjector) Partition(groups ...[]*FaultyNetwork) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.partitions = map[string]int{}
+	for i, group := range groups {
+		for _, n := range group {
+			f.partitions[n.Endpoint().String()] = i + 1
+		}
+	}
+}
+
+func (f *FaultInjector) Heal() {
+	f.Lock()
+	defer f.Unlock()
+
+	f.partitions = map[string]int{}
+}
+
+func (f *FaultInjector) IsPartitioned(from, to *common.Endpoint) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.partitions[from.String()] != f.partitions[to.String()]
+}
+
+// schedule returns the delay of message and whether the message is dropped.
+func (f *FaultInjector) schedule() (delay time.Duration, drop bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.DropRate > 0 && f.rand.Float64() < f.DropRate {
+		return 0, true
+	}
+
+	delay = f.Delay
+	if f.Jitter > 0 {
+		delay += time.Duration(f.rand.Int63n(int64(f.Jitter)))
+	}
+
+	return delay, false
+}
+
+// Byzantine makes the validator byzantine by changing the ballots, which are
+// sent to the other validators. It returns the ballot for the validator of
+// `to`; if `false` is returned, the ballot is not sent to it.
+type Byzantine interface {
+	Ballot(b ballot.Ballot, to *common.Endpoint) (ballot.Ballot, bool)
+}
+
+// isOtherHalf splits the validators into two halves by their endpoint; the
+// byzantine validator sends the different ballots to each half.
+func isOtherHalf(endpoint *common.Endpoint) bool {
+	return crc32.ChecksumIEEE([]byte(endpoint.String()))%2 == 1
+}
+
+// EquivocatingProposer proposes the two different ballots for the same round;
+// the other one has the different confirmed time, so it makes the different
+// block.
+type EquivocatingProposer struct {
+	sync.Mutex
+
+	kp        *keypair.Full
+	networkID []byte
+	proposals map[ /* voting.Basis.Index() */ string]ballot.Ballot
+}
+
+func NewEquivocatingProposer(kp *keypair.Full, networkID []byte) *EquivocatingProposer {
+	return &EquivocatingProposer{
+		kp:        kp,
+		networkID: networkID,
+		proposals: map[string]ballot.Ballot{},
+	}
+}
+
+func (e *EquivocatingProposer) Ballot(b ballot.Ballot, to *common.Endpoint) (ballot.Ballot, bool) {
+	if b.State() != ballot.StateINIT || b.Proposer() != e.kp.Address() || !isOtherHalf(to) {
+		return b, true
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	index := b.VotingBasis().Index()
+	if other, found := e.proposals[index]; found {
+		return other, true
+	}
+
+	other := b
+	for other.ProposerConfirmed() == b.ProposerConfirmed() {
+		time.Sleep(time.Millisecond)
+		other.Sign(e.kp, e.networkID)
+	}
+	e.proposals[index] = other
+
+	return other, true
+}
+
+// EquivocatingVoter sends the opposite votes of SIGN and ACCEPT to the other
+// half of validators.
+type EquivocatingVoter struct {
+	kp        *keypair.Full
+	networkID []byte
+}
+
+func NewEquivocatingVoter(kp *keypair.Full, networkID []byte) *EquivocatingVoter {
+	return &EquivocatingVoter{kp: kp, networkID: networkID}
+}
+
+func (e *EquivocatingVoter) Ballot(b ballot.Ballot, to *common.Endpoint) (ballot.Ballot, bool) {
+	if !b.State().IsValidForVote() || b.Source() != e.kp.Address() || !isOtherHalf(to) {
+		return b, true
+	}
+
+	vote := voting.NO
+	if b.Vote() != voting.YES {
+		vote = voting.YES
+	}
+	b.SetVote(b.State(), vote)
+	b.Sign(e.kp, e.networkID)
+
+	return b, true
+}
+
+// InvalidProposerTransaction proposes the ballot, which has the proposer
+// transaction with the wrong inflation amount.
+type InvalidProposerTransaction struct {
+	kp        *keypair.Full
+	networkID []byte
+}
+
+func NewInvalidProposerTransaction(kp *keypair.Full, networkID []byte) *InvalidProposerTransaction {
+	return &InvalidProposerTransaction{kp: kp, networkID: networkID}
+}
+
+func (e *InvalidProposerTransaction) Ballot(b ballot.Ballot, to *common.Endpoint) (ballot.Ballot, bool) {
+	if b.State() != ballot.StateINIT || b.Proposer() != e.kp.Address() {
+		return b, true
+	}
+
+	ptx := b.ProposerTransaction()
+	ops := make([]operation.Operation, len(ptx.B.Operations))
+	copy(ops, ptx.B.Operations)
+	for i, op := range ops {
+		if opb, ok := op.B.(operation.Inflation); ok {
+			opb.Amount = opb.Amount + common.Unit
+			ops[i].B = opb
+		}
+	}
+	ptx.B.Operations = ops
+	ptx.H.Hash = ptx.B.MakeHashString()
+
+	b.SetProposerTransaction(ptx)
+	b.Sign(e.kp, e.networkID)
+
+	return b, true
+}
+
+// VoteWithholder never sends it's SIGN and ACCEPT votes.
+type VoteWithholder struct {
+	kp *keypair.Full
+}
+
+func NewVoteWithholder(kp *keypair.Full) *VoteWithholder {
+	return &VoteWithholder{kp: kp}
+}
+
+func (e *VoteWithholder) Ballot(b ballot.Ballot, to *common.Endpoint) (ballot.Ballot, bool) {
+	if b.State().IsValidForVote() && b.Source() == e.kp.Address() {
+		return b, false
+	}
+
+	return b, true
+}
+
+// FaultyNetwork wraps `network.MemoryNetwork` to inject the faults of
+// `FaultInjector` and the behaviour of `Byzantine` into the messages, which
+// are sent by the node. The messages to the node itself are not changed.
+type FaultyNetwork struct {
+	*network.MemoryNetwork
+
+	injector  *FaultInjector
+	byzantine Byzantine
+}
+
+func NewFaultyNetwork(n *network.MemoryNetwork, injector *FaultInjector) *FaultyNetwork {
+	return &FaultyNetwork{
+		MemoryNetwork: n,
+		injector:      injector,
+	}
+}
+
+// SetByzantine makes the node byzantine; it must be set before the node is
+// started.
+func (n *FaultyNetwork) SetByzantine(byzantine Byzantine) {
+	n.byzantine = byzantine
+}
+
+func (n *FaultyNetwork) GetClient(endpoint *common.Endpoint) network.NetworkClient {
+	return &faultyClient{
+		NetworkClient: n.MemoryNetwork.GetClient(endpoint),
+		network:       n,
+		to:            endpoint,
+	}
+}
+
+type faultyClient struct {
+	network.NetworkClient
+
+	network *FaultyNetwork
+	to      *common.Endpoint
+}
+
+func (c *faultyClient) isSelf() bool {
+	return c.network.Endpoint().String() == c.to.String()
+}
+
+func (c *faultyClient) Connect(n node.Node) (b []byte, err error) {
+	if !c.isSelf() && c.network.injector.IsPartitioned(c.network.Endpoint(), c.to) {
+		err = errors.MemoryNetworkLinkDown
+		return
+	}
+
+	return c.NetworkClient.Connect(n)
+}
+
+func (c *faultyClient) SendMessage(message common.Serializable) (body []byte, err error) {
+	err = c.send(message, c.NetworkClient.SendMessage)
+	return
+}
+
+func (c *faultyClient) SendTransaction(message common.Serializable) (body []byte, err error) {
+	err = c.send(message, c.NetworkClient.SendTransaction)
+	return
+}
+
+func (c *faultyClient) SendBallot(message common.Serializable) (body []byte, err error) {
+	if c.network.byzantine != nil && !c.isSelf() {
+		var s []byte
+		if s, err = message.Serialize(); err != nil {
+			return
+		}
+
+		var b ballot.Ballot
+		if b, err = ballot.NewBallotFromJSON(s); err != nil {
+			return
+		}
+
+		var send bool
+		if b, send = c.network.byzantine.Ballot(b, c.to); !send {
+			return
+		}
+		message = b
+	}
+
+	err = c.send(message, c.NetworkClient.SendBallot)
+	return
+}
+
+func (c *faultyClient) send(message common.Serializable, f func(common.Serializable) ([]byte, error)) (err error) {
+	if c.isSelf() {
+		_, err = f(message)
+		return
+	}
+
+	if c.network.injector.IsPartitioned(c.network.Endpoint(), c.to) {
+		err = errors.MemoryNetworkLinkDown
+		return
+	}
+
+	delay, drop := c.network.injector.schedule()
+	if drop {
+		return
+	}
+	if delay < 1 {
+		_, err = f(message)
+		return
+	}
+
+	time.AfterFunc(delay, func() {
+		f(message)
+	})
+
+	return
+}
+
+// MakeFaultyNodeRunners makes the node runners, which are connected by the
+// `FaultyNetwork`s of the same `FaultInjector`.
+func MakeFaultyNodeRunners(n int, conf common.Config, injector *FaultInjector) (nodeRunners []*NodeRunner, networks []*FaultyNetwork) {
+	var nodes []*node.LocalNode
+	var prev *network.MemoryNetwork
+	for i := 0; i < n; i++ {
+		_, s, v := network.CreateMemoryNetwork(prev)
+		prev = s
+		networks = append(networks, NewFaultyNetwork(s, injector))
+		nodes = append(nodes, v)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			nodes[i].AddValidators(nodes[j].ConvertToValidator())
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+		connectionManager := network.NewValidatorConnectionManager(nodes[i], networks[i], policy, conf)
+
+		st := block.InitTestBlockchain()
+		is, err := consensus.NewISAAC(networkID, nodes[i], policy, connectionManager, st, conf, nil)
+		if err != nil {
+			panic(err)
+		}
+		nr, err := NewNodeRunner(string(networkID), nodes[i], policy, networks[i], is, st, conf)
+		if err != nil {
+			panic(err)
+		}
+		nodeRunners = append(nodeRunners, nr)
+	}
+
+	return
+}
+
+// CheckSafety checks the node runners store the same blocks; the different
+// blocks at the same height mean the consensus is broken.
+func CheckSafety(nodeRunners []*NodeRunner) error {
+	var highest uint64
+	for _, nr := range nodeRunners {
+		if height := block.GetLatestBlock(nr.Storage()).Height; height > highest {
+			highest = height
+		}
+	}
+
+	for height := common.GenesisBlockHeight; height <= highest; height++ {
+		var hash, by string
+		for _, nr := range nodeRunners {
+			blk, err := block.GetBlockByHeight(nr.Storage(), height)
+			if err != nil {
+				continue
+			}
+			if hash == "" {
+				hash, by = blk.Hash, nr.Node().Alias()
+				continue
+			}
+			if blk.Hash != hash {
+				return fmt.Errorf(
+					"different blocks at height %d: %s has %s, but %s has %s",
+					height, by, hash, nr.Node().Alias(), blk.Hash,
+				)
+			}
+		}
+	}
+
+	return nil
+}
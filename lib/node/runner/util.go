@@ -107,18 +107,7 @@ func NewNodeInfo(nr *NodeRunner) node.NodeInfo {
 		Validators: localNode.GetValidators(),
 	}
 
-	policy := node.NodePolicy{
-		NetworkID:                 string(nr.NetworkID()),
-		InitialBalance:            nr.InitialBalance,
-		BaseReserve:               common.BaseReserve,
-		BaseFee:                   common.BaseFee,
-		BlockTime:                 nr.Conf.BlockTime,
-		OperationsLimit:           nr.Conf.OpsLimit,
-		TransactionsLimit:         nr.Conf.TxsLimit,
-		GenesisBlockConfirmedTime: common.GenesisBlockConfirmedTime,
-		InflationRatio:            common.InflationRatioString,
-		BlockHeightEndOfInflation: common.BlockHeightEndOfInflation,
-	}
+	policy := node.NewNodePolicy(string(nr.NetworkID()), nr.InitialBalance, nr.Conf)
 
 	return node.NodeInfo{
 		Node:   nd,
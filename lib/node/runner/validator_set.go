@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"time"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// updateValidators applies the confirmed changes of validator set, which are
+// activated until the next block of the latest block. After the validators of
+// `LocalNode` are changed, `ConnectionManager`, the threshold policy and the
+// proposer selector follow the new validator set. It is called when the node
+// is started and after the new block is stored, so the ballots of the new
+// height are checked with the validator set of it.
+//
+// When the local node is removed, it keeps following the consensus like the
+// watcher, but it does not vote; see `castVote()`.
+func (nr *NodeRunner) updateValidators() (err error) {
+	nr.validatorSetLock.Lock()
+	defer nr.validatorSetLock.Unlock()
+
+	next := block.GetLatestBlock(nr.storage).Height + 1
+	if next <= nr.validatorSetHeight {
+		return
+	}
+
+	var changes []block.ValidatorSetChange
+	if changes, err = block.GetValidatorSetChanges(nr.storage, nr.validatorSetHeight, next); err != nil {
+		return
+	}
+	nr.validatorSetHeight = next
+
+	if len(changes) < 1 {
+		return
+	}
+
+	for _, vsc := range changes {
+		switch vsc.Type {
+		case operation.TypeAddValidator:
+			if vsc.Address == nr.localNode.Address() && nr.localNode.IsWatcher() {
+				continue
+			}
+
+			var endpoint *common.Endpoint
+			if endpoint, err = common.NewEndpointFromString(vsc.Endpoint); err != nil {
+				return
+			}
+			var v *node.Validator
+			if v, err = node.NewValidator(vsc.Address, endpoint, vsc.Alias); err != nil {
+				return
+			}
+			nr.localNode.AddValidators(v)
+		case operation.TypeRemoveValidator:
+			nr.localNode.RemoveValidators(vsc.Address)
+			if vsc.Address == nr.localNode.Address() {
+				nr.log.Warn("local node is removed from the validator set; stop voting", "height", vsc.ActivationHeight)
+			}
+		}
+
+		nr.log.Debug("validator set changed", "change", vsc)
+	}
+
+	nr.connectionManager.UpdateValidators()
+
+	validators := nr.localNode.GetValidators()
+	if err = nr.policy.SetValidators(len(validators)); err != nil {
+		return
+	}
+
+	nr.log.Info("validator set updated", "height", next, "validators", len(validators))
+
+	return
+}
+
+// watchValidatorSet updates the validators of watcher periodically; the
+// watcher does not handle ballots, so the validator set is updated after the
+// blocks are stored by `sync.Watcher`.
+func (nr *NodeRunner) watchValidatorSet(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := nr.updateValidators(); err != nil {
+				nr.log.Error("failed to update validators", "error", err)
+			}
+		case <-nr.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func makeValidatorSetTransaction(kp *keypair.Full, sequenceID uint64, opbs ...operation.Body) transaction.Transaction {
+	var ops []operation.Operation
+	for _, opb := range opbs {
+		op, err := operation.NewOperation(opb)
+		if err != nil {
+			panic(err)
+		}
+		ops = append(ops, op)
+	}
+
+	tx, err := transaction.NewTransaction(kp.Address(), sequenceID, common.BaseFee, ops...)
+	if err != nil {
+		panic(err)
+	}
+	tx.Sign(kp, networkID)
+
+	return tx
+}
+
+func TestValidateValidatorSetChange(t *testing.T) {
+	nr, _, _ := createNodeRunnerForTesting(3, common.NewConfig(), nil)
+	st := nr.Storage()
+
+	kp, _ := keypair.Random()
+	endpoint, _ := common.NewEndpointFromString("https://localhost:12345")
+	latest := block.GetLatestBlock(st)
+
+	genesisAccount, _ := block.GetBlockAccount(st, block.GenesisKP.Address())
+
+	{ // valid
+		tx := makeValidatorSetTransaction(
+			block.GenesisKP,
+			genesisAccount.SequenceID,
+			operation.NewAddValidator(kp.Address(), endpoint, "", latest.Height+2),
+		)
+		require.NoError(t, ValidateTx(st, common.NewConfig(), tx))
+	}
+
+	{ // activated before the block, which includes the transaction
+		tx := makeValidatorSetTransaction(
+			block.GenesisKP,
+			genesisAccount.SequenceID,
+			operation.NewRemoveValidator(kp.Address(), latest.Height+1),
+		)
+		require.Equal(t, errors.InvalidActivationHeight, ValidateTx(st, common.NewConfig(), tx))
+	}
+
+	{ // only genesis account can change the validator set
+		op, _ := operation.NewOperation(operation.NewRemoveValidator(kp.Address(), latest.Height+2))
+		commonAccount, _ := block.GetBlockAccount(st, block.CommonKP.Address())
+
+		require.Equal(t, errors.ValidatorSetChangeNotAllowed, ValidateOp(st, common.NewConfig(), commonAccount, op))
+	}
+}
+
+/*
+TestNodeRunnerUpdateValidators indicates the following:
+	1. There are 3 validators.
+	2. The block of height 2 has the transaction, which adds the new validator
+	and removes the one of validators at height 4.
+	3. Until the block of height 3 is stored, the validator set is not changed.
+	4. After the block of height 3, the new validator set is used for the block
+	of height 4.
+*/
+func TestNodeRunnerUpdateValidators(t *testing.T) {
+	nr, nodes, _ := createNodeRunnerForTesting(3, common.NewConfig(), nil)
+	st := nr.Storage()
+
+	kp, _ := keypair.Random()
+	endpoint, _ := common.NewEndpointFromString("https://localhost:12345")
+	removed := nodes[2].Address()
+
+	require.Equal(t, 3, nr.Policy().Validators())
+
+	genesisAccount, _ := block.GetBlockAccount(st, block.GenesisKP.Address())
+	tx := makeValidatorSetTransaction(
+		block.GenesisKP,
+		genesisAccount.SequenceID,
+		operation.NewAddValidator(kp.Address(), endpoint, "new", 4),
+		operation.NewRemoveValidator(removed, 4),
+	)
+	require.NoError(t, ValidateTx(st, common.NewConfig(), tx))
+
+	latest := block.GetLatestBlock(st)
+	blk := block.TestMakeNewBlockWithPrevBlock(latest, []string{tx.GetHash()})
+	blk.MustSave(st)
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, st))
+
+	require.NoError(t, nr.updateValidators())
+	require.True(t, nr.Node().HasValidators(removed))
+	require.False(t, nr.Node().HasValidators(kp.Address()))
+
+	blk = block.TestMakeNewBlockWithPrevBlock(blk, nil)
+	blk.MustSave(st)
+
+	require.NoError(t, nr.updateValidators())
+	require.False(t, nr.Node().HasValidators(removed))
+	require.True(t, nr.Node().HasValidators(kp.Address()))
+	require.Equal(t, "new", nr.Node().GetValidators()[kp.Address()].Alias())
+
+	require.Equal(t, 3, nr.Policy().Validators())
+	require.Equal(t, 2, nr.Policy().Threshold())
+
+	validators := nr.ConnectionManager().AllValidators()
+	require.Equal(t, 3, len(validators))
+	require.Contains(t, validators, kp.Address())
+	require.NotContains(t, validators, removed)
+	require.Nil(t, nr.ConnectionManager().GetNode(removed))
+	require.NotNil(t, nr.ConnectionManager().GetNode(kp.Address()))
+
+	{ // the stored changes are applied again after restart
+		localNode := nodes[0]
+		localNode.AddValidators(nodes[2].ConvertToValidator())
+		localNode.RemoveValidators(kp.Address())
+
+		nr.validatorSetHeight = 0
+		require.NoError(t, nr.updateValidators())
+		require.False(t, nr.Node().HasValidators(removed))
+		require.True(t, nr.Node().HasValidators(kp.Address()))
+	}
+}
+
+// TestNodeRunnerUpdateValidatorsRemoveLocalNode checks the local node, which is
+// removed from the validator set, does not vote anymore.
+func TestNodeRunnerUpdateValidatorsRemoveLocalNode(t *testing.T) {
+	nr, nodes, cm := createNodeRunnerForTesting(3, common.NewConfig(), nil)
+	st := nr.Storage()
+
+	genesisAccount, _ := block.GetBlockAccount(st, block.GenesisKP.Address())
+	tx := makeValidatorSetTransaction(
+		block.GenesisKP,
+		genesisAccount.SequenceID,
+		operation.NewRemoveValidator(nodes[0].Address(), 3),
+	)
+
+	latest := block.GetLatestBlock(st)
+	blk := block.TestMakeNewBlockWithPrevBlock(latest, []string{tx.GetHash()})
+	blk.MustSave(st)
+	require.NoError(t, FinishTransactions(blk, []*transaction.Transaction{&tx}, st))
+
+	require.NoError(t, nr.updateValidators())
+	require.False(t, nr.Node().HasValidators(nodes[0].Address()))
+	require.Equal(t, 2, nr.Policy().Validators())
+
+	basis := voting.Basis{Height: blk.Height, BlockHash: blk.Hash, TotalTxs: blk.TotalTxs, TotalOps: blk.TotalOps}
+	b := GenerateEmptyTxBallot(st, nodes[0], basis, ballot.StateSIGN, nodes[0], common.NewConfig())
+	require.NoError(t, nr.castVote(*b))
+	require.Equal(t, 0, len(cm.Messages()))
+
+	_, found, err := nr.voteJournal.Get(b.VotingBasis(), b.State())
+	require.NoError(t, err)
+	require.False(t, found)
+}
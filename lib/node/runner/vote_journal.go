@@ -0,0 +1,54 @@
+package runner
+
+import (
+	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// castVote journals the ballot of local node before it is broadcasted. If the
+// node already voted differently for the same round and state, for example
+// before it restarted, the new ballot is refused and the journaled ballot is
+// broadcasted again. After the local node is removed from the validator set,
+// it follows the consensus, but does not vote anymore.
+func (nr *NodeRunner) castVote(b ballot.Ballot) (err error) {
+	if !nr.localNode.HasValidators(nr.localNode.Address()) {
+		nr.log.Debug("not validator anymore; vote is not broadcasted", "basis", b.VotingBasis(), "state", b.State())
+		return
+	}
+
+	// the votes before the latest block are not needed anymore
+	if latest := block.GetLatestBlock(nr.storage); latest.Height > 0 {
+		if err = nr.voteJournal.RemoveUntil(latest.Height - 1); err != nil {
+			return
+		}
+	}
+
+	var voted ballot.Ballot
+	var found bool
+	if voted, found, err = nr.voteJournal.Get(b.VotingBasis(), b.State()); err != nil {
+		return
+	}
+
+	if !found {
+		if err = nr.voteJournal.Add(b); err != nil {
+			return
+		}
+	} else if consensus.IsConflictingBallot(voted, b) {
+		nr.log.Warn(
+			"conflicting vote refused",
+			"basis", b.VotingBasis(),
+			"state", b.State(),
+			"voted", voted.Vote(),
+			"vote", b.Vote(),
+		)
+		nr.ConnectionManager().Broadcast(voted)
+
+		return errors.ConflictingVote
+	}
+
+	nr.ConnectionManager().Broadcast(b)
+
+	return
+}
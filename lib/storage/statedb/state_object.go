@@ -3,10 +3,9 @@ package statedb
 import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
-	"boscoin.io/sebak/lib/common/observer"
 	"boscoin.io/sebak/lib/storage/statedb/trie"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"bytes"
-	"fmt"
 )
 
 type Storage map[common.Hash]common.Hash
@@ -114,6 +113,9 @@ func (so *stateObject) SetState(key, value common.Hash) {
 
 func (so *stateObject) AddBalance(amount common.Amount) (err error) {
 	val, err := so.Balance().Add(amount)
+	if err != nil {
+		return
+	}
 	so.data.Balance = val
 	if so.onDirty != nil {
 		so.onDirty(so.Address())
@@ -123,13 +125,18 @@ func (so *stateObject) AddBalance(amount common.Amount) (err error) {
 }
 
 func (so *stateObject) AddBalanceWithSequenceID(amount common.Amount, sequenceID uint64) (err error) {
+	if err = so.AddBalance(amount); err != nil {
+		return
+	}
 	so.data.SequenceID = sequenceID
-	so.AddBalance(amount)
 	return
 }
 
 func (so *stateObject) SubBalance(amount common.Amount) (err error) {
 	val, err := so.Balance().Sub(amount)
+	if err != nil {
+		return
+	}
 	so.data.Balance = val
 	if so.onDirty != nil {
 		so.onDirty(so.Address())
@@ -139,8 +146,10 @@ func (so *stateObject) SubBalance(amount common.Amount) (err error) {
 }
 
 func (so *stateObject) SubBalanceWithSequenceID(amount common.Amount, sequenceID uint64) (err error) {
+	if err = so.SubBalance(amount); err != nil {
+		return
+	}
 	so.data.SequenceID = sequenceID
-	so.SubBalance(amount)
 	return
 }
 
@@ -152,6 +161,22 @@ func (so *stateObject) SetSequenceID(sequenceID uint64) {
 	}
 }
 
+func (so *stateObject) SetLinked(linked string) {
+	so.data.Linked = linked
+	if so.onDirty != nil {
+		so.onDirty(so.Address())
+		so.onDirty = nil
+	}
+}
+
+func (so *stateObject) SetSigners(signers []operation.Signer, thresholds operation.Thresholds) {
+	so.data.SetSigners(signers, thresholds)
+	if so.onDirty != nil {
+		so.onDirty(so.Address())
+		so.onDirty = nil
+	}
+}
+
 func (so *stateObject) SetCode(codeHash, code []byte) {
 	so.code = code
 	so.data.CodeHash = codeHash
@@ -193,37 +218,7 @@ func (so *stateObject) CommitDB(root common.Hash) (err error) {
 }
 
 func (so *stateObject) Save() (err error) {
-	st := so.db.BackEnd()
-	key := block.GetBlockAccountKey(so.Address())
-
-	var exists bool
-	exists, err = st.Has(key)
-	if err != nil {
-		return
-	}
-
-	if exists {
-		err = st.Set(key, so.data)
-	} else {
-		// TODO consider to use, [`Transaction`](https://godoc.org/github.com/syndtr/goleveldb/leveldb#DB.OpenTransaction)
-		err = st.New(key, so.data)
-		createdKey := block.GetBlockAccountCreatedKey(common.GetUniqueIDFromUUID())
-		err = st.New(createdKey, so.Address())
-	}
-	if err == nil {
-		event := "saved"
-		event += " " + fmt.Sprintf("address-%s", so.Address())
-		observer.BlockAccountObserver.Trigger(event, &so.data)
-	}
-
-	bac := block.BlockAccountSequenceID{
-		SequenceID: so.data.SequenceID,
-		Address:    so.Address(),
-		Balance:    so.data.Balance,
-	}
-	err = bac.Save(st)
-
-	return
+	return so.data.Save(so.db.BackEnd())
 }
 
 /*
@@ -3,7 +3,9 @@ package statedb
 import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage/statedb/trie"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"fmt"
 )
 
@@ -14,6 +16,10 @@ type StateDB struct {
 	stateObjects            map[string]*stateObject
 	stateObjectsDirty       map[string]struct{}
 	stateObjectsCommitDirty map[string]struct{}
+
+	// stateObjectsOrder keeps the order of loaded accounts to store them by
+	// the order of operations.
+	stateObjectsOrder []string
 }
 
 func New(root common.Hash, db *trie.EthDatabase) *StateDB {
@@ -45,13 +51,14 @@ func (stateDB *StateDB) GetOrNewStateObject(addr string) *stateObject {
 	return stateObject
 }
 
-func (stateDB *StateDB) GetCheckPoint(addr string) uint64 {
+func (stateDB *StateDB) GetCheckPoint(addr string) (uint64, error) {
 	stateObject := stateDB.getStateObject(addr)
-	if stateObject != nil {
-		return stateObject.SequenceID()
+	if stateObject == nil {
+		return 0, errors.BlockAccountDoesNotExists
 	}
-	panic("No such sequenceID")
+	return stateObject.SequenceID(), nil
 }
+
 func (stateDB *StateDB) GetBalance(addr string) common.Amount {
 	stateObject := stateDB.getStateObject(addr)
 	if stateObject != nil {
@@ -86,6 +93,21 @@ func (stateDB *StateDB) GetState(a string, b common.Hash) common.Hash {
 
 func (stateDB *StateDB) CreateAccount(addr string) {
 	stateDB.createObject(addr)
+	stateDB.MarkStateObjectDirty(addr)
+}
+
+func (stateDB *StateDB) SetLinked(addr string, linked string) {
+	stateObject := stateDB.GetOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetLinked(linked)
+	}
+}
+
+func (stateDB *StateDB) SetSigners(addr string, signers []operation.Signer, thresholds operation.Thresholds) {
+	stateObject := stateDB.GetOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetSigners(signers, thresholds)
+	}
 }
 
 func (stateDB *StateDB) SetSequenceID(addr string, sequenceID uint64) {
@@ -95,32 +117,36 @@ func (stateDB *StateDB) SetSequenceID(addr string, sequenceID uint64) {
 	}
 }
 
-func (stateDB *StateDB) AddBalance(addr string, amount common.Amount) {
+func (stateDB *StateDB) AddBalance(addr string, amount common.Amount) (err error) {
 	stateObject := stateDB.GetOrNewStateObject(addr)
 	if stateObject != nil {
-		stateObject.AddBalance(amount)
+		err = stateObject.AddBalance(amount)
 	}
+	return
 }
 
-func (stateDB *StateDB) AddBalanceWithSequenceID(addr string, amount common.Amount, sequenceID uint64) {
+func (stateDB *StateDB) AddBalanceWithSequenceID(addr string, amount common.Amount, sequenceID uint64) (err error) {
 	stateObject := stateDB.GetOrNewStateObject(addr)
 	if stateObject != nil {
-		stateObject.AddBalanceWithSequenceID(amount, sequenceID)
+		err = stateObject.AddBalanceWithSequenceID(amount, sequenceID)
 	}
+	return
 }
 
-func (stateDB *StateDB) SubBalance(addr string, amount common.Amount) {
+func (stateDB *StateDB) SubBalance(addr string, amount common.Amount) (err error) {
 	stateObject := stateDB.GetOrNewStateObject(addr)
 	if stateObject != nil {
-		stateObject.SubBalance(amount)
+		err = stateObject.SubBalance(amount)
 	}
+	return
 }
 
-func (stateDB *StateDB) SubBalanceWithSequenceID(addr string, amount common.Amount, sequenceID uint64) {
+func (stateDB *StateDB) SubBalanceWithSequenceID(addr string, amount common.Amount, sequenceID uint64) (err error) {
 	stateObject := stateDB.GetOrNewStateObject(addr)
 	if stateObject != nil {
-		stateObject.SubBalanceWithSequenceID(amount, sequenceID)
+		err = stateObject.SubBalanceWithSequenceID(amount, sequenceID)
 	}
+	return
 }
 
 func (stateDB *StateDB) SetCode(addr string, code []byte) {
@@ -149,7 +175,6 @@ func (stateDB *StateDB) getStateObject(addr string) (stateObject *stateObject) {
 		return nil
 	}
 	var data block.BlockAccount
-
 	if err := data.Deserialize(enc); err != nil {
 		return nil
 	}
@@ -158,7 +183,31 @@ func (stateDB *StateDB) getStateObject(addr string) (stateObject *stateObject) {
 	return obj
 }
 
+// GetProof returns the account committed in the trie and the proof nodes of
+// it; unlike `getStateObject`, the account changed but not committed yet is
+// not found.
+func (stateDB *StateDB) GetProof(addr string) (ba *block.BlockAccount, proof [][]byte, err error) {
+	var enc []byte
+	if enc, err = stateDB.trie.TryGet([]byte(addr)); err != nil {
+		return
+	} else if len(enc) == 0 {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	ba = &block.BlockAccount{}
+	if err = ba.Deserialize(enc); err != nil {
+		return
+	}
+
+	proof, err = stateDB.trie.Prove([]byte(addr))
+	return
+}
+
 func (stateDB *StateDB) setStateObject(object *stateObject) {
+	if _, found := stateDB.stateObjects[object.Address()]; !found {
+		stateDB.stateObjectsOrder = append(stateDB.stateObjectsOrder, object.Address())
+	}
 	stateDB.stateObjects[object.Address()] = object
 }
 
@@ -181,7 +230,8 @@ func (stateDB *StateDB) updateStateObject(stateObject *stateObject) {
 
 func (stateDB *StateDB) CommitTrie() (root common.Hash, err error) {
 
-	for addr, stateObject := range stateDB.stateObjects {
+	for _, addr := range stateDB.stateObjectsOrder {
+		stateObject := stateDB.stateObjects[addr]
 		if _, isDirty := stateDB.stateObjectsDirty[addr]; isDirty {
 			if _, err = stateObject.CommitTrie(); err != nil {
 				return common.Hash{}, err
@@ -195,8 +245,20 @@ func (stateDB *StateDB) CommitTrie() (root common.Hash, err error) {
 	return
 }
 
+// ChangedAccounts returns the accounts changed by `CommitTrie()`, which are
+// not stored yet by `CommitDB()`.
+func (stateDB *StateDB) ChangedAccounts() (accounts []block.BlockAccount) {
+	for _, addr := range stateDB.stateObjectsOrder {
+		if _, isDirty := stateDB.stateObjectsCommitDirty[addr]; isDirty {
+			accounts = append(accounts, stateDB.stateObjects[addr].data)
+		}
+	}
+	return
+}
+
 func (stateDB *StateDB) CommitDB(root common.Hash) (err error) {
-	for addr, stateObject := range stateDB.stateObjects {
+	for _, addr := range stateDB.stateObjectsOrder {
+		stateObject := stateDB.stateObjects[addr]
 		if _, isDirty := stateDB.stateObjectsCommitDirty[addr]; isDirty {
 			if err = stateObject.CommitDB(stateObject.data.RootHash); err != nil {
 				return
@@ -3,9 +3,12 @@ package statedb
 import (
 	"testing"
 
+	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/storage/statedb/trie"
+	trieproof "boscoin.io/sebak/lib/trie/proof"
 
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +39,77 @@ func TestStateDB(t *testing.T) {
 		require.Equal(t, gotValueHash, valueHash)
 	}
 }
+
+func TestStateDBBalance(t *testing.T) {
+	st := storage.NewTestStorage()
+
+	// account which is stored without state trie is not in the state
+	ba := block.NewBlockAccount("killme", common.Amount(100))
+	require.NoError(t, ba.Save(st))
+
+	stateDB := New(common.Hash{}, trie.NewEthDatabase(st))
+	require.False(t, stateDB.ExistAccount("killme"))
+	_, err := stateDB.GetCheckPoint("killme")
+	require.Equal(t, errors.BlockAccountDoesNotExists, err)
+
+	stateDB.CreateAccount("showme")
+	require.NoError(t, stateDB.AddBalance("showme", common.Amount(100)))
+	require.True(t, stateDB.ExistAccount("showme"))
+	require.False(t, stateDB.ExistAccount("findme"))
+
+	require.NoError(t, stateDB.SubBalanceWithSequenceID("showme", common.Amount(30), 1))
+	require.Equal(t, common.Amount(70), stateDB.GetBalance("showme"))
+	sequenceID, err := stateDB.GetCheckPoint("showme")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequenceID)
+
+	// insufficient balance does not change the account
+	require.Error(t, stateDB.SubBalance("showme", common.Amount(71)))
+	require.Equal(t, common.Amount(70), stateDB.GetBalance("showme"))
+
+	root, err := stateDB.CommitTrie()
+	require.NoError(t, err)
+	require.NoError(t, stateDB.CommitDB(root))
+
+	{
+		stateDB := New(root, trie.NewEthDatabase(st))
+		require.Equal(t, common.Amount(70), stateDB.GetBalance("showme"))
+
+		ba, err := block.GetBlockAccount(st, "showme")
+		require.NoError(t, err)
+		require.Equal(t, common.Amount(70), ba.Balance)
+		require.Equal(t, uint64(1), ba.SequenceID)
+	}
+}
+
+func TestStateDBProof(t *testing.T) {
+	st := storage.NewTestStorage()
+
+	stateDB := New(common.Hash{}, trie.NewEthDatabase(st))
+	for _, addr := range []string{"showme", "findme", "killme"} {
+		stateDB.CreateAccount(addr)
+		require.NoError(t, stateDB.AddBalance(addr, common.Amount(100)))
+	}
+	root, err := stateDB.CommitTrie()
+	require.NoError(t, err)
+	require.NoError(t, stateDB.CommitDB(root))
+
+	stateDB = New(root, trie.NewEthDatabase(st))
+	ba, proof, err := stateDB.GetProof("showme")
+	require.NoError(t, err)
+	require.Equal(t, "showme", ba.Address)
+	require.Equal(t, common.Amount(100), ba.Balance)
+
+	value, err := trieproof.VerifyProof(root, []byte("showme"), proof)
+	require.NoError(t, err)
+	expected, err := ba.Serialize()
+	require.NoError(t, err)
+	require.Equal(t, expected, value)
+
+	// the proof of the other root can not be verified
+	_, err = trieproof.VerifyProof(common.Hash{}, []byte("showme"), proof)
+	require.Error(t, err)
+
+	_, _, err = stateDB.GetProof("unknown")
+	require.Equal(t, errors.BlockAccountDoesNotExists, err)
+}
@@ -1,6 +1,7 @@
 package trie
 
 import (
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/storage"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -18,16 +19,22 @@ func NewEthDatabase(ldb *storage.LevelDBBackend) *EthDatabase {
 	}
 }
 
+// makeKey puts the trie nodes under `common.StateTriePrefix`; without prefix,
+// the node hash can be found by the prefix iteration of the other models.
+func makeKey(key []byte) []byte {
+	return append([]byte(common.StateTriePrefix), key...)
+}
+
 func (db *EthDatabase) Put(key []byte, value []byte) error {
-	return db.ldbBackend.Core.Put(key, value, nil)
+	return db.ldbBackend.Core.Put(makeKey(key), value, nil)
 }
 
 func (db *EthDatabase) Has(key []byte) (bool, error) {
-	return db.ldbBackend.Core.Has(key, nil)
+	return db.ldbBackend.Core.Has(makeKey(key), nil)
 }
 
 func (db *EthDatabase) Get(key []byte) ([]byte, error) {
-	dat, err := db.ldbBackend.Core.Get(key, nil)
+	dat, err := db.ldbBackend.Core.Get(makeKey(key), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +42,7 @@ func (db *EthDatabase) Get(key []byte) ([]byte, error) {
 }
 
 func (db *EthDatabase) Delete(key []byte) error {
-	return db.ldbBackend.Core.Delete(key, nil)
+	return db.ldbBackend.Core.Delete(makeKey(key), nil)
 }
 
 func (db *EthDatabase) Close() {
@@ -59,19 +66,48 @@ type ldbBatch struct {
 }
 
 func (b *ldbBatch) Put(key, value []byte) error {
-	b.b.Put(key, value)
+	b.b.Put(makeKey(key), value)
 	b.size += len(value)
 	return nil
 }
 
 func (b *ldbBatch) Delete(key []byte) error {
-	b.b.Delete(key)
+	b.b.Delete(makeKey(key))
 	b.size += 1
 	return nil
 }
 
+// Write writes the items at once by the leveldb batch. If the backend is
+// opened by `LevelDBBackend.OpenBatch()`, the items are put into the batch of
+// backend instead, because `BatchCore.Write()` writes to the database at
+// once; they are written atomically when the backend is committed.
 func (b *ldbBatch) Write() error {
-	return b.db.Core.Write(b.b, nil)
+	if _, ok := b.db.Core.(*storage.BatchCore); !ok {
+		return b.db.Core.Write(b.b, nil)
+	}
+
+	r := &coreReplayer{core: b.db.Core}
+	if err := b.b.Replay(r); err != nil {
+		return err
+	}
+	return r.err
+}
+
+type coreReplayer struct {
+	core storage.LevelDBCore
+	err  error
+}
+
+func (r *coreReplayer) Put(key, value []byte) {
+	if r.err == nil {
+		r.err = r.core.Put(key, value, nil)
+	}
+}
+
+func (r *coreReplayer) Delete(key []byte) {
+	if r.err == nil {
+		r.err = r.core.Delete(key, nil)
+	}
 }
 
 func (b *ldbBatch) ValueSize() int {
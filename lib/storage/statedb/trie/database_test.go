@@ -0,0 +1,44 @@
+package trie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestEthDatabaseBatchWrite(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	// without `OpenBatch()`, the items are written at once
+	b := NewEthDatabase(st).NewBatch()
+	require.NoError(t, b.Put([]byte("k1"), []byte("v1")))
+	require.NoError(t, b.Put([]byte("k2"), []byte("v2")))
+	require.NoError(t, b.Write())
+
+	db := NewEthDatabase(st)
+	for _, k := range []string{"k1", "k2"} {
+		found, err := db.Has([]byte(k))
+		require.NoError(t, err)
+		require.True(t, found)
+	}
+
+	// with `OpenBatch()`, the items are written when it is committed
+	bs, err := st.OpenBatch()
+	require.NoError(t, err)
+
+	b = NewEthDatabase(bs).NewBatch()
+	require.NoError(t, b.Put([]byte("k3"), []byte("v3")))
+	require.NoError(t, b.Write())
+
+	found, err := db.Has([]byte("k3"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, bs.Commit())
+	found, err = db.Has([]byte("k3"))
+	require.NoError(t, err)
+	require.True(t, found)
+}
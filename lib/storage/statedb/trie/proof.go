@@ -0,0 +1,21 @@
+package trie
+
+// proofList collects the proof nodes by the order from the root to the leaf.
+type proofList [][]byte
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, append([]byte{}, value...))
+	return nil
+}
+
+// Prove returns the encoded trie nodes on the path from the root to `key`;
+// they are verified by `proof.VerifyProof` in lib/trie/proof.
+func (t *Trie) Prove(key []byte) (proof [][]byte, err error) {
+	var l proofList
+	if err = t.Trie.Prove(key, 0, &l); err != nil {
+		return
+	}
+
+	proof = l
+	return
+}
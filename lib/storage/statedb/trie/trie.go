@@ -27,3 +27,9 @@ func NewTrie(root common.Hash, db *EthDatabase) *Trie {
 func (t *Trie) CommitDB(root common.Hash) (err error) {
 	return t.DB.Commit(root, false)
 }
+
+// NewIterator returns the iterator over the leaves of trie in the order of
+// key.
+func (t *Trie) NewIterator() *trie.Iterator {
+	return trie.NewIterator(t.NodeIterator(nil))
+}
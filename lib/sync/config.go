@@ -7,6 +7,7 @@ import (
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/inconshreveable/log15"
 )
 
@@ -15,6 +16,11 @@ const (
 	FetchTimeout                    = 1 * time.Minute
 	RetryInterval                   = 10 * time.Second
 	CheckBlockHeightInterval        = 30 * time.Second
+	WatchInterval                   = 5 * time.Second
+	SyncHeaderPeers                 = 3
+	SyncHeaderBatchSize      uint64 = 100
+	SyncBodyRangeSize        uint64 = 10
+	MaxNodeItemBodySize             = 64 * 1024 * 1024 // max size of the single node item, like the snapshot chunk
 )
 
 type Config struct {
@@ -30,6 +36,11 @@ type Config struct {
 	FetchTimeout             time.Duration
 	RetryInterval            time.Duration
 	CheckBlockHeightInterval time.Duration
+	WatchInterval            time.Duration
+	SyncSnapshot             bool
+	HeaderPeers              int
+	HeaderBatchSize          uint64
+	BodyRangeSize            uint64
 }
 
 func NewConfig(networkID []byte,
@@ -51,16 +62,26 @@ func NewConfig(networkID []byte,
 		FetchTimeout:             FetchTimeout,
 		RetryInterval:            RetryInterval,
 		CheckBlockHeightInterval: CheckBlockHeightInterval,
+		WatchInterval:            WatchInterval,
+		HeaderPeers:              SyncHeaderPeers,
+		HeaderBatchSize:          SyncHeaderBatchSize,
+		BodyRangeSize:            SyncBodyRangeSize,
 	}
 	return c
 }
 
-func (c *Config) NewSyncer() *Syncer {
-	s := NewSyncer(c.storage, c.network, c.connectionManager, c.networkID, c.localNode, c.commonCfg, func(s *Syncer) {
+// NewSyncer makes `Syncer`; the blocks and the snapshot are accepted only
+// when they are agreed by the threshold of `policy`.
+func (c *Config) NewSyncer(policy voting.ThresholdPolicy) *Syncer {
+	s := NewSyncer(c.storage, c.network, c.connectionManager, c.networkID, c.localNode, policy, c.commonCfg, func(s *Syncer) {
 		s.poolSize = c.SyncPoolSize
 		s.fetchTimeout = c.FetchTimeout
 		s.retryInterval = c.RetryInterval
 		s.checkInterval = c.CheckBlockHeightInterval
+		s.snapshot = c.SyncSnapshot
+		s.headerPeers = c.HeaderPeers
+		s.headerBatchSize = c.HeaderBatchSize
+		s.bodyRangeSize = c.BodyRangeSize
 		s.logger = c.logger
 	})
 
@@ -68,11 +89,26 @@ func (c *Config) NewSyncer() *Syncer {
 	return s
 }
 
+// NewWatcher makes `Watcher` for the watcher node; `syncer` syncs the blocks
+// to the height, which `Watcher` found.
+func (c *Config) NewWatcher(syncer SyncController, policy voting.ThresholdPolicy) *Watcher {
+	return NewWatcher(c.connectionManager, c.localNode, policy, syncer, func(w *Watcher) {
+		w.fetchTimeout = c.FetchTimeout
+		w.checkInterval = c.WatchInterval
+		w.logger = c.logger
+	})
+}
+
 func (c *Config) LoggingConfig() {
 	c.logger.Info("syncer config",
 		"poolSize", c.SyncPoolSize,
 		"fetchTimeout", c.FetchTimeout,
 		"retryInterval", c.RetryInterval,
 		"checkInterval", c.CheckBlockHeightInterval,
+		"watchInterval", c.WatchInterval,
+		"syncSnapshot", c.SyncSnapshot,
+		"headerPeers", c.HeaderPeers,
+		"headerBatchSize", c.HeaderBatchSize,
+		"bodyRangeSize", c.BodyRangeSize,
 	)
 }
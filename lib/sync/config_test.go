@@ -28,7 +28,7 @@ func TestNewConfig(t *testing.T) {
 	cfg.SyncPoolSize = 100
 	cfg.logger = common.NopLogger()
 
-	syncer := cfg.NewSyncer()
+	syncer := cfg.NewSyncer(newTestPolicy(1))
 
 	require.NotNil(t, syncer)
 	require.Equal(t, syncer.poolSize, cfg.SyncPoolSize)
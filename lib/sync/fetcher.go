@@ -31,8 +31,7 @@ type BlockFetcher struct {
 	storage           *storage.LevelDBBackend
 	localNode         *node.LocalNode
 
-	fetchTimeout  time.Duration
-	retryInterval time.Duration
+	fetchTimeout time.Duration
 
 	logger log15.Logger
 }
@@ -52,8 +51,7 @@ func NewBlockFetcher(nw network.Network,
 		localNode:         localNode,
 		logger:            common.NopLogger(),
 
-		fetchTimeout:  1 * time.Minute,
-		retryInterval: 30 * time.Second,
+		fetchTimeout: 1 * time.Minute,
 	}
 
 	for _, opt := range opts {
@@ -70,125 +68,74 @@ func NewBlockFetcher(nw network.Network,
 	return f
 }
 
-func (f *BlockFetcher) Fetch(ctx context.Context, syncInfo *SyncInfo) (*SyncInfo, error) {
-	height := syncInfo.Height
-
-	TryForever(func(attempt int) (bool, error) {
-		select {
-		case <-ctx.Done():
-			return false, ctx.Err()
-		default:
-			f.logger.Debug("Try to fetch", "height", height, "attempt", attempt)
-			if err := f.fetch(ctx, syncInfo); err != nil {
-				if err == context.Canceled {
-					return false, ctx.Err()
-				}
-
-				f.logger.Error(err.Error(), "err", err)
-				c := time.After(f.retryInterval) //afterFunc?
-				select {
-				case <-ctx.Done():
-					return false, ctx.Err()
-				case <-c:
-					return true, err
-				}
-			}
-			return false, nil
-		}
-	})
+// FetchHeaders fetches the blocks of [from, to] without the transactions
+// from the node of `nodeAddr`; the blocks are not verified yet.
+func (f *BlockFetcher) FetchHeaders(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+	n := f.connectionManager.GetNode(nodeAddr)
+	if n == nil {
+		return nil, errors.New("FetchHeaders: node not found")
+	}
 
-	return syncInfo, nil
-}
+	sis, err := f.fetchRange(ctx, n, from, to, runner.GetBlocksOptionsModeBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]block.Block, len(sis))
+	for i, si := range sis {
+		blocks[i] = *si.Block
+	}
 
-func (f *BlockFetcher) fetch(ctx context.Context, si *SyncInfo) error {
-	var (
-		height    = si.Height
-		nodeAddrs = si.NodeAddrs
-	)
-	f.logger.Debug("Fetch start", "height", height)
+	return blocks, nil
+}
 
-	n := f.pickRandomNode(nodeAddrs)
+// FetchBodies fetches the blocks of [from, to] with the transactions from the
+// node of `nodeAddr`.
+func (f *BlockFetcher) FetchBodies(ctx context.Context, nodeAddr string, from, to uint64) ([]*SyncInfo, error) {
+	n := f.connectionManager.GetNode(nodeAddr)
 	if n == nil {
-		return errors.New("Fetch: node not found")
+		return nil, errors.New("FetchBodies: node not found")
 	}
-	f.logger.Debug(fmt.Sprintf("fetching items from node: %v", n), "fetching_node", n, "height", height)
 
-	apiURL := apiClientURL(n, height)
+	return f.fetchRange(ctx, n, from, to, runner.GetBlocksOptionsModeFull)
+}
+
+func (f *BlockFetcher) fetchRange(ctx context.Context, n node.Node, from, to uint64, mode runner.GetBlocksOptionsMode) ([]*SyncInfo, error) {
+	apiURL := apiClientURL(n, from, to, mode)
 	f.logger.Debug("apiClient", "url", apiURL.String())
 
 	req, err := http.NewRequest("GET", apiURL.String(), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	resp, err := f.apiClient.Do(req)
+	resp, err := f.apiClient.Do(req.WithContext(ctx))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return errors.New("fetch: block not found")
+		return nil, errors.New("fetch: block not found")
 	}
 
-	items, err := f.unmarshalResp(resp.Body)
+	sis, err := f.unmarshalResp(resp.Body, mode == runner.GetBlocksOptionsModeFull)
 	if err != nil {
-		return err
-	}
-
-	f.logger.Debug("fetch get items", "items", len(items), "height", height)
-
-	blocks, ok := items[runner.NodeItemBlock]
-	if !ok || len(blocks) <= 0 {
-		err := errors.New("fetch: block not found in response")
-		return err
-	}
-
-	bts, ok := items[runner.NodeItemBlockTransaction]
-	if !ok {
-		err := errors.New("fetch: block transactions not found in response")
-		return err
+		return nil, err
 	}
 
-	blk := blocks[0].(block.Block)
-	si.Block = &blk
-
-	txmap := make(map[string]*transaction.Transaction) // For ordering txs by block.Transactions
-
-	for _, bt := range bts {
-		bt, ok := bt.(block.BlockTransaction)
-		if !ok {
-			return errors.InvalidTransaction
-		}
+	f.logger.Debug("fetch get blocks", "blocks", len(sis), "from", from, "to", to)
 
-		var tx transaction.Transaction
-		if err := json.Unmarshal(bt.Message, &tx); err != nil {
-			return err
-		}
-		txmap[bt.Hash] = &tx
+	if uint64(len(sis)) != to-from+1 {
+		return nil, errors.New("fetch: block not found in response")
 	}
-
-	for _, hash := range blk.Transactions {
-		tx, ok := txmap[hash]
-		if !ok {
-			//TODO(anarcher): Error type for controlling timeout
-			err := fmt.Errorf("Tx: %s not found in block height %d", hash, height)
-			return err
+	for i, si := range sis {
+		if si.Height != from+uint64(i) {
+			return nil, fmt.Errorf("fetch: unexpected block height %d", si.Height)
 		}
-		si.Txs = append(si.Txs, tx)
 	}
 
-	if blk.ProposerTransaction != "" {
-		if tx, ok := txmap[blk.ProposerTransaction]; ok {
-			ptx := &ballot.ProposerTransaction{Transaction: *tx}
-			si.Ptx = ptx
-		} else {
-			err := fmt.Errorf("proposer transactions (%v) not found in transactions", blk.ProposerTransaction)
-			return err
-		}
-	}
-
-	return nil
+	return sis, nil
 }
 
 // pickRandomNode choose one node by random. It is very protype for choosing fetching which node
@@ -235,8 +182,18 @@ func (f *BlockFetcher) existsBlockHeight(height uint64) bool {
 	return exists
 }
 
-func (f *BlockFetcher) unmarshalResp(body io.ReadCloser) (map[runner.NodeItemDataType][]interface{}, error) {
-	items := map[runner.NodeItemDataType][]interface{}{}
+// unmarshalResp reads the blocks in the order of response; in full mode, the
+// transactions of block follow the block.
+func (f *BlockFetcher) unmarshalResp(body io.ReadCloser, full bool) (sis []*SyncInfo, err error) {
+	var si *SyncInfo
+	var txmap map[string]*transaction.Transaction // For ordering txs by block.Transactions
+
+	finish := func() error {
+		if si == nil || !full {
+			return nil
+		}
+		return fillSyncInfoTxs(si, txmap)
+	}
 
 	sc := bufio.NewScanner(body)
 	for sc.Scan() {
@@ -244,22 +201,73 @@ func (f *BlockFetcher) unmarshalResp(body io.ReadCloser) (map[runner.NodeItemDat
 		if err != nil {
 			return nil, err
 		}
-		items[itemType] = append(items[itemType], b)
+
+		switch itemType {
+		case runner.NodeItemBlock:
+			if err := finish(); err != nil {
+				return nil, err
+			}
+
+			blk := b.(block.Block)
+			si = &SyncInfo{Height: blk.Height, Block: &blk}
+			txmap = make(map[string]*transaction.Transaction)
+			sis = append(sis, si)
+		case runner.NodeItemBlockTransaction:
+			bt, ok := b.(block.BlockTransaction)
+			if !ok || si == nil {
+				return nil, errors.InvalidTransaction
+			}
+
+			var tx transaction.Transaction
+			if err := json.Unmarshal(bt.Message, &tx); err != nil {
+				return nil, err
+			}
+			txmap[bt.Hash] = &tx
+		}
 	}
 	if err := sc.Err(); err != nil {
 		return nil, err
 	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
 
-	return items, nil
+	return sis, nil
+}
+
+func fillSyncInfoTxs(si *SyncInfo, txmap map[string]*transaction.Transaction) error {
+	blk := si.Block
+	for _, hash := range blk.Transactions {
+		tx, ok := txmap[hash]
+		if !ok {
+			//TODO(anarcher): Error type for controlling timeout
+			err := fmt.Errorf("Tx: %s not found in block height %d", hash, blk.Height)
+			return err
+		}
+		si.Txs = append(si.Txs, tx)
+	}
+
+	if blk.ProposerTransaction != "" {
+		if tx, ok := txmap[blk.ProposerTransaction]; ok {
+			ptx := &ballot.ProposerTransaction{Transaction: *tx}
+			si.Ptx = ptx
+		} else {
+			err := fmt.Errorf("proposer transactions (%v) not found in transactions", blk.ProposerTransaction)
+			return err
+		}
+	}
+
+	return nil
 }
 
-func apiClientURL(n node.Node, height uint64) *url.URL {
+func apiClientURL(n node.Node, from, to uint64, mode runner.GetBlocksOptionsMode) *url.URL {
 	ep := n.Endpoint()
 	u := url.URL(*ep)
 	u.Path = network.UrlPathPrefixNode + runner.GetBlocksPattern
 	q := u.Query()
-	q.Set("height-range", fmt.Sprintf("%d-%d", height, height+1))
-	q.Set("mode", "full")
+	q.Set("height-range", fmt.Sprintf("%d-%d", from, to+1))
+	q.Set("limit", fmt.Sprintf("%d", to-from+1))
+	q.Set("mode", string(mode))
 	u.RawQuery = q.Encode()
 
 	return &u
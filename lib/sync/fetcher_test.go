@@ -3,9 +3,12 @@ package sync
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
@@ -70,8 +73,67 @@ func TestBlockFetcher(t *testing.T) {
 	//f.logger = log
 
 	ctx := context.Background()
-	si, err := f.Fetch(ctx, &SyncInfo{Height: 1})
+	sis, err := f.FetchBodies(ctx, kp.Address(), 1, 1)
 	require.NoError(t, err)
+	require.Equal(t, 1, len(sis))
+	si := sis[0]
 	require.Equal(t, bk.Hash, si.Block.Hash)
 	require.Equal(t, bk.TransactionsRoot, si.Block.TransactionsRoot)
 }
+
+// TestBlockFetcherFromMutualTLSValidator checks the watcher, which does not
+// have the certificate, can sync from the validator in mutual TLS.
+func TestBlockFetcherFromMutualTLSValidator(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	bk := block.GetLatestBlock(st)
+
+	kpValidator, _ := keypair.Random()
+	g := network.NewKeyGeneratorWithKeypair("tls_tmp", "validator.cert", "validator.key", kpValidator)
+	defer g.Close()
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	query := url.Values{}
+	query.Set("TLSCertFile", g.GetCertPath())
+	query.Set("TLSKeyFile", g.GetKeyPath())
+	query.Set("MutualTLS", "true")
+	endpoint := &common.Endpoint{Scheme: "https", Host: addr, RawQuery: query.Encode()}
+
+	config, err := network.NewHTTP2NetworkConfigFromEndpoint("validator", endpoint)
+	require.NoError(t, err)
+	server := network.NewHTTP2Network(config)
+	server.AddHandler(network.UrlPathPrefixNode+runner.GetBlocksPattern, func(w http.ResponseWriter, r *http.Request) {
+		renderNodeItem(w, runner.NodeItemBlock, bk)
+	})
+	require.NoError(t, server.Ready())
+	go server.Start()
+	defer server.Stop()
+
+	for i := 0; i < 50; i++ {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	_, nw, watcher := network.CreateMemoryNetwork(nil)
+	watcher.SetWatcher()
+	cm := &mockConnectionManager{
+		allConnected: []string{kpValidator.Address()},
+		getNodeFunc: func(string) node.Node {
+			v, _ := node.NewValidator(kpValidator.Address(), endpoint, "validator")
+			return v
+		},
+	}
+
+	f := NewBlockFetcher(nw, cm, st, watcher)
+	headers, err := f.FetchHeaders(context.Background(), kpValidator.Address(), bk.Height, bk.Height)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(headers))
+	require.Equal(t, bk.Hash, headers[0].Hash)
+}
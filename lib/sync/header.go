@@ -0,0 +1,259 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// verifyHeaderChain checks `headers` are the blocks of [from, to] and each
+// block is linked to the previous one by `PrevBlockHash`, starting from
+// `prev`.
+func verifyHeaderChain(prev block.Block, headers []block.Block, from, to uint64) error {
+	if uint64(len(headers)) != to-from+1 {
+		return errors.BlockHeaderChainNotLinked
+	}
+
+	for i, h := range headers {
+		if h.Height != from+uint64(i) {
+			return errors.BlockHeaderChainNotLinked
+		}
+		if h.PrevBlockHash != prev.Hash {
+			return errors.BlockHeaderChainNotLinked
+		}
+		if err := h.IsWellFormed(); err != nil {
+			return err
+		}
+		prev = h
+	}
+
+	return nil
+}
+
+// peers returns the connected nodes in `nodeAddrs`; without `nodeAddrs`, all
+// the connected nodes are returned.
+func (s *Syncer) peers(nodeAddrs []string) []string {
+	var nodeMap = make(map[string]struct{})
+	for _, addr := range nodeAddrs {
+		nodeMap[addr] = struct{}{}
+	}
+
+	var peers []string
+	for _, addr := range s.connectionManager.AllConnected() {
+		if addr == s.localNode.Address() {
+			continue
+		}
+		if len(nodeAddrs) > 0 {
+			if _, ok := nodeMap[addr]; !ok {
+				continue
+			}
+		}
+		peers = append(peers, addr)
+	}
+
+	return peers
+}
+
+// fetchHeaderChain fetches the headers of [from, to] from the `headerPeers`
+// peers at once; at least the threshold of peers are asked. The headers are
+// accepted only when the majority of the asked peers and the threshold of
+// peers return the same chain linked to `prev`; the peers, which agreed, are
+// returned for fetching the block bodies.
+func (s *Syncer) fetchHeaderChain(prev block.Block, from, to uint64, nodeAddrs []string) ([]block.Block, []string, error) {
+	peers := s.peers(nodeAddrs)
+	if len(peers) < 1 {
+		return nil, nil, errors.New("fetchHeaderChain: node not found")
+	}
+
+	threshold := s.policy.Threshold()
+	if len(peers) < threshold {
+		s.logger.Error("not enough peers to agree headers", "from", from, "to", to, "peers", len(peers), "threshold", threshold)
+		return nil, nil, errors.BlockHeaderChainNotAgreed
+	}
+
+	numPeers := s.headerPeers
+	if numPeers < threshold {
+		numPeers = threshold
+	}
+
+	rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+	if len(peers) > numPeers {
+		peers = peers[:numPeers]
+	}
+
+	type result struct {
+		addr    string
+		headers []block.Block
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []result
+	)
+	for _, addr := range peers {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+
+			headers, err := s.fetcher.FetchHeaders(s.ctx, addr, from, to)
+			if err == nil {
+				err = verifyHeaderChain(prev, headers, from, to)
+			}
+			if err != nil {
+				s.logger.Error("failed to fetch headers", "node", addr, "from", from, "to", to, "err", err)
+				s.fetchFailed(addr, err)
+				return
+			}
+
+			mu.Lock()
+			results = append(results, result{addr: addr, headers: headers})
+			mu.Unlock()
+		}(addr)
+	}
+	wg.Wait()
+
+	// The chains, which are linked to `prev`, are the same if the last blocks
+	// are the same.
+	agreed := make(map[string][]string)
+	for _, r := range results {
+		hash := r.headers[len(r.headers)-1].Hash
+		agreed[hash] = append(agreed[hash], r.addr)
+	}
+
+	for _, r := range results {
+		addrs := agreed[r.headers[len(r.headers)-1].Hash]
+		if len(addrs) >= threshold && len(addrs)*2 > len(peers) {
+			return r.headers, addrs, nil
+		}
+	}
+
+	s.logger.Error("headers are not agreed", "from", from, "to", to, "peers", len(peers), "chains", len(agreed), "threshold", threshold)
+
+	return nil, nil, errors.BlockHeaderChainNotAgreed
+}
+
+// fetchBodies fetches the bodies of `headers` by the ranges of
+// `bodyRangeSize` from `peers` in parallel, and commits them in order; the
+// height of the committed block is sent to `heightc`.
+func (s *Syncer) fetchBodies(headers []block.Block, peers []string, heightc chan<- uint64) error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	type result struct {
+		sis []*SyncInfo
+		err error
+	}
+
+	var results []chan result
+	for i := 0; i < len(headers); i += int(s.bodyRangeSize) {
+		end := i + int(s.bodyRangeSize)
+		if end > len(headers) {
+			end = len(headers)
+		}
+
+		var (
+			rangeHeaders = headers[i:end]
+			offset       = len(results)
+			c            = make(chan result, 1)
+		)
+		results = append(results, c)
+
+		go func() {
+			err := s.workPool.Add(ctx, func() {
+				sis, err := s.fetchBodyRange(ctx, rangeHeaders, peers, offset)
+				c <- result{sis: sis, err: err}
+			})
+			if err != nil {
+				c <- result{err: err}
+			}
+		}()
+	}
+
+	for _, c := range results {
+		var r result
+		select {
+		case r = <-c:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if r.err != nil {
+			return r.err
+		}
+
+		for _, si := range r.sis {
+			if err := s.validator.Validate(ctx, si); err != nil {
+				s.logger.Error("validate failure", "err", err, "height", si.Height)
+				return err
+			}
+			s.logger.Info("done sync work", "height", si.Height, "hash", si.Block.Hash)
+
+			select {
+			case heightc <- si.Height:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// fetchBodyRange fetches the bodies of `headers` from one of `peers`; the
+// peer is changed when it fails, so the different ranges are fetched from
+// the different peers by `offset`.
+func (s *Syncer) fetchBodyRange(ctx context.Context, headers []block.Block, peers []string, offset int) (sis []*SyncInfo, err error) {
+	var (
+		from = headers[0].Height
+		to   = headers[len(headers)-1].Height
+	)
+
+	err = Try(MaxRetries, func(attempt int) (bool, error) {
+		addr := peers[(offset+attempt-1)%len(peers)]
+
+		var err error
+		if sis, err = s.fetcher.FetchBodies(ctx, addr, from, to); err == nil {
+			err = matchHeaders(sis, headers)
+		}
+		if err == nil {
+			for _, si := range sis {
+				si.NodeAddrs = peers
+			}
+			return false, nil
+		}
+		s.logger.Error("fetch failure", "node", addr, "from", from, "to", to, "attempt", attempt, "err", err)
+		s.fetchFailed(addr, err)
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(s.retryInterval):
+			return true, err
+		}
+	})
+
+	return
+}
+
+// matchHeaders checks the fetched blocks are the same with the verified
+// headers; the verified header is used instead of the fetched one.
+func matchHeaders(sis []*SyncInfo, headers []block.Block) error {
+	if len(sis) != len(headers) {
+		return fmt.Errorf("expected %d blocks, but got %d", len(headers), len(sis))
+	}
+
+	for i, si := range sis {
+		if si.Block == nil || si.Block.Hash != headers[i].Hash {
+			return errors.HashDoesNotMatch
+		}
+		header := headers[i]
+		si.Block = &header
+	}
+
+	return nil
+}
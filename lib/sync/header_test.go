@@ -0,0 +1,208 @@
+package sync
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// testChain is the chain of blocks; the first block is the block, which the
+// chain starts from.
+type testChain []block.Block
+
+func makeTestChain(start block.Block, n int) testChain {
+	chain := testChain{start}
+	for i := 0; i < n; i++ {
+		chain = append(chain, block.TestMakeNewBlockWithPrevBlock(chain[len(chain)-1], nil))
+	}
+	return chain
+}
+
+func (c testChain) headers(from, to uint64) []block.Block {
+	start := c[0].Height
+	return append([]block.Block{}, c[from-start:to-start+1]...)
+}
+
+func (c testChain) bodies(from, to uint64) (sis []*SyncInfo) {
+	for _, h := range c.headers(from, to) {
+		blk := h
+		sis = append(sis, &SyncInfo{Height: blk.Height, Block: &blk})
+	}
+	return
+}
+
+func newHeaderTestSyncer(peers ...string) (*storage.LevelDBBackend, *Syncer) {
+	st, syncer := newSnapshotTestSyncer()
+	syncer.connectionManager = &mockConnectionManager{allConnected: peers}
+	syncer.retryInterval = time.Millisecond
+	return st, syncer
+}
+
+func TestVerifyHeaderChain(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	genesis := block.GetLatestBlock(st)
+	chain := makeTestChain(genesis, 5)
+	fork := makeTestChain(genesis, 5)
+
+	require.NoError(t, verifyHeaderChain(genesis, chain.headers(2, 6), 2, 6))
+
+	// missing block
+	require.Equal(t, errors.BlockHeaderChainNotLinked, verifyHeaderChain(genesis, chain.headers(2, 5), 2, 6))
+
+	// not linked to the previous block
+	headers := chain.headers(2, 6)
+	headers[2] = fork[3]
+	require.Equal(t, errors.BlockHeaderChainNotLinked, verifyHeaderChain(genesis, headers, 2, 6))
+
+	// modified block
+	headers = chain.headers(2, 6)
+	headers[4].StateRoot = "modified"
+	require.Equal(t, errors.HashDoesNotMatch, verifyHeaderChain(genesis, headers, 2, 6))
+}
+
+func TestSyncerFetchHeaderChain(t *testing.T) {
+	st, syncer := newHeaderTestSyncer("a", "b", "c")
+	defer st.Close()
+
+	genesis := block.GetLatestBlock(st)
+	chain := makeTestChain(genesis, 5)
+	forks := map[string]testChain{
+		"b": makeTestChain(genesis, 5),
+		"c": makeTestChain(genesis, 5),
+	}
+
+	// only "c" returns the forked chain
+	syncer.fetcher = &mockFetcher{
+		fetchHeadersFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+			if nodeAddr == "c" {
+				return forks["c"].headers(from, to), nil
+			}
+			return chain.headers(from, to), nil
+		},
+	}
+
+	headers, peers, err := syncer.fetchHeaderChain(genesis, 2, 6, nil)
+	require.NoError(t, err)
+	require.Equal(t, chain[5].Hash, headers[4].Hash)
+	require.ElementsMatch(t, []string{"a", "b"}, peers)
+
+	// every peer returns the different chain
+	syncer.fetcher = &mockFetcher{
+		fetchHeadersFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+			if fork, ok := forks[nodeAddr]; ok {
+				return fork.headers(from, to), nil
+			}
+			return chain.headers(from, to), nil
+		},
+	}
+
+	_, _, err = syncer.fetchHeaderChain(genesis, 2, 6, nil)
+	require.Equal(t, errors.BlockHeaderChainNotAgreed, err)
+
+	// the peer, which is not in `nodeAddrs`, is not asked
+	headers, peers, err = syncer.fetchHeaderChain(genesis, 2, 6, []string{"a"})
+	require.NoError(t, err)
+	require.Equal(t, chain[5].Hash, headers[4].Hash)
+	require.Equal(t, []string{"a"}, peers)
+}
+
+func TestSyncerFetchHeaderChainThreshold(t *testing.T) {
+	st, syncer := newHeaderTestSyncer("a", "b", "c", "d")
+	defer st.Close()
+
+	genesis := block.GetLatestBlock(st)
+	chain := makeTestChain(genesis, 5)
+	fork := makeTestChain(genesis, 5)
+
+	var asked int32
+	syncer.fetcher = &mockFetcher{
+		fetchHeadersFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+			atomic.AddInt32(&asked, 1)
+			if nodeAddr == "c" {
+				return fork.headers(from, to), nil
+			}
+			return chain.headers(from, to), nil
+		},
+	}
+
+	// the threshold is 2, but only "a" is allowed
+	syncer.policy = newTestPolicy(2)
+	_, _, err := syncer.fetchHeaderChain(genesis, 2, 6, []string{"a"})
+	require.Equal(t, errors.BlockHeaderChainNotAgreed, err)
+	require.Equal(t, int32(0), atomic.LoadInt32(&asked))
+
+	// the majority of "a", "b" and "c" agrees, but the threshold is 3
+	syncer.policy = newTestPolicy(4)
+	_, _, err = syncer.fetchHeaderChain(genesis, 2, 6, []string{"a", "b", "c"})
+	require.Equal(t, errors.BlockHeaderChainNotAgreed, err)
+
+	// the threshold, 4 is larger than `headerPeers`, so every peer is asked
+	syncer.policy = newTestPolicy(5)
+	syncer.fetcher = &mockFetcher{
+		fetchHeadersFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+			return chain.headers(from, to), nil
+		},
+	}
+	headers, peers, err := syncer.fetchHeaderChain(genesis, 2, 6, nil)
+	require.NoError(t, err)
+	require.Equal(t, chain[5].Hash, headers[4].Hash)
+	require.ElementsMatch(t, []string{"a", "b", "c", "d"}, peers)
+}
+
+func TestSyncerFetchBodies(t *testing.T) {
+	st, syncer := newHeaderTestSyncer("a", "b")
+	defer st.Close()
+
+	syncer.bodyRangeSize = 3
+	syncer.workPool = NewPool(syncer.poolSize)
+	defer syncer.workPool.Finish()
+
+	genesis := block.GetLatestBlock(st)
+	chain := makeTestChain(genesis, 10)
+	fork := makeTestChain(genesis, 10)
+
+	// "b" returns the bodies of the forked chain, so the bodies are fetched
+	// from "a" again
+	syncer.fetcher = &mockFetcher{
+		fetchBodiesFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]*SyncInfo, error) {
+			if nodeAddr == "b" {
+				return fork.bodies(from, to), nil
+			}
+			time.Sleep(time.Duration(11-from) * time.Millisecond) // later ranges are fetched first
+			return chain.bodies(from, to), nil
+		},
+	}
+
+	var validated []uint64
+	syncer.validator = &mockValidator{
+		validateFunc: func(ctx context.Context, si *SyncInfo) error {
+			require.Equal(t, chain[si.Height-genesis.Height].Hash, si.Block.Hash)
+			validated = append(validated, si.Height)
+			return nil
+		},
+	}
+
+	heightc := make(chan uint64, 10)
+	require.NoError(t, syncer.fetchBodies(chain.headers(2, 11), []string{"a", "b"}, heightc))
+	close(heightc)
+
+	var heights []uint64
+	for height := range heightc {
+		heights = append(heights, height)
+	}
+	require.Equal(t, []uint64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, validated)
+	require.Equal(t, validated, heights)
+
+	failures := syncer.fetchFailures()
+	require.Equal(t, uint64(0), failures["a"])
+	require.True(t, failures["b"] > 0)
+}
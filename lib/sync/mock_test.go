@@ -6,9 +6,13 @@ import (
 	"net"
 	"net/http"
 
+	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
 )
 
 type mockConnectionManager struct {
@@ -23,7 +27,9 @@ func (m *mockConnectionManager) GetNodeAddress() string {
 
 func (m *mockConnectionManager) ConnectionWatcher(network.Network, net.Conn, http.ConnState) {}
 func (m *mockConnectionManager) Broadcast(common.Message)                                    {}
+func (m *mockConnectionManager) Relay(common.Message)                                        {}
 func (m *mockConnectionManager) Start()                                                      {}
+func (m *mockConnectionManager) UpdateValidators()                                           {}
 
 func (m *mockConnectionManager) GetConnection(string) network.NetworkClient {
 	return nil
@@ -57,11 +63,16 @@ func (d mockDoer) Do(req *http.Request) (*http.Response, error) {
 }
 
 type mockFetcher struct {
-	fetchFunc func(context.Context, *SyncInfo) (*SyncInfo, error)
+	fetchHeadersFunc func(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error)
+	fetchBodiesFunc  func(ctx context.Context, nodeAddr string, from, to uint64) ([]*SyncInfo, error)
 }
 
-func (f mockFetcher) Fetch(ctx context.Context, si *SyncInfo) (*SyncInfo, error) {
-	return f.fetchFunc(ctx, si)
+func (f mockFetcher) FetchHeaders(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+	return f.fetchHeadersFunc(ctx, nodeAddr, from, to)
+}
+
+func (f mockFetcher) FetchBodies(ctx context.Context, nodeAddr string, from, to uint64) ([]*SyncInfo, error) {
+	return f.fetchBodiesFunc(ctx, nodeAddr, from, to)
 }
 
 type mockValidator struct {
@@ -71,3 +82,33 @@ type mockValidator struct {
 func (v mockValidator) Validate(ctx context.Context, si *SyncInfo) error {
 	return v.validateFunc(ctx, si)
 }
+
+type mockSnapshotFetcher struct {
+	st *storage.LevelDBBackend
+	n  node.Node
+
+	fetchSnapshotByHeightFunc func(ctx context.Context, nodeAddr string, height uint64) (block.Snapshot, error)
+}
+
+func (f mockSnapshotFetcher) FetchSnapshot(ctx context.Context, nodeAddrs []string) (block.Snapshot, node.Node, error) {
+	s, err := block.GetLatestSnapshot(f.st)
+	return s, f.n, err
+}
+
+func (f mockSnapshotFetcher) FetchSnapshotByHeight(ctx context.Context, nodeAddr string, height uint64) (block.Snapshot, error) {
+	if f.fetchSnapshotByHeightFunc == nil {
+		return block.Snapshot{}, errors.New("snapshot not found")
+	}
+	return f.fetchSnapshotByHeightFunc(ctx, nodeAddr, height)
+}
+
+func (f mockSnapshotFetcher) FetchSnapshotChunk(ctx context.Context, n node.Node, height, index uint64) (block.SnapshotChunk, error) {
+	return block.GetSnapshotChunk(f.st, height, index)
+}
+
+// newTestPolicy makes the threshold policy of 67% for `validators`.
+func newTestPolicy(validators int) voting.ThresholdPolicy {
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(67)
+	policy.SetValidators(validators)
+	return policy
+}
@@ -0,0 +1,83 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"boscoin.io/sebak/lib/metrics"
+	"boscoin.io/sebak/lib/node"
+)
+
+// progressRate measures the speed of the current sync from the height and
+// the time when the sync started.
+type progressRate struct {
+	started time.Time
+	height  uint64
+}
+
+func newProgressRate(height uint64) progressRate {
+	return progressRate{started: time.Now(), height: height}
+}
+
+// update sets the speed and ETA of `p` by the current block.
+func (r progressRate) update(p *SyncProgress) {
+	p.BlocksPerSecond = 0
+	p.ETA = 0
+
+	elapsed := time.Since(r.started).Seconds()
+	if elapsed > 0 && p.CurrentBlock > r.height {
+		p.BlocksPerSecond = float64(p.CurrentBlock-r.height) / elapsed
+	}
+	if p.BlocksPerSecond > 0 && p.HighestBlock > p.CurrentBlock {
+		p.ETA = time.Duration(float64(p.HighestBlock-p.CurrentBlock) / p.BlocksPerSecond * float64(time.Second))
+	}
+
+	metrics.SyncCurrentBlock.Set(float64(p.CurrentBlock))
+	metrics.SyncHighestBlock.Set(float64(p.HighestBlock))
+	metrics.SyncBlocksPerSecond.Set(p.BlocksPerSecond)
+	metrics.SyncETASeconds.Set(p.ETA.Seconds())
+}
+
+// fetchFailed counts the failure to fetch from the peer of `addr`.
+func (s *Syncer) fetchFailed(addr string, err error) {
+	if err == context.Canceled {
+		return
+	}
+
+	s.failuresLock.Lock()
+	s.failures[addr]++
+	s.failuresLock.Unlock()
+
+	metrics.SyncFetchFailures.WithLabelValues(addr).Inc()
+}
+
+func (s *Syncer) fetchFailures() map[string]uint64 {
+	s.failuresLock.Lock()
+	defer s.failuresLock.Unlock()
+
+	failures := make(map[string]uint64, len(s.failures))
+	for addr, n := range s.failures {
+		failures[addr] = n
+	}
+
+	return failures
+}
+
+// SyncInfo returns the progress of sync for `node.NodeInfo`; it is synced
+// only after the highest block of the peers is known and reached.
+func (s *Syncer) SyncInfo(ctx context.Context) (node.NodeSyncInfo, error) {
+	p, err := s.SyncProgress(ctx)
+	if err != nil {
+		return node.NodeSyncInfo{}, err
+	}
+
+	return node.NodeSyncInfo{
+		Synced:          p.HighestKnown && p.CurrentBlock >= p.HighestBlock,
+		StartingBlock:   p.StartingBlock,
+		CurrentBlock:    p.CurrentBlock,
+		HighestBlock:    p.HighestBlock,
+		BlocksPerSecond: p.BlocksPerSecond,
+		ETA:             p.ETA,
+		FetchFailures:   p.FetchFailures,
+	}, nil
+}
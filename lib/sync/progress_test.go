@@ -0,0 +1,23 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressRate(t *testing.T) {
+	rate := progressRate{started: time.Now().Add(-10 * time.Second), height: 100}
+
+	p := &SyncProgress{StartingBlock: 101, CurrentBlock: 150, HighestBlock: 200}
+	rate.update(p)
+	require.InDelta(t, 5, p.BlocksPerSecond, 0.1)
+	require.InDelta(t, float64(10*time.Second), float64(p.ETA), float64(time.Second))
+
+	// nothing synced yet
+	p = &SyncProgress{StartingBlock: 101, CurrentBlock: 100, HighestBlock: 200}
+	rate.update(p)
+	require.Equal(t, float64(0), p.BlocksPerSecond)
+	require.Equal(t, time.Duration(0), p.ETA)
+}
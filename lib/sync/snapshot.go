@@ -0,0 +1,274 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/node/runner"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// FetchSnapshot fetches the latest snapshot from one of `nodeAddrs`; the
+// chunks of snapshot should be fetched from the returned node.
+func (f *BlockFetcher) FetchSnapshot(ctx context.Context, nodeAddrs []string) (block.Snapshot, node.Node, error) {
+	n := f.pickRandomNode(nodeAddrs)
+	if n == nil {
+		return block.Snapshot{}, nil, errors.New("FetchSnapshot: node not found")
+	}
+
+	item, err := f.getNodeItem(ctx, snapshotURL(n, runner.GetSnapshotPattern, nil))
+	if err != nil {
+		return block.Snapshot{}, nil, err
+	}
+
+	s, ok := item.(block.Snapshot)
+	if !ok {
+		return block.Snapshot{}, nil, errors.InvalidMessage
+	}
+
+	return s, n, nil
+}
+
+// FetchSnapshotByHeight fetches the snapshot of `height` from the node of
+// `nodeAddr`.
+func (f *BlockFetcher) FetchSnapshotByHeight(ctx context.Context, nodeAddr string, height uint64) (block.Snapshot, error) {
+	n := f.connectionManager.GetNode(nodeAddr)
+	if n == nil {
+		return block.Snapshot{}, errors.New("FetchSnapshotByHeight: node not found")
+	}
+
+	query := url.Values{}
+	query.Set("height", strconv.FormatUint(height, 10))
+
+	item, err := f.getNodeItem(ctx, snapshotURL(n, runner.GetSnapshotPattern, query))
+	if err != nil {
+		return block.Snapshot{}, err
+	}
+
+	s, ok := item.(block.Snapshot)
+	if !ok {
+		return block.Snapshot{}, errors.InvalidMessage
+	}
+
+	return s, nil
+}
+
+func (f *BlockFetcher) FetchSnapshotChunk(ctx context.Context, n node.Node, height, index uint64) (block.SnapshotChunk, error) {
+	query := url.Values{}
+	query.Set("height", strconv.FormatUint(height, 10))
+	query.Set("index", strconv.FormatUint(index, 10))
+
+	item, err := f.getNodeItem(ctx, snapshotURL(n, runner.GetSnapshotChunkPattern, query))
+	if err != nil {
+		return block.SnapshotChunk{}, err
+	}
+
+	c, ok := item.(block.SnapshotChunk)
+	if !ok {
+		return block.SnapshotChunk{}, errors.InvalidMessage
+	}
+
+	return c, nil
+}
+
+// getNodeItem requests the single node item; the chunk of snapshot can be
+// larger than the buffer of `bufio.Scanner`, so the body is read at once, but
+// not more than `MaxNodeItemBodySize`.
+func (f *BlockFetcher) getNodeItem(ctx context.Context, u *url.URL) (interface{}, error) {
+	f.logger.Debug("apiClient", "url", u.String())
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.apiClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.StorageRecordDoesNotExist
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get node item: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxNodeItemBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > MaxNodeItemBodySize {
+		return nil, errors.SnapshotTooLarge
+	}
+
+	itemType, item, err := runner.UnmarshalNodeItemResponse(bytes.TrimSpace(body))
+	if err != nil {
+		return nil, err
+	}
+	if itemType == runner.NodeItemError {
+		return nil, item.(*errors.Error)
+	}
+
+	return item, nil
+}
+
+func snapshotURL(n node.Node, pattern string, query url.Values) *url.URL {
+	ep := n.Endpoint()
+	u := url.URL(*ep)
+	u.Path = network.UrlPathPrefixNode + pattern
+	u.RawQuery = query.Encode()
+
+	return &u
+}
+
+// syncSnapshot loads the latest snapshot of the other node instead of
+// replaying the blocks; the snapshot must be signed by one of the validators,
+// the snapshot must be confirmed by the threshold of validators and the
+// accounts of it must match with the state root of the snapshot block.
+// Nothing is stored if the snapshot is not valid. The next blocks are
+// verified by the header chain linked to the snapshot block.
+func (s *Syncer) syncSnapshot(nodeAddrs []string) (err error) {
+	ctx := s.ctx
+
+	var ss block.Snapshot
+	var n node.Node
+	if ss, n, err = s.snapshotFetcher.FetchSnapshot(ctx, nodeAddrs); err != nil {
+		return
+	}
+
+	latestHeight := s.latestBlockHeight()
+	if ss.Height() <= latestHeight {
+		s.logger.Info("snapshot is not newer than the latest block", "snapshot", ss.Height(), "latest", latestHeight)
+		return
+	}
+	if !s.localNode.HasValidators(ss.B.Source) {
+		return errors.SnapshotFromUnknownValidator
+	}
+	if err = ss.IsWellFormed(s.networkID); err != nil {
+		return
+	}
+	if err = s.confirmSnapshot(ss, nodeAddrs); err != nil {
+		return
+	}
+
+	s.logger.Info(
+		"start to load snapshot",
+		"node", n.Address(),
+		"height", ss.Height(),
+		"accounts", ss.B.TotalAccounts,
+		"chunks", len(ss.B.Chunks),
+	)
+
+	var bs *storage.LevelDBBackend
+	if bs, err = s.storage.OpenBatch(); err != nil {
+		return
+	}
+
+	loader := block.NewSnapshotLoader(bs, ss)
+	for !loader.IsFull() {
+		index := loader.Next()
+
+		var c block.SnapshotChunk
+		err = Try(MaxRetries, func(attempt int) (bool, error) {
+			var err error
+			if c, err = s.snapshotFetcher.FetchSnapshotChunk(ctx, n, ss.Height(), index); err == nil {
+				return false, nil
+			}
+			s.logger.Error("failed to fetch snapshot chunk", "index", index, "attempt", attempt, "err", err)
+
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-time.After(s.retryInterval):
+				return true, err
+			}
+		})
+		if err == nil {
+			err = loader.Add(c)
+		}
+		if err != nil {
+			bs.Discard()
+			return
+		}
+		s.logger.Debug("snapshot chunk loaded", "index", index)
+	}
+
+	if err = loader.Finish(); err != nil {
+		bs.Discard()
+		return
+	}
+	if err = bs.Commit(); err != nil {
+		bs.Discard()
+		return
+	}
+
+	s.logger.Info("snapshot loaded", "height", ss.Height(), "hash", ss.B.Block.Hash)
+
+	return
+}
+
+// confirmSnapshot checks the snapshot is the same with the snapshots of the
+// other validators at the same height; the signer of snapshot and the
+// validators, which have the same block, chunks and validator set changes,
+// should reach the threshold. Only the state root of block is checked by the
+// accounts, so the operations in chunks and the validator set changes are
+// trusted by the threshold.
+func (s *Syncer) confirmSnapshot(ss block.Snapshot, nodeAddrs []string) error {
+	threshold := s.policy.Threshold()
+	confirmed := map[string]struct{}{ss.B.Source: struct{}{}}
+	stateHash := ss.B.MakeStateHashString()
+
+	for _, addr := range s.peers(nodeAddrs) {
+		if len(confirmed) >= threshold {
+			break
+		}
+		if _, found := confirmed[addr]; found || !s.localNode.HasValidators(addr) {
+			continue
+		}
+
+		other, err := s.snapshotFetcher.FetchSnapshotByHeight(s.ctx, addr, ss.Height())
+		if err != nil {
+			s.logger.Error("failed to fetch snapshot", "node", addr, "height", ss.Height(), "err", err)
+			// the validator may not have the snapshot at the same height
+			if err != errors.StorageRecordDoesNotExist {
+				s.fetchFailed(addr, err)
+			}
+			continue
+		}
+		if other.B.Source != addr || other.IsWellFormed(s.networkID) != nil {
+			s.logger.Error("invalid snapshot", "node", addr, "height", ss.Height())
+			continue
+		}
+		if other.B.MakeStateHashString() != stateHash {
+			s.logger.Error("snapshot does not match", "node", addr, "height", ss.Height())
+			continue
+		}
+		confirmed[addr] = struct{}{}
+	}
+
+	if len(confirmed) < threshold {
+		s.logger.Error("snapshot is not confirmed", "height", ss.Height(), "confirmed", len(confirmed), "threshold", threshold)
+		return errors.SnapshotNotConfirmed
+	}
+
+	return nil
+}
+
+// needSnapshot checks the snapshot can be loaded; the snapshot is loaded only
+// when the local node has nothing but the genesis block.
+func (s *Syncer) needSnapshot(latestHeight, highestHeight uint64) bool {
+	return s.snapshot && latestHeight <= common.GenesisBlockHeight && highestHeight > common.GenesisBlockHeight
+}
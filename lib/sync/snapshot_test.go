@@ -0,0 +1,285 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/node/runner"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// makeSnapshotStorage makes the storage, which has the snapshot of the block
+// at height 2, signed by `kp`.
+func makeSnapshotStorage(t *testing.T, kp *keypair.Full, networkID []byte) (*storage.LevelDBBackend, block.Block) {
+	st := block.InitTestBlockchain()
+
+	genesis := block.GetGenesis(st)
+	blk := block.NewBlock(
+		kp.Address(),
+		voting.Basis{Height: 2, BlockHash: genesis.Hash},
+		"",
+		nil,
+		genesis.StateRoot, // no state change
+		common.NowISO8601(),
+	)
+	blk.MustSave(st)
+
+	s, err := block.MakeSnapshot(st, *blk, 1)
+	require.NoError(t, err)
+	s.Sign(kp, networkID)
+	require.NoError(t, s.Save(st))
+
+	return st, *blk
+}
+
+// newSnapshotTestSyncer makes the syncer, which is not started; only
+// `Syncer.syncSnapshot()` is tested.
+func newSnapshotTestSyncer() (*storage.LevelDBBackend, *Syncer) {
+	return newSnapshotTestSyncerWithPolicy(&mockConnectionManager{}, newTestPolicy(1))
+}
+
+func newSnapshotTestSyncerWithPolicy(cm *mockConnectionManager, policy voting.ThresholdPolicy) (*storage.LevelDBBackend, *Syncer) {
+	st := block.InitTestBlockchain()
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+
+	return st, NewSyncer(st, nw, cm, []byte("test-network"), localNode, policy, common.NewConfig())
+}
+
+func TestSyncerSnapshot(t *testing.T) {
+	st, syncer := newSnapshotTestSyncer()
+	defer st.Close()
+
+	kp, _ := keypair.Random()
+	ep, _ := common.NewEndpointFromString("https://node1")
+	v, _ := node.NewValidator(kp.Address(), ep, "n1")
+	syncer.localNode.AddValidators(v)
+
+	src, blk := makeSnapshotStorage(t, kp, syncer.networkID)
+	defer src.Close()
+
+	syncer.snapshotFetcher = mockSnapshotFetcher{st: src, n: v}
+
+	require.False(t, syncer.needSnapshot(1, 5))
+	syncer.snapshot = true
+	require.True(t, syncer.needSnapshot(1, 5))
+	require.False(t, syncer.needSnapshot(1, 1))
+
+	require.NoError(t, syncer.syncSnapshot([]string{kp.Address()}))
+
+	// the snapshot block becomes the latest block
+	require.Equal(t, blk.Hash, block.GetLatestBlock(st).Hash)
+	require.False(t, syncer.needSnapshot(syncer.latestBlockHeight(), 5))
+
+	genesis := block.GetGenesis(src)
+	for _, address := range []string{block.GenesisKP.Address(), block.CommonKP.Address()} {
+		expected, err := block.GetBlockAccount(src, address)
+		require.NoError(t, err)
+		ba, err := block.GetBlockAccount(st, address)
+		require.NoError(t, err)
+		require.Equal(t, expected.Balance, ba.Balance)
+	}
+	require.Equal(t, genesis.StateRoot, block.GetLatestBlock(st).StateRoot)
+}
+
+func TestSyncerSnapshotFromUnknownValidator(t *testing.T) {
+	st, syncer := newSnapshotTestSyncer()
+	defer st.Close()
+
+	kp, _ := keypair.Random()
+	ep, _ := common.NewEndpointFromString("https://node1")
+	v, _ := node.NewValidator(kp.Address(), ep, "n1")
+
+	src, _ := makeSnapshotStorage(t, kp, syncer.networkID)
+	defer src.Close()
+
+	syncer.snapshot = true
+	syncer.snapshotFetcher = mockSnapshotFetcher{st: src, n: v}
+
+	// nothing is loaded, so the blocks will be synced from genesis
+	require.Equal(t, errors.SnapshotFromUnknownValidator, syncer.syncSnapshot([]string{kp.Address()}))
+	require.Equal(t, common.GenesisBlockHeight, block.GetLatestBlock(st).Height)
+}
+
+func TestSyncerSnapshotConfirm(t *testing.T) {
+	var (
+		kps        []*keypair.Full
+		validators []*node.Validator
+		addrs      []string
+	)
+	for i := 0; i < 3; i++ {
+		kp, _ := keypair.Random()
+		ep, _ := common.NewEndpointFromString(fmt.Sprintf("https://node%d", i))
+		v, _ := node.NewValidator(kp.Address(), ep, fmt.Sprintf("n%d", i))
+		kps = append(kps, kp)
+		validators = append(validators, v)
+		addrs = append(addrs, kp.Address())
+	}
+
+	cm := &mockConnectionManager{allConnected: addrs}
+	st, syncer := newSnapshotTestSyncerWithPolicy(cm, newTestPolicy(len(validators)))
+	defer st.Close()
+	syncer.localNode.AddValidators(validators...)
+	syncer.snapshot = true
+
+	src, blk := makeSnapshotStorage(t, kps[0], syncer.networkID)
+	defer src.Close()
+
+	ss, err := block.GetLatestSnapshot(src)
+	require.NoError(t, err)
+
+	// the other validators make the snapshots of the same block
+	snapshots := map[string]block.Snapshot{}
+	for i, kp := range kps[1:] {
+		other := ss
+		other.Sign(kp, syncer.networkID)
+		snapshots[addrs[i+1]] = other
+	}
+	syncer.snapshotFetcher = mockSnapshotFetcher{
+		st: src,
+		n:  validators[0],
+		fetchSnapshotByHeightFunc: func(ctx context.Context, nodeAddr string, height uint64) (block.Snapshot, error) {
+			if other, found := snapshots[nodeAddr]; found && other.Height() == height {
+				return other, nil
+			}
+			return block.Snapshot{}, errors.StorageRecordDoesNotExist
+		},
+	}
+
+	{ // the chunks of snapshot does not match with the other validator
+		wrong := snapshots[addrs[2]]
+		wrong.B.Chunks = append([]string{}, ss.B.Chunks...)
+		wrong.B.Chunks[0] = "wrong-chunk"
+		wrong.Sign(kps[2], syncer.networkID)
+		snapshots[addrs[2]] = wrong
+	}
+
+	// 2 of 3 validators are not enough for the threshold, 3
+	require.Equal(t, errors.SnapshotNotConfirmed, syncer.syncSnapshot(addrs))
+	require.Equal(t, common.GenesisBlockHeight, block.GetLatestBlock(st).Height)
+
+	{ // the validator set changes of snapshot does not match
+		wrong := ss
+		wrong.B.ValidatorSetChanges = append(wrong.B.ValidatorSetChanges, block.ValidatorSetChange{Height: 1})
+		wrong.Sign(kps[2], syncer.networkID)
+		snapshots[addrs[2]] = wrong
+	}
+	require.Equal(t, errors.SnapshotNotConfirmed, syncer.syncSnapshot(addrs))
+
+	// the snapshot must be signed by the validator itself
+	snapshots[addrs[2]] = snapshots[addrs[1]]
+	require.Equal(t, errors.SnapshotNotConfirmed, syncer.syncSnapshot(addrs))
+
+	delete(snapshots, addrs[2])
+	require.Equal(t, errors.SnapshotNotConfirmed, syncer.syncSnapshot(addrs))
+	require.Equal(t, common.GenesisBlockHeight, block.GetLatestBlock(st).Height)
+
+	other := ss
+	other.Sign(kps[2], syncer.networkID)
+	snapshots[addrs[2]] = other
+	require.NoError(t, syncer.syncSnapshot(addrs))
+	require.Equal(t, blk.Hash, block.GetLatestBlock(st).Hash)
+}
+
+func TestBlockFetcherSnapshotTooLarge(t *testing.T) {
+	kp, _ := keypair.Random()
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+	cm := &mockConnectionManager{
+		allConnected: []string{kp.Address()},
+		getNodeFunc: func(addr string) node.Node {
+			ep, _ := common.NewEndpointFromString("https://node1?NodeName=n1")
+			v, _ := node.NewValidator(kp.Address(), ep, "n1")
+			return v
+		},
+	}
+
+	f := NewBlockFetcher(nw, cm, storage.NewTestStorage(), localNode)
+	f.apiClient = mockDoer{
+		handleFunc: func(req *http.Request) (*http.Response, error) {
+			w := httptest.NewRecorder()
+			w.Write(bytes.Repeat([]byte(" "), MaxNodeItemBodySize+1))
+			return w.Result(), nil
+		},
+	}
+
+	_, _, err := f.FetchSnapshot(context.Background(), nil)
+	require.Equal(t, errors.SnapshotTooLarge, err)
+}
+
+func TestBlockFetcherSnapshot(t *testing.T) {
+	kp, _ := keypair.Random()
+	src, _ := makeSnapshotStorage(t, kp, []byte("test-network"))
+	defer src.Close()
+
+	expected, err := block.GetLatestSnapshot(src)
+	require.NoError(t, err)
+
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+	cm := &mockConnectionManager{
+		allConnected: []string{kp.Address()},
+		getNodeFunc: func(addr string) node.Node {
+			ep, _ := common.NewEndpointFromString("https://node1?NodeName=n1")
+			v, _ := node.NewValidator(kp.Address(), ep, "n1")
+			return v
+		},
+	}
+
+	f := NewBlockFetcher(nw, cm, src, localNode)
+	f.apiClient = mockDoer{
+		handleFunc: func(req *http.Request) (*http.Response, error) {
+			w := httptest.NewRecorder()
+			query := req.URL.Query()
+			switch req.URL.Path {
+			case network.UrlPathPrefixNode + runner.GetSnapshotPattern:
+				if height := query.Get("height"); len(height) > 0 && height != strconv.FormatUint(expected.Height(), 10) {
+					w.WriteHeader(http.StatusNotFound)
+				} else {
+					renderNodeItem(w, runner.NodeItemSnapshot, expected)
+				}
+			case network.UrlPathPrefixNode + runner.GetSnapshotChunkPattern:
+				index, _ := strconv.ParseUint(query.Get("index"), 10, 64)
+				if c, err := block.GetSnapshotChunk(src, expected.Height(), index); err != nil {
+					w.WriteHeader(http.StatusNotFound)
+				} else {
+					renderNodeItem(w, runner.NodeItemSnapshotChunk, c)
+				}
+			}
+			return w.Result(), nil
+		},
+	}
+
+	ctx := context.Background()
+	s, n, err := f.FetchSnapshot(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected.H.Hash, s.H.Hash)
+	require.Equal(t, kp.Address(), n.Address())
+
+	for i, hash := range s.B.Chunks {
+		c, err := f.FetchSnapshotChunk(ctx, n, s.Height(), uint64(i))
+		require.NoError(t, err)
+		require.Equal(t, hash, c.MakeHashString())
+	}
+
+	_, err = f.FetchSnapshotChunk(ctx, n, s.Height(), uint64(len(s.B.Chunks)))
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+
+	s, err = f.FetchSnapshotByHeight(ctx, kp.Address(), expected.Height())
+	require.NoError(t, err)
+	require.Equal(t, expected.H.Hash, s.H.Hash)
+
+	_, err = f.FetchSnapshotByHeight(ctx, kp.Address(), expected.Height()+1)
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+}
@@ -2,15 +2,16 @@ package sync
 
 import (
 	"context"
-	"strconv"
+	"sync"
 	"time"
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
-	"boscoin.io/sebak/lib/common/observer"
+	"boscoin.io/sebak/lib/metrics"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/inconshreveable/log15"
 )
 
@@ -24,6 +25,11 @@ type Syncer struct {
 	fetchTimeout  time.Duration
 	retryInterval time.Duration
 	checkInterval time.Duration
+	snapshot      bool // load the snapshot of the other node before syncing blocks
+
+	headerPeers     int    // number of peers, which are asked for the same headers
+	headerBatchSize uint64 // number of headers, which are verified at once
+	bodyRangeSize   uint64 // number of blocks, which are fetched from one peer at once
 
 	afterFunc AfterFunc
 
@@ -33,9 +39,11 @@ type Syncer struct {
 	networkID         []byte
 	commonCfg         common.Config
 	localNode         *node.LocalNode
+	policy            voting.ThresholdPolicy
 
-	fetcher   Fetcher
-	validator Validator
+	fetcher         Fetcher
+	snapshotFetcher SnapshotFetcher
+	validator       Validator
 
 	workPool   *Pool
 	stop       chan chan struct{}
@@ -45,6 +53,9 @@ type Syncer struct {
 	requestHighestBlock chan *requestHighestBlock
 	getSyncProgress     chan chan *SyncProgress
 
+	failures     map[string]uint64 // number of fetch failures by peer
+	failuresLock sync.Mutex
+
 	logger log15.Logger
 }
 
@@ -55,6 +66,7 @@ func NewSyncer(st *storage.LevelDBBackend,
 	cm network.ConnectionManager,
 	networkID []byte,
 	localNode *node.LocalNode,
+	policy voting.ThresholdPolicy,
 	cfg common.Config,
 	opts ...SyncerOption) *Syncer {
 	ctx, cancelFunc := context.WithCancel(context.Background())
@@ -66,12 +78,17 @@ func NewSyncer(st *storage.LevelDBBackend,
 		networkID:         networkID,
 		commonCfg:         cfg,
 		localNode:         localNode,
+		policy:            policy,
 
 		poolSize:      SyncPoolSize,
 		fetchTimeout:  FetchTimeout,
 		retryInterval: RetryInterval,
 		checkInterval: CheckBlockHeightInterval,
 
+		headerPeers:     SyncHeaderPeers,
+		headerBatchSize: SyncHeaderBatchSize,
+		bodyRangeSize:   SyncBodyRangeSize,
+
 		afterFunc: time.After,
 
 		stop:       make(chan chan struct{}),
@@ -81,6 +98,8 @@ func NewSyncer(st *storage.LevelDBBackend,
 		requestHighestBlock: make(chan *requestHighestBlock),
 		getSyncProgress:     make(chan chan *SyncProgress),
 
+		failures: make(map[string]uint64),
+
 		logger: common.NopLogger(),
 	}
 
@@ -93,6 +112,7 @@ func NewSyncer(st *storage.LevelDBBackend,
 		f.logger = s.logger
 	})
 	s.fetcher = fetcher
+	s.snapshotFetcher = fetcher
 
 	validator := NewBlockValidator(nw, st, networkID, cfg, func(v *BlockValidator) {
 		v.logger = s.logger
@@ -156,8 +176,10 @@ func (s *Syncer) SyncProgress(ctx context.Context) (*SyncProgress, error) {
 func (s *Syncer) loop() {
 	var (
 		checkc       = s.afterFunc(s.checkInterval)
-		notifyc      = make(chan struct{})
-		onNotify     = false
+		heightc      = make(chan uint64)
+		donec        = make(chan error)
+		running      = false
+		rate         progressRate
 		height       = s.latestBlockHeight()
 		syncProgress = &SyncProgress{
 			StartingBlock: height,
@@ -167,131 +189,131 @@ func (s *Syncer) loop() {
 		nodeAddrs []string
 	)
 
+	// run syncs the blocks in background; only one sync runs at once.
+	run := func() {
+		if running {
+			return
+		}
+		if !s.prepareSync(syncProgress) {
+			return
+		}
+		running = true
+		rate = newProgressRate(syncProgress.CurrentBlock)
+		rate.update(syncProgress)
+
+		highestHeight := syncProgress.HighestBlock
+		addrs := nodeAddrs
+		go func() {
+			err := s.sync(highestHeight, addrs, heightc)
+			select {
+			case donec <- err:
+			case <-s.ctx.Done():
+			}
+		}()
+	}
+
 	for {
 		select {
 		case <-checkc:
 			s.logger.Debug("check interval", "checkInterval", s.checkInterval)
-			s.sync(syncProgress, nodeAddrs)
+			run()
 			checkc = s.afterFunc(s.checkInterval)
-		case <-notifyc:
-			s.logger.Debug("got notification finished height")
-			onNotify = false // reset onNotify for singleflight
-			s.sync(syncProgress, nodeAddrs)
+		case height := <-heightc:
+			syncProgress.CurrentBlock = height
+			rate.update(syncProgress)
+		case err := <-donec:
+			running = false
+			if err != nil {
+				if err != context.Canceled {
+					s.logger.Error("stop sync work", "err", err)
+				}
+				// retry at the next check interval
+				break
+			}
+			s.logger.Info("sync progress",
+				"start", syncProgress.StartingBlock, "cur", syncProgress.CurrentBlock, "high", syncProgress.HighestBlock)
+			run() // the highest height can be updated while syncing
 		case req := <-s.requestHighestBlock:
 			height := req.height
 			nodeAddrs = req.nodeAddrs
 			s.logger.Info("updated highest height", "height", height, "nodes", len(nodeAddrs))
+			syncProgress.HighestKnown = true
 			if height > syncProgress.CurrentBlock {
 				syncProgress.HighestBlock = height
-				s.sync(syncProgress, nodeAddrs)
+				metrics.SyncHighestBlock.Set(float64(height))
+				run()
 			}
 		case c := <-s.getSyncProgress:
-			c <- syncProgress
+			p := *syncProgress
+			p.FetchFailures = s.fetchFailures()
+			c <- &p
 		case c := <-s.stop:
 			close(c)
 			return
 		}
-
-		if !onNotify && syncProgress.CurrentBlock < syncProgress.HighestBlock {
-			event := strconv.FormatUint(syncProgress.CurrentBlock, 10)
-			observer.SyncBlockWaitObserver.One(event, func(...interface{}) {
-				select {
-				case notifyc <- struct{}{}:
-				case <-s.ctx.Done():
-				}
-				s.logger.Debug("send for notify", "event", event)
-			})
-			onNotify = true
-		}
 	}
 }
 
-func (s *Syncer) sync(p *SyncProgress, nodeAddrs []string) {
-	var (
-		startHeight       = p.CurrentBlock + 1
-		currentHeight     = p.CurrentBlock
-		highestHeight     = p.HighestBlock
-		latestBlockHeight = s.latestBlockHeight()
-	)
-
-	if latestBlockHeight > currentHeight {
-		startHeight = latestBlockHeight + 1
+// prepareSync updates the progress by the latest block and checks there are
+// blocks to sync.
+func (s *Syncer) prepareSync(p *SyncProgress) bool {
+	latestBlockHeight := s.latestBlockHeight()
+	if latestBlockHeight > p.CurrentBlock {
+		p.CurrentBlock = latestBlockHeight
 	}
-	if startHeight > highestHeight {
-		p.StartingBlock = latestBlockHeight + 1
-		p.CurrentBlock = currentHeight
-
+	if p.CurrentBlock >= p.HighestBlock {
 		logmsg := "sync progress skip: start height is over or equal than highest (requested) height"
 		s.logger.Debug(logmsg,
 			"start", p.StartingBlock, "cur", p.CurrentBlock, "high", p.HighestBlock)
-		return
+		return false
 	}
 
-	for height := startHeight; height <= highestHeight; height++ {
-		s.logger.Debug("work height", "height", height)
-		// TryAdd for unblocking when the pool is full. Just keep syncprogress for next sync
-		if s.work(height, nodeAddrs) == false {
-			break
-		}
-		currentHeight = height
-	}
-	p.StartingBlock = startHeight
-	p.CurrentBlock = currentHeight
+	p.StartingBlock = p.CurrentBlock + 1
 
-	s.logger.Info("sync progress",
-		"start", p.StartingBlock, "cur", p.CurrentBlock, "high", p.HighestBlock)
+	return true
 }
 
-func (s *Syncer) work(height uint64, nodeAddrs []string) bool {
-	ctx := s.ctx
-	work := func() {
-		latestHeight := s.latestBlockHeight()
-		if latestHeight > 0 && height <= latestHeight {
-			s.logger.Info("this height has already synced", "height", height)
-			return
+// sync syncs the blocks up to `highestHeight`. The headers are fetched first
+// by `headerBatchSize` and verified by the peers, and then the bodies of the
+// headers are fetched from the peers, which agreed with the headers.
+func (s *Syncer) sync(highestHeight uint64, nodeAddrs []string, heightc chan<- uint64) error {
+	latestBlockHeight := s.latestBlockHeight()
+	if s.needSnapshot(latestBlockHeight, highestHeight) {
+		if err := s.syncSnapshot(nodeAddrs); err != nil {
+			s.logger.Error("failed to load snapshot; sync the blocks from genesis", "err", err)
 		}
+	}
 
-		var (
-			syncInfo = &SyncInfo{
-				Height:    height,
-				NodeAddrs: nodeAddrs,
-			}
-			err error
-		)
+	prev := block.GetLatestBlock(s.storage)
+	if prev.Height > latestBlockHeight { // snapshot loaded
+		select {
+		case heightc <- prev.Height:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
 
-	L:
-		for {
-			select {
-			case <-ctx.Done():
-				break L
-			default:
-				syncInfo, err = s.fetcher.Fetch(ctx, syncInfo)
-				if err != nil {
-					if err != context.Canceled {
-						s.logger.Error("fetch failure", "err", err, "height", height)
-					}
-				}
-				err = s.validator.Validate(ctx, syncInfo)
-				if err != nil {
-					if err != context.Canceled {
-						s.logger.Error("validate failure", "err", err, "height", height)
-					}
-					continue
-				}
-				break L
-			}
+	for prev.Height < highestHeight {
+
+		from := prev.Height + 1
+		to := from + s.headerBatchSize - 1
+		if to > highestHeight {
+			to = highestHeight
 		}
+
+		headers, peers, err := s.fetchHeaderChain(prev, from, to, nodeAddrs)
 		if err != nil {
-			if err != context.Canceled {
-				s.logger.Error("stop sync work", "height", height, "err", err)
-			} else {
-				s.logger.Debug("stop sync work", "height", height, "err", err)
-			}
-		} else {
-			s.logger.Info("done sync work", "height", height, "hash", syncInfo.Block.Hash)
+			return err
+		}
+		s.logger.Debug("headers verified", "from", from, "to", to, "peers", len(peers))
+
+		if err := s.fetchBodies(headers, peers, heightc); err != nil {
+			return err
 		}
+		prev = headers[len(headers)-1]
 	}
-	return s.workPool.TryAdd(ctx, work)
+
+	return nil
 }
 
 func (s *Syncer) latestBlockHeight() uint64 {
@@ -56,37 +56,84 @@ func TestSyncerSetSyncTarget(t *testing.T) {
 			}
 		}
 		require.Equal(t, len(heights), 9)
+		for i, h := range heights {
+			require.Equal(t, uint64(i+2), h) // blocks are validated in order
+		}
 
-		progress, err := syncer.SyncProgress(ctx)
-		require.NoError(t, err)
+		var progress *SyncProgress
+		for i := 0; i < 100; i++ {
+			var err error
+			progress, err = syncer.SyncProgress(ctx)
+			require.NoError(t, err)
+			if progress.CurrentBlock == height {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
 		require.Equal(t, progress.StartingBlock, uint64(2))
 		require.Equal(t, progress.CurrentBlock, height)
 		require.Equal(t, progress.HighestBlock, height)
+		require.Equal(t, time.Duration(0), progress.ETA)
+		require.Empty(t, progress.FetchFailures)
+
+		info, err := syncer.SyncInfo(ctx)
+		require.NoError(t, err)
+		require.True(t, info.Synced)
+		require.Equal(t, height, info.CurrentBlock)
 
 	}
 	SyncerTest(t, fn)
 }
 
+func TestSyncerSyncInfoOnStartup(t *testing.T) {
+	fn := func(tctx *SyncerTestContext) {
+		var (
+			ctx    = context.Background()
+			syncer = tctx.syncer
+		)
+
+		go func() {
+			syncer.Start()
+		}()
+
+		// the height of the peers is not known yet
+		info, err := syncer.SyncInfo(ctx)
+		require.NoError(t, err)
+		require.False(t, info.Synced)
+		require.Equal(t, info.HighestBlock, info.CurrentBlock)
+
+		// the peers have the same height with the local node
+		latest := syncer.latestBlockHeight()
+		require.NoError(t, syncer.SetSyncTargetBlock(ctx, latest, []string{"a", "b"}))
+
+		info, err = syncer.SyncInfo(ctx)
+		require.NoError(t, err)
+		require.True(t, info.Synced)
+		require.Equal(t, latest, info.CurrentBlock)
+	}
+	SyncerTest(t, fn)
+}
+
 func SyncerTest(t *testing.T, fn func(*SyncerTestContext)) {
 	st := block.InitTestBlockchain()
 	defer st.Close()
 	_, nw, localNode := network.CreateMemoryNetwork(nil)
-	cm := &mockConnectionManager{}
+	cm := &mockConnectionManager{allConnected: []string{"a", "b"}}
 	networkID := []byte("test-network")
 
 	tickc := make(chan time.Time)
 	infoc := make(chan *SyncInfo)
 
-	syncer := NewSyncer(st, nw, cm, networkID, localNode, common.NewConfig())
+	syncer := NewSyncer(st, nw, cm, networkID, localNode, newTestPolicy(2), common.NewConfig())
 	defer syncer.Stop()
 
+	chain := makeTestChain(block.GetLatestBlock(st), 20)
 	syncer.fetcher = &mockFetcher{
-		fetchFunc: func(ctx context.Context, si *SyncInfo) (*SyncInfo, error) {
-			bk := block.TestMakeNewBlock([]string{})
-			bk.Height = si.Height
-			si.Height = bk.Height
-			si.Block = &bk
-			return si, nil
+		fetchHeadersFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error) {
+			return chain.headers(from, to), nil
+		},
+		fetchBodiesFunc: func(ctx context.Context, nodeAddr string, from, to uint64) ([]*SyncInfo, error) {
+			return chain.bodies(from, to), nil
 		},
 	}
 	syncer.validator = &mockValidator{
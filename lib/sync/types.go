@@ -7,6 +7,7 @@ import (
 
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/transaction"
 )
 
@@ -14,6 +15,11 @@ type SyncProgress struct {
 	StartingBlock uint64 // Block number where sync began
 	CurrentBlock  uint64 // Current block number where sync is at
 	HighestBlock  uint64 // Highest alleged block number in the chain
+	HighestKnown  bool   // Whether `HighestBlock` is known by the peers
+
+	BlocksPerSecond float64           // Synced blocks per second in the current sync
+	ETA             time.Duration     // Estimated time to reach `HighestBlock`
+	FetchFailures   map[string]uint64 // Number of fetch failures by peer address
 }
 
 type SyncController interface {
@@ -38,7 +44,14 @@ type Doer interface {
 type AfterFunc = func(time.Duration) <-chan time.Time
 
 type Fetcher interface {
-	Fetch(ctx context.Context, syncInfo *SyncInfo) (*SyncInfo, error)
+	FetchHeaders(ctx context.Context, nodeAddr string, from, to uint64) ([]block.Block, error)
+	FetchBodies(ctx context.Context, nodeAddr string, from, to uint64) ([]*SyncInfo, error)
+}
+
+type SnapshotFetcher interface {
+	FetchSnapshot(ctx context.Context, nodeAddrs []string) (block.Snapshot, node.Node, error)
+	FetchSnapshotByHeight(ctx context.Context, nodeAddr string, height uint64) (block.Snapshot, error)
+	FetchSnapshotChunk(ctx context.Context, n node.Node, height, index uint64) (block.SnapshotChunk, error)
 }
 
 type Validator interface {
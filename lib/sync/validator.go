@@ -3,12 +3,9 @@ package sync
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
-	"boscoin.io/sebak/lib/common/observer"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node/runner"
@@ -27,19 +24,17 @@ type BlockValidator struct {
 
 	networkID []byte
 
-	prevBlockWaitTimeout time.Duration // Waiting prev block if is doesn't exist
-	logger               log15.Logger
+	logger log15.Logger
 }
 
 type BlockValidatorOption func(*BlockValidator)
 
 func NewBlockValidator(nw network.Network, ldb *storage.LevelDBBackend, networkID []byte, cfg common.Config, opts ...BlockValidatorOption) *BlockValidator {
 	v := &BlockValidator{
-		network:              nw,
-		storage:              ldb,
-		networkID:            networkID,
-		prevBlockWaitTimeout: 10 * time.Minute,
-		commonCfg:            cfg,
+		network:   nw,
+		storage:   ldb,
+		networkID: networkID,
+		commonCfg: cfg,
 
 		logger: common.NopLogger(),
 	}
@@ -78,7 +73,8 @@ func (v *BlockValidator) Validate(ctx context.Context, syncInfo *SyncInfo) error
 }
 
 func (v *BlockValidator) validate(ctx context.Context, syncInfo *SyncInfo) error {
-	//Waiting to get prev block for runner.ValidateTx
+	// The blocks are validated in order by `Syncer`, so the previous block
+	// must be stored already.
 	prevBlk, err := v.getPrevBlock(ctx, syncInfo.Height)
 	if err != nil {
 		return err
@@ -111,6 +107,23 @@ func (v *BlockValidator) finishBlock(ctx context.Context, syncInfo *SyncInfo) er
 
 	//TODO(anarcher): using leveldb.Tx or leveldb.Batch?
 	blk := *syncInfo.Block
+
+	prevBlk, err := block.GetBlockByHeight(bs, blk.Height-1)
+	if err != nil {
+		bs.Discard()
+		return err
+	}
+	sdb, stateRoot, err := runner.ApplyBlockState(bs, prevBlk, syncInfo.Txs, *syncInfo.Ptx, v.logger)
+	if err != nil {
+		bs.Discard()
+		return err
+	}
+	if stateRoot != blk.StateRoot {
+		bs.Discard()
+		v.logger.Error("state root does not match", "height", blk.Height, "in block", blk.StateRoot, "state root", stateRoot)
+		return errors.StateRootDoesNotMatch
+	}
+
 	if err := blk.Save(bs); err != nil {
 		if err == errors.BlockAlreadyExists {
 			return nil
@@ -134,6 +147,11 @@ func (v *BlockValidator) finishBlock(ctx context.Context, syncInfo *SyncInfo) er
 		return err
 	}
 
+	if err := runner.CommitBlockState(bs, blk, sdb); err != nil {
+		bs.Discard()
+		return err
+	}
+
 	v.logger.Debug(fmt.Sprintf("finish to sync block height: %v", syncInfo.Height), "height", syncInfo.Height, "hash", blk.Hash)
 
 	if err := bs.Commit(); err != nil {
@@ -141,14 +159,6 @@ func (v *BlockValidator) finishBlock(ctx context.Context, syncInfo *SyncInfo) er
 		return err
 	}
 
-	select {
-	case <-ctx.Done():
-		return nil
-	default:
-		event := strconv.FormatUint(syncInfo.Height, 10)
-		observer.SyncBlockWaitObserver.Trigger(event)
-	}
-
 	return nil
 }
 
@@ -166,7 +176,7 @@ func (v *BlockValidator) validateBlock(ctx context.Context, si *SyncInfo, prevBl
 		TotalOps:  si.Block.TotalOps,
 	}
 
-	blk := block.NewBlock(si.Block.Proposer, r, si.Block.ProposerTransaction, txs, si.Block.Confirmed)
+	blk := block.NewBlock(si.Block.Proposer, r, si.Block.ProposerTransaction, txs, si.Block.StateRoot, si.Block.Confirmed)
 
 	if blk.Hash != si.Block.Hash {
 		err := errors.HashDoesNotMatch
@@ -195,7 +205,11 @@ func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 			return err
 		}
 
-		if err := runner.ValidateTx(v.storage, *tx); err != nil {
+		if err := runner.ValidateTxHeight(v.storage, *tx); err != nil {
+			return err
+		}
+
+		if err := runner.ValidateTx(v.storage, v.commonCfg, *tx); err != nil {
 			return err
 		}
 	}
@@ -216,54 +230,8 @@ func (v *BlockValidator) existsBlock(ctx context.Context, st *storage.LevelDBBac
 	}
 }
 
-func (v *BlockValidator) getPrevBlock(pctx context.Context, height uint64) (*block.Block, error) {
-	ctx, cancelFunc := context.WithCancel(pctx)
-
+func (v *BlockValidator) getPrevBlock(ctx context.Context, height uint64) (*block.Block, error) {
 	prevHeight := height - 1
-	waitC := make(chan struct{})
-
-	go func() {
-	L:
-		for {
-			exists, err := block.ExistsBlockByHeight(v.storage, prevHeight)
-			if err != nil {
-				v.logger.Error("getPrevBlock: block.ExistsBlockByHeight", "err", err)
-			}
-			if exists == true {
-				select {
-				case waitC <- struct{}{}:
-				case <-ctx.Done():
-				}
-				break L
-			}
-
-			sleep := time.After(v.prevBlockWaitTimeout)
-
-			select {
-			case <-ctx.Done():
-			case <-sleep:
-			}
-		}
-		v.logger.Debug("done: prev height db watcher", "height", height)
-	}()
-
-	event := strconv.FormatUint(prevHeight, 10)
-	observer.SyncBlockWaitObserver.One(event, func(args ...interface{}) {
-		select {
-		case waitC <- struct{}{}:
-			v.logger.Debug("SyncBlockWaitObserver", "prevHeight", prevHeight)
-		case <-ctx.Done():
-		}
-		v.logger.Debug("done: SyncBlockWaitObserver", "height", height)
-	})
-
-	select {
-	case <-waitC:
-	case <-ctx.Done():
-	}
-
-	cancelFunc() // done observer and db watcher
-
 	prevBlock, err := block.GetBlockByHeight(v.storage, prevHeight)
 	if err != nil {
 		v.logger.Error("prevBlock: GetBlockByHeight", "prevHeight", prevHeight, "err", err)
@@ -0,0 +1,206 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/node/runner/api"
+	"boscoin.io/sebak/lib/voting"
+
+	"github.com/inconshreveable/log15"
+)
+
+// Watcher makes the watcher node follow the chain continuously. The watcher
+// does not receive the ballots, so it checks the latest blocks of the
+// connected validators periodically and sets the height, which is reached by
+// the threshold of validators, to the sync target of `SyncController`.
+type Watcher struct {
+	connectionManager network.ConnectionManager
+	localNode         *node.LocalNode
+	policy            voting.ThresholdPolicy
+	syncer            SyncController
+	apiClient         Doer
+
+	fetchTimeout  time.Duration
+	checkInterval time.Duration
+	afterFunc     AfterFunc
+
+	latestReqSyncHeight uint64
+
+	stop       chan chan struct{}
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+
+	logger log15.Logger
+}
+
+type WatcherOption = func(w *Watcher)
+
+func NewWatcher(cm network.ConnectionManager,
+	localNode *node.LocalNode,
+	policy voting.ThresholdPolicy,
+	syncer SyncController,
+	opts ...WatcherOption) *Watcher {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+
+	w := &Watcher{
+		connectionManager: cm,
+		localNode:         localNode,
+		policy:            policy,
+		syncer:            syncer,
+
+		fetchTimeout:  FetchTimeout,
+		checkInterval: WatchInterval,
+		afterFunc:     time.After,
+
+		stop:       make(chan chan struct{}),
+		ctx:        ctx,
+		cancelFunc: cancelFunc,
+
+		logger: common.NopLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	client, err := common.NewHTTP2Client(w.fetchTimeout, 0, true)
+	if err != nil {
+		w.logger.Error("make http2 client", "err", err)
+		panic(err) // It's an unrecoverable error not to make client when starting watcher / node
+	}
+	w.apiClient = client
+
+	return w
+}
+
+func (w *Watcher) Start() error {
+	w.logger.Info("starting watcher")
+	w.loop()
+	return nil
+}
+
+func (w *Watcher) Stop() error {
+	w.cancelFunc()
+	c := make(chan struct{})
+	w.stop <- c
+	<-c
+	w.logger.Info("stopped watcher")
+	return nil
+}
+
+func (w *Watcher) loop() {
+	checkc := w.afterFunc(w.checkInterval)
+
+	for {
+		select {
+		case <-checkc:
+			w.check()
+			checkc = w.afterFunc(w.checkInterval)
+		case c := <-w.stop:
+			close(c)
+			return
+		}
+	}
+}
+
+func (w *Watcher) check() {
+	nodesHeight := map[string]uint64{}
+	for _, address := range w.connectionManager.AllConnected() {
+		if address == w.localNode.Address() {
+			continue
+		}
+
+		n := w.connectionManager.GetNode(address)
+		if n == nil {
+			continue
+		}
+
+		nodeInfo, err := w.getNodeInfo(n)
+		if err != nil {
+			if err != context.Canceled {
+				w.logger.Debug("failed to get node info", "node", address, "err", err)
+			}
+			continue
+		}
+		nodesHeight[address] = nodeInfo.Block.Height
+	}
+
+	height, nodeAddrs, err := w.syncTarget(nodesHeight)
+	if err != nil {
+		w.logger.Debug("sync target not found", "err", err)
+		return
+	}
+	if height <= w.latestReqSyncHeight {
+		return
+	}
+
+	if err := w.syncer.SetSyncTargetBlock(w.ctx, height, nodeAddrs); err != nil {
+		if err != context.Canceled {
+			w.logger.Error("syncer.SetSyncTargetBlock", "err", err, "height", height)
+		}
+		return
+	}
+	w.latestReqSyncHeight = height
+}
+
+// syncTarget returns the highest height, which is reached by the threshold
+// of validators and the validators, which reached it.
+func (w *Watcher) syncTarget(nodesHeight map[string]uint64) (height uint64, nodeAddrs []string, err error) {
+	threshold := w.policy.Threshold()
+	if threshold < 1 || len(nodesHeight) < threshold {
+		err = fmt.Errorf("could not find enough nodes (threshold=%d) above", threshold)
+		return
+	}
+
+	var kvs []common.KV
+	for k, v := range nodesHeight {
+		kvs = append(kvs, common.KV{Key: k, Value: v})
+	}
+	common.SortDecByValue(kvs)
+
+	height = kvs[threshold-1].Value
+	for _, kv := range kvs[:threshold] {
+		nodeAddrs = append(nodeAddrs, kv.Key)
+	}
+
+	return
+}
+
+func (w *Watcher) getNodeInfo(n node.Node) (nodeInfo node.NodeInfo, err error) {
+	u := url.URL(*n.Endpoint())
+	u.Path = api.GetNodeInfoPattern
+	u.RawQuery = ""
+
+	var req *http.Request
+	if req, err = http.NewRequest("GET", u.String(), nil); err != nil {
+		return
+	}
+	req = req.WithContext(w.ctx)
+
+	var resp *http.Response
+	if resp, err = w.apiClient.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = errors.HTTPProblem.Clone().SetData("status", resp.StatusCode)
+		return
+	}
+
+	var b []byte
+	if b, err = ioutil.ReadAll(resp.Body); err != nil {
+		return
+	}
+
+	return node.NewNodeInfoFromJSON(b)
+}
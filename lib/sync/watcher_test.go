@@ -0,0 +1,106 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+)
+
+type mockSyncController struct {
+	requests chan *requestHighestBlock
+}
+
+func (m mockSyncController) SetSyncTargetBlock(ctx context.Context, height uint64, nodeAddrs []string) error {
+	m.requests <- &requestHighestBlock{height: height, nodeAddrs: nodeAddrs}
+	return nil
+}
+
+func TestWatcher(t *testing.T) {
+	_, _, localNode := network.CreateMemoryNetwork(nil)
+	localNode.SetWatcher()
+
+	// 4 validators; the watcher needs the height of 3 validators
+	heights := map[string]uint64{}
+	validators := map[string]*node.Validator{}
+	var addresses []string
+	for i := 0; i < 4; i++ {
+		kp, _ := keypair.Random()
+		endpoint, _ := common.NewEndpointFromString(fmt.Sprintf("https://validator%d:12345", i))
+		v, _ := node.NewValidator(kp.Address(), endpoint, "")
+
+		validators[v.Address()] = v
+		addresses = append(addresses, v.Address())
+		heights[endpoint.Host] = uint64(10 + i)
+	}
+
+	cm := &mockConnectionManager{
+		allConnected: addresses,
+		getNodeFunc: func(addr string) node.Node {
+			return validators[addr]
+		},
+	}
+
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(67)
+	policy.SetValidators(len(validators))
+
+	syncer := mockSyncController{requests: make(chan *requestHighestBlock, 1)}
+
+	watcher := NewWatcher(cm, localNode, policy, syncer)
+	watcher.apiClient = mockDoer{
+		handleFunc: func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/", req.URL.Path)
+
+			w := httptest.NewRecorder()
+			b, _ := common.JSONMarshalIndent(node.NodeInfo{
+				Block: node.NodeBlockInfo{Height: heights[req.URL.Host]},
+			})
+			w.Write(b)
+			return w.Result(), nil
+		},
+	}
+
+	{ // the 3rd highest height is the target
+		watcher.check()
+
+		req := <-syncer.requests
+		require.Equal(t, uint64(11), req.height)
+		require.Equal(t, 3, len(req.nodeAddrs))
+		require.NotContains(t, req.nodeAddrs, addresses[0])
+	}
+
+	{ // same height is not requested again
+		watcher.check()
+		watcher.check()
+		require.Equal(t, 0, len(syncer.requests))
+	}
+
+	{ // validators make new blocks
+		for host := range heights {
+			heights[host] += 5
+		}
+		watcher.check()
+
+		req := <-syncer.requests
+		require.Equal(t, uint64(16), req.height)
+	}
+
+	{ // not enough validators are connected
+		cm.allConnected = addresses[:2]
+		for host := range heights {
+			heights[host] += 5
+		}
+		watcher.check()
+		watcher.check()
+		require.Equal(t, 0, len(syncer.requests))
+	}
+}
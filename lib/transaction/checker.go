@@ -47,7 +47,7 @@ func CheckSequenceID(c common.Checker, args ...interface{}) (err error) {
 
 func CheckBaseFee(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
-	if checker.Transaction.B.Fee < checker.Transaction.TotalBaseFee() {
+	if checker.Transaction.B.Fee < checker.Transaction.TotalBaseFee(checker.Conf.NetworkParams.BaseFee) {
 		err = errors.InvalidFee
 		return
 	}
@@ -55,6 +55,28 @@ func CheckBaseFee(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
+func CheckHeightBounds(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+	b := checker.Transaction.B
+	if b.MinHeight > 0 && b.MaxHeight > 0 && b.MinHeight > b.MaxHeight {
+		err = errors.TransactionInvalidHeightBounds
+		return
+	}
+
+	return
+}
+
+func CheckMemo(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+	if memo := checker.Transaction.B.Memo; memo != nil {
+		if err = memo.IsWellFormed(); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func CheckOperationTypes(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
@@ -101,19 +123,44 @@ func CheckOperations(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
+// CheckVerifySignature verifies the signature of source and the signatures
+// of the other signers. Without `Header.Signatures`, the signature of source
+// is mandatory; whether the signers can sign for the source account is
+// checked with the stored account in `ValidateTx`.
 func CheckVerifySignature(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
+	tx := checker.Transaction
+	if len(tx.H.Signature) > 0 || len(tx.H.Signatures) < 1 {
+		if err = verifySignature(checker.NetworkID, tx.H.Hash, tx.B.Source, tx.H.Signature); err != nil {
+			return
+		}
+	}
+
+	signers := map[string]bool{tx.B.Source: true}
+	for _, s := range tx.H.Signatures {
+		if _, found := signers[s.Signer]; found {
+			err = errors.DuplicatedSigner
+			return
+		}
+		signers[s.Signer] = true
+
+		if err = verifySignature(checker.NetworkID, tx.H.Hash, s.Signer, s.Signature); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func verifySignature(networkID []byte, hash, address, signature string) (err error) {
 	var kp keypair.KP
-	if kp, err = keypair.Parse(checker.Transaction.B.Source); err != nil {
+	if kp, err = keypair.Parse(address); err != nil {
 		return
 	}
-	err = kp.Verify(
-		append(checker.NetworkID, []byte(checker.Transaction.H.Hash)...),
-		base58.Decode(checker.Transaction.H.Signature),
+
+	return kp.Verify(
+		append(networkID, []byte(hash)...),
+		base58.Decode(signature),
 	)
-	if err != nil {
-		return
-	}
-	return
 }
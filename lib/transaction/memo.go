@@ -0,0 +1,66 @@
+package transaction
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+type MemoType string
+
+const (
+	MemoTypeText MemoType = "text"
+	MemoTypeID   MemoType = "id"
+	MemoTypeHash MemoType = "hash"
+)
+
+// MaxMemoTextLength is the maximum length of text memo in bytes.
+const MaxMemoTextLength int = 64
+
+// MemoHashLength is the length of the decoded hash memo.
+const MemoHashLength int = 32
+
+// Memo is the optional note of transaction, like the customer reference for
+// the deposit of exchange. Like the other fields of `Body`, it is covered by
+// the signature.
+//  * `MemoTypeText`: utf-8 text, not longer than `MaxMemoTextLength` bytes
+//  * `MemoTypeID`: unsigned 64 bit integer in decimal
+//  * `MemoTypeHash`: base58 encoded hash of `MemoHashLength` bytes
+type Memo struct {
+	Type  MemoType `json:"type"`
+	Value string   `json:"value"`
+}
+
+func NewMemo(memoType MemoType, value string) (memo *Memo, err error) {
+	m := Memo{Type: memoType, Value: value}
+	if err = m.IsWellFormed(); err != nil {
+		return
+	}
+
+	memo = &m
+	return
+}
+
+func (m Memo) IsWellFormed() (err error) {
+	switch m.Type {
+	case MemoTypeText:
+		if len(m.Value) > MaxMemoTextLength || !utf8.ValidString(m.Value) {
+			err = errors.TransactionInvalidMemo
+		}
+	case MemoTypeID:
+		if _, perr := strconv.ParseUint(m.Value, 10, 64); perr != nil {
+			err = errors.TransactionInvalidMemo
+		}
+	case MemoTypeHash:
+		if len(base58.Decode(m.Value)) != MemoHashLength {
+			err = errors.TransactionInvalidMemo
+		}
+	default:
+		err = errors.TransactionInvalidMemo
+	}
+
+	return
+}
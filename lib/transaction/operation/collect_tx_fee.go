@@ -40,7 +40,7 @@ func NewCollectTxFee(
 	}
 }
 
-func (o CollectTxFee) IsWellFormed([]byte, common.Config) (err error) {
+func (o CollectTxFee) IsWellFormed(networkID []byte, conf common.Config) (err error) {
 	if _, err = keypair.Parse(o.Target); err != nil {
 		return
 	}
@@ -65,7 +65,7 @@ func (o CollectTxFee) IsWellFormed([]byte, common.Config) (err error) {
 			err = errors.InvalidOperation
 			return
 		}
-	} else if o.Amount < (common.BaseFee * common.Amount(o.Txs)) {
+	} else if o.Amount < (conf.NetworkParams.BaseFee * common.Amount(o.Txs)) {
 		err = errors.InvalidOperation
 		return
 	}
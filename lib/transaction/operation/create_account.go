@@ -28,7 +28,7 @@ func (o CreateAccount) Serialize() (encoded []byte, err error) {
 }
 
 // Implement transaction/operation : IsWellFormed
-func (o CreateAccount) IsWellFormed([]byte, common.Config) (err error) {
+func (o CreateAccount) IsWellFormed(networkID []byte, conf common.Config) (err error) {
 	if _, err = keypair.Parse(o.Target); err != nil {
 		return
 	}
@@ -38,7 +38,7 @@ func (o CreateAccount) IsWellFormed([]byte, common.Config) (err error) {
 		return
 	}
 
-	if o.Amount < common.BaseReserve {
+	if o.Amount < conf.NetworkParams.BaseReserve {
 		err = errors.InsufficientAmountNewAccount
 		return
 	}
@@ -19,6 +19,9 @@ const (
 	TypeCollectTxFee         OperationType = "collect-tx-fee"
 	TypeInflation            OperationType = "inflation"
 	TypeUnfreezingRequest    OperationType = "unfreezing-request"
+	TypeSetSigners           OperationType = "set-signers"
+	TypeAddValidator         OperationType = "add-validator"
+	TypeRemoveValidator      OperationType = "remove-validator"
 )
 
 func IsValidOperationType(oType string) bool {
@@ -29,6 +32,9 @@ func IsValidOperationType(oType string) bool {
 		string(TypeCongressVotingResult),
 		string(TypeCollectTxFee),
 		string(TypeInflation),
+		string(TypeSetSigners),
+		string(TypeAddValidator),
+		string(TypeRemoveValidator),
 	}, oType)
 	return b
 }
@@ -39,6 +45,9 @@ var KindsNormalTransaction map[OperationType]struct{} = map[OperationType]struct
 	TypeCongressVoting:       struct{}{},
 	TypeCongressVotingResult: struct{}{},
 	TypeUnfreezingRequest:    struct{}{},
+	TypeSetSigners:           struct{}{},
+	TypeAddValidator:         struct{}{},
+	TypeRemoveValidator:      struct{}{},
 }
 
 type Operation struct {
@@ -63,6 +72,12 @@ func NewOperation(opb Body) (op Operation, err error) {
 		t = TypeCongressVoting
 	case CongressVotingResult:
 		t = TypeCongressVotingResult
+	case SetSigners:
+		t = TypeSetSigners
+	case AddValidator:
+		t = TypeAddValidator
+	case RemoveValidator:
+		t = TypeRemoveValidator
 	default:
 		err = errors.UnknownOperationType
 		return
@@ -193,6 +208,24 @@ func UnmarshalBodyJSON(t OperationType, b []byte) (body Body, err error) {
 			return
 		}
 		body = ob
+	case TypeSetSigners:
+		var ob SetSigners
+		if err = json.Unmarshal(b, &ob); err != nil {
+			return
+		}
+		body = ob
+	case TypeAddValidator:
+		var ob AddValidator
+		if err = json.Unmarshal(b, &ob); err != nil {
+			return
+		}
+		body = ob
+	case TypeRemoveValidator:
+		var ob RemoveValidator
+		if err = json.Unmarshal(b, &ob); err != nil {
+			return
+		}
+		body = ob
 	default:
 		err = errors.InvalidOperation
 		return
@@ -28,6 +28,7 @@ func NewOperationBodyInflation(
 	target string,
 	amount common.Amount,
 	initialBalance common.Amount,
+	ratio float64,
 	blockHeight uint64,
 	blockHash string,
 	totalTxs uint64,
@@ -36,7 +37,7 @@ func NewOperationBodyInflation(
 		Target:         target,
 		Amount:         amount,
 		InitialBalance: initialBalance,
-		Ratio:          common.InflationRatioString,
+		Ratio:          common.InflationRatio2String(ratio),
 		Height:         blockHeight,
 		BlockHash:      blockHash,
 		TotalTxs:       totalTxs,
@@ -0,0 +1,134 @@
+package operation
+
+import (
+	"encoding/json"
+
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// MaxSigners is the maximum number of signers of one account.
+const MaxSigners int = 20
+
+// ThresholdLevel decides which threshold of the source account must be
+// reached by the signatures of transaction.
+type ThresholdLevel int
+
+const (
+	ThresholdLow ThresholdLevel = iota
+	ThresholdMedium
+	ThresholdHigh
+)
+
+// ThresholdLevels sets the `ThresholdLevel` of each operation type; the
+// transaction requires the highest level of it's operations.
+var ThresholdLevels map[OperationType]ThresholdLevel = map[OperationType]ThresholdLevel{
+	TypeCreateAccount:        ThresholdMedium,
+	TypePayment:              ThresholdMedium,
+	TypeCongressVoting:       ThresholdLow,
+	TypeCongressVotingResult: ThresholdLow,
+	TypeUnfreezingRequest:    ThresholdMedium,
+	TypeSetSigners:           ThresholdHigh,
+	TypeAddValidator:         ThresholdHigh,
+	TypeRemoveValidator:      ThresholdHigh,
+}
+
+func (o Operation) ThresholdLevel() ThresholdLevel {
+	if level, found := ThresholdLevels[o.H.Type]; found {
+		return level
+	}
+
+	return ThresholdHigh
+}
+
+type Signer struct {
+	Address string `json:"address"`
+	Weight  uint8  `json:"weight"`
+}
+
+type Thresholds struct {
+	Low    uint8 `json:"low"`
+	Medium uint8 `json:"medium"`
+	High   uint8 `json:"high"`
+}
+
+func (t Thresholds) Get(level ThresholdLevel) uint8 {
+	switch level {
+	case ThresholdLow:
+		return t.Low
+	case ThresholdMedium:
+		return t.Medium
+	default:
+		return t.High
+	}
+}
+
+// SetSigners replaces the signer set and thresholds of the source account.
+// Once set, the key of source account can sign only when it is one of the
+// `Signers`. Empty `Signers` and zero `Thresholds` make the account to be
+// signed by it's own key again.
+type SetSigners struct {
+	Signers    []Signer   `json:"signers"`
+	Thresholds Thresholds `json:"thresholds"`
+}
+
+func NewSetSigners(signers []Signer, thresholds Thresholds) SetSigners {
+	return SetSigners{
+		Signers:    signers,
+		Thresholds: thresholds,
+	}
+}
+
+func (o SetSigners) Serialize() (encoded []byte, err error) {
+	return json.Marshal(o)
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o SetSigners) IsWellFormed([]byte, common.Config) (err error) {
+	if len(o.Signers) > MaxSigners {
+		err = errors.TooManySigners
+		return
+	}
+
+	if len(o.Signers) < 1 {
+		if o.Thresholds != (Thresholds{}) {
+			err = errors.InvalidSignerThresholds
+		}
+		return
+	}
+
+	var totalWeight int
+	addresses := map[string]bool{}
+	for _, signer := range o.Signers {
+		if _, err = keypair.Parse(signer.Address); err != nil {
+			err = errors.BadPublicAddress
+			return
+		}
+		if signer.Weight < 1 {
+			err = errors.InvalidSignerThresholds
+			return
+		}
+		if _, found := addresses[signer.Address]; found {
+			err = errors.DuplicatedSigner
+			return
+		}
+		addresses[signer.Address] = true
+		totalWeight += int(signer.Weight)
+	}
+
+	if o.Thresholds.Low > o.Thresholds.Medium || o.Thresholds.Medium > o.Thresholds.High {
+		err = errors.InvalidSignerThresholds
+		return
+	}
+
+	// the account must not be locked by the thresholds which can not be
+	// reached by the signers.
+	if totalWeight < int(o.Thresholds.High) {
+		err = errors.InvalidSignerThresholds
+		return
+	}
+
+	return
+}
@@ -0,0 +1,95 @@
+package operation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestSetSignersIsWellFormed(t *testing.T) {
+	conf := common.NewConfig()
+	kp0, _ := keypair.Random()
+	kp1, _ := keypair.Random()
+
+	{ // valid
+		opb := NewSetSigners(
+			[]Signer{{Address: kp0.Address(), Weight: 1}, {Address: kp1.Address(), Weight: 2}},
+			Thresholds{Low: 1, Medium: 2, High: 3},
+		)
+		require.NoError(t, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // empty signers resets the account
+		opb := NewSetSigners(nil, Thresholds{})
+		require.NoError(t, opb.IsWellFormed(networkID, conf))
+
+		opb = NewSetSigners(nil, Thresholds{Low: 1})
+		require.Equal(t, errors.InvalidSignerThresholds, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // duplicated signer
+		opb := NewSetSigners(
+			[]Signer{{Address: kp0.Address(), Weight: 1}, {Address: kp0.Address(), Weight: 2}},
+			Thresholds{Low: 1, Medium: 1, High: 1},
+		)
+		require.Equal(t, errors.DuplicatedSigner, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // zero weight
+		opb := NewSetSigners(
+			[]Signer{{Address: kp0.Address(), Weight: 0}},
+			Thresholds{},
+		)
+		require.Equal(t, errors.InvalidSignerThresholds, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // thresholds can not be reached
+		opb := NewSetSigners(
+			[]Signer{{Address: kp0.Address(), Weight: 1}, {Address: kp1.Address(), Weight: 1}},
+			Thresholds{Low: 1, Medium: 2, High: 3},
+		)
+		require.Equal(t, errors.InvalidSignerThresholds, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // unordered thresholds
+		opb := NewSetSigners(
+			[]Signer{{Address: kp0.Address(), Weight: 3}},
+			Thresholds{Low: 2, Medium: 1, High: 3},
+		)
+		require.Equal(t, errors.InvalidSignerThresholds, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // too many signers
+		var signers []Signer
+		for i := 0; i < MaxSigners+1; i++ {
+			kp, _ := keypair.Random()
+			signers = append(signers, Signer{Address: kp.Address(), Weight: 1})
+		}
+		opb := NewSetSigners(signers, Thresholds{Low: 1, Medium: 1, High: 1})
+		require.Equal(t, errors.TooManySigners, opb.IsWellFormed(networkID, conf))
+	}
+}
+
+func TestSetSignersSerialize(t *testing.T) {
+	kp0, _ := keypair.Random()
+	opb := NewSetSigners(
+		[]Signer{{Address: kp0.Address(), Weight: 1}},
+		Thresholds{Low: 1, Medium: 1, High: 1},
+	)
+	op, err := NewOperation(opb)
+	require.NoError(t, err)
+	require.Equal(t, TypeSetSigners, op.H.Type)
+	require.Equal(t, ThresholdHigh, op.ThresholdLevel())
+
+	b, err := op.Serialize()
+	require.NoError(t, err)
+
+	var o Operation
+	require.NoError(t, json.Unmarshal(b, &o))
+	require.Equal(t, opb, o.B.(SetSigners))
+}
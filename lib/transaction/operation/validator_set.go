@@ -0,0 +1,91 @@
+package operation
+
+import (
+	"encoding/json"
+
+	"github.com/stellar/go/keypair"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// AddValidator adds the validator to the validator set. The validators use
+// the new validator set from the block of `ActivationHeight`; if the
+// validator already exists, it's endpoint and alias are replaced.
+type AddValidator struct {
+	Address          string `json:"address"`
+	Endpoint         string `json:"endpoint"`
+	Alias            string `json:"alias"`
+	ActivationHeight uint64 `json:"activation-height"`
+}
+
+func NewAddValidator(address string, endpoint *common.Endpoint, alias string, activationHeight uint64) AddValidator {
+	return AddValidator{
+		Address:          address,
+		Endpoint:         endpoint.String(),
+		Alias:            alias,
+		ActivationHeight: activationHeight,
+	}
+}
+
+func (o AddValidator) Serialize() (encoded []byte, err error) {
+	return json.Marshal(o)
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o AddValidator) IsWellFormed([]byte, common.Config) (err error) {
+	if _, err = keypair.Parse(o.Address); err != nil {
+		err = errors.BadPublicAddress
+		return
+	}
+
+	var endpoint *common.Endpoint
+	if endpoint, err = common.NewEndpointFromString(o.Endpoint); err != nil {
+		err = errors.InvalidValidatorEndpoint
+		return
+	}
+	if len(endpoint.Scheme) < 1 || len(endpoint.Host) < 1 {
+		err = errors.InvalidValidatorEndpoint
+		return
+	}
+
+	if o.ActivationHeight <= common.GenesisBlockHeight {
+		err = errors.InvalidActivationHeight
+		return
+	}
+
+	return
+}
+
+// RemoveValidator removes the validator from the validator set at the block
+// of `ActivationHeight`.
+type RemoveValidator struct {
+	Address          string `json:"address"`
+	ActivationHeight uint64 `json:"activation-height"`
+}
+
+func NewRemoveValidator(address string, activationHeight uint64) RemoveValidator {
+	return RemoveValidator{
+		Address:          address,
+		ActivationHeight: activationHeight,
+	}
+}
+
+func (o RemoveValidator) Serialize() (encoded []byte, err error) {
+	return json.Marshal(o)
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o RemoveValidator) IsWellFormed([]byte, common.Config) (err error) {
+	if _, err = keypair.Parse(o.Address); err != nil {
+		err = errors.BadPublicAddress
+		return
+	}
+
+	if o.ActivationHeight <= common.GenesisBlockHeight {
+		err = errors.InvalidActivationHeight
+		return
+	}
+
+	return
+}
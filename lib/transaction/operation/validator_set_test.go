@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestAddValidatorIsWellFormed(t *testing.T) {
+	conf := common.NewConfig()
+	kp, _ := keypair.Random()
+	endpoint, _ := common.NewEndpointFromString("https://localhost:12345")
+
+	{ // valid
+		opb := NewAddValidator(kp.Address(), endpoint, "v1", 10)
+		require.NoError(t, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // invalid address
+		opb := NewAddValidator("showme", endpoint, "v1", 10)
+		require.Equal(t, errors.BadPublicAddress, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // invalid endpoint
+		opb := NewAddValidator(kp.Address(), endpoint, "v1", 10)
+		opb.Endpoint = "localhost"
+		require.Equal(t, errors.InvalidValidatorEndpoint, opb.IsWellFormed(networkID, conf))
+	}
+
+	{ // the genesis block can not be the activation height
+		opb := NewAddValidator(kp.Address(), endpoint, "v1", common.GenesisBlockHeight)
+		require.Equal(t, errors.InvalidActivationHeight, opb.IsWellFormed(networkID, conf))
+	}
+}
+
+func TestRemoveValidatorIsWellFormed(t *testing.T) {
+	conf := common.NewConfig()
+	kp, _ := keypair.Random()
+
+	require.NoError(t, NewRemoveValidator(kp.Address(), 10).IsWellFormed(networkID, conf))
+	require.Equal(t, errors.BadPublicAddress, NewRemoveValidator("showme", 10).IsWellFormed(networkID, conf))
+	require.Equal(t, errors.InvalidActivationHeight, NewRemoveValidator(kp.Address(), 0).IsWellFormed(networkID, conf))
+}
+
+func TestValidatorSetOperationJSON(t *testing.T) {
+	kp, _ := keypair.Random()
+	endpoint, _ := common.NewEndpointFromString("https://localhost:12345")
+
+	for _, opb := range []Body{
+		NewAddValidator(kp.Address(), endpoint, "v1", 10),
+		NewRemoveValidator(kp.Address(), 10),
+	} {
+		op, err := NewOperation(opb)
+		require.NoError(t, err)
+		require.Equal(t, ThresholdHigh, op.ThresholdLevel())
+
+		b, err := op.Serialize()
+		require.NoError(t, err)
+
+		var unmarshaled Operation
+		require.NoError(t, json.Unmarshal(b, &unmarshaled))
+		require.Equal(t, op.H.Type, unmarshaled.H.Type)
+		require.Equal(t, opb, unmarshaled.B)
+		require.Equal(t, op.MakeHashString(), unmarshaled.MakeHashString())
+	}
+}
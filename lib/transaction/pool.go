@@ -1,25 +1,64 @@
 package transaction
 
 import (
+	"sort"
 	"sync"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/metrics"
 )
 
+// PoolJournal records the changes of `Pool` to the persistent storage.
+type PoolJournal interface {
+	Add(Transaction) error
+	Remove(...string) error
+}
+
+// Pool keeps the incoming transactions until they are included in the block.
+//
+// The transactions are queued by source account and ordered by
+// `Body.SequenceID` in the source queue, so the sequential transactions of
+// one account can wait together. Only the head of each source queue can be
+// proposed, because one block can not have the transactions of the same
+// source.
+//
+// The transactions are also ordered by the fee per operation; the higher fee
+// comes first and the older one comes first in the same fee. When the number
+// of transactions reaches `limit`, the transaction of the lowest fee at the
+// tail of source queues is evicted for the new one.
 type Pool struct {
 	sync.RWMutex
 
 	Pool    map[ /* Transaction.GetHash() */ string]Transaction
-	Sources map[ /* Transaction.Source() */ string]bool
-	hashes  []string // Transaction.GetHash()
+	Sources map[ /* Transaction.Source() */ string][]string // Transaction.GetHash(), ordered by `Body.SequenceID`
+
+	hashes  []string // Transaction.GetHash(), ordered by priority
+	added   map[ /* Transaction.GetHash() */ string]uint64
+	serial  uint64
+	limit   int
+	journal PoolJournal
 }
 
-func NewPool() *Pool {
+func NewPool(conf common.Config) *Pool {
 	return &Pool{
 		Pool:    map[string]Transaction{},
-		Sources: map[string]bool{},
+		Sources: map[string][]string{},
 		hashes:  []string{},
+		added:   map[string]uint64{},
+		limit:   conf.TxPoolLimit,
 	}
 }
 
+// SetJournal sets the `PoolJournal`; after then, the added and removed
+// transactions are recorded.
+func (tp *Pool) SetJournal(journal PoolJournal) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	tp.journal = journal
+}
+
 func (tp *Pool) Len() int {
 	tp.RLock()
 	defer tp.RUnlock()
@@ -43,22 +82,118 @@ func (tp *Pool) Get(hash string) (tx Transaction, found bool) {
 	return
 }
 
-func (tp *Pool) Add(tx Transaction) bool {
-	if tp.Has(tx.GetHash()) {
-		return false
+// feePerOperation is the priority of transaction in `Pool`.
+func feePerOperation(tx Transaction) common.Amount {
+	if len(tx.B.Operations) < 1 {
+		return tx.B.Fee
+	}
+	return tx.B.Fee / common.Amount(len(tx.B.Operations))
+}
+
+// higher checks the transaction of `a` has the higher priority than `b`.
+func (tp *Pool) higher(a, b string) bool {
+	feeA, feeB := feePerOperation(tp.Pool[a]), feePerOperation(tp.Pool[b])
+	if feeA != feeB {
+		return feeA > feeB
 	}
 
+	return tp.added[a] < tp.added[b]
+}
+
+// Add puts the transaction into the queue of it's source; the known
+// transaction is ignored. The `Body.SequenceID` of transaction must not be in
+// the source queue. If `Pool` is full, the lowest fee transaction is evicted
+// and returned; when the new transaction has the lowest fee,
+// `errors.TransactionPoolFull` is returned.
+func (tp *Pool) Add(tx Transaction) (evicted []Transaction, err error) {
 	tp.Lock()
 	defer tp.Unlock()
 
-	tp.Pool[tx.GetHash()] = tx
-	tp.Sources[tx.Source()] = true
-	tp.hashes = append(tp.hashes, tx.GetHash())
+	hash := tx.GetHash()
+	if _, found := tp.Pool[hash]; found {
+		return
+	}
+	if tp.hasSequenceID(tx.Source(), tx.B.SequenceID) {
+		err = errors.TransactionSameSource
+		return
+	}
+
+	tp.Pool[hash] = tx
+	tp.serial++
+	tp.added[hash] = tp.serial
 
-	return true
+	var lowest string
+	if tp.limit > 0 && len(tp.Pool) > tp.limit {
+		lowest = tp.lowest(tx.Source())
+		if lowest == "" || !tp.higher(hash, lowest) {
+			delete(tp.Pool, hash)
+			delete(tp.added, hash)
+			err = errors.TransactionPoolFull
+			return
+		}
+	}
+
+	if tp.journal != nil {
+		if err = tp.journal.Add(tx); err != nil {
+			delete(tp.Pool, hash)
+			delete(tp.added, hash)
+			return
+		}
+	}
+
+	source := tx.Source()
+	queue := append(tp.Sources[source], hash)
+	sort.SliceStable(queue, func(i, j int) bool {
+		return tp.Pool[queue[i]].B.SequenceID < tp.Pool[queue[j]].B.SequenceID
+	})
+	tp.Sources[source] = queue
+
+	i := sort.Search(len(tp.hashes), func(i int) bool {
+		return tp.higher(hash, tp.hashes[i])
+	})
+	tp.hashes = append(tp.hashes, "")
+	copy(tp.hashes[i+1:], tp.hashes[i:])
+	tp.hashes[i] = hash
+
+	// the lowest one is evicted after the new transaction is recorded, so the
+	// failure of journal does not lose the evicted transaction.
+	if lowest != "" {
+		etx := tp.Pool[lowest]
+		if err = tp.remove(lowest); err != nil {
+			if rerr := tp.remove(hash); rerr != nil {
+				err = rerr
+			}
+			tp.updateMetrics()
+			return
+		}
+		evicted = append(evicted, etx)
+		metrics.TxPoolEvicted.Inc()
+	}
+
+	tp.updateMetrics()
+
+	return
 }
 
-func (tp *Pool) Remove(hashes ...string) {
+// lowest finds the lowest priority transaction at the tail of source queues;
+// the tail of `exclude` source is not evicted to keep the new transaction of
+// the source in sequence.
+func (tp *Pool) lowest(exclude string) string {
+	for i := len(tp.hashes) - 1; i >= 0; i-- {
+		hash := tp.hashes[i]
+		source := tp.Pool[hash].Source()
+		if source == exclude {
+			continue
+		}
+		if queue := tp.Sources[source]; queue[len(queue)-1] == hash {
+			return hash
+		}
+	}
+
+	return ""
+}
+
+func (tp *Pool) Remove(hashes ...string) (err error) {
 	if len(hashes) < 1 {
 		return
 	}
@@ -67,20 +202,64 @@ func (tp *Pool) Remove(hashes ...string) {
 	defer tp.Unlock()
 
 	for _, hash := range hashes {
-		if tx, found := tp.Pool[hash]; found {
-			delete(tp.Sources, tx.Source())
-			delete(tp.Pool, hash)
-			for i, h := range tp.hashes {
-				if h == hash {
-					tp.hashes = append(tp.hashes[:i], tp.hashes[i+1:]...)
-					break
-				}
-			}
+		if err = tp.remove(hash); err != nil {
+			break
 		}
 	}
+
+	tp.updateMetrics()
+
+	return
 }
 
-func (tp *Pool) AvailableTransactions(transactionLimit int) []string {
+func (tp *Pool) remove(hash string) (err error) {
+	tx, found := tp.Pool[hash]
+	if !found {
+		return
+	}
+
+	if tp.journal != nil {
+		if err = tp.journal.Remove(hash); err != nil {
+			return
+		}
+	}
+
+	source := tx.Source()
+	queue := tp.Sources[source]
+	for i, h := range queue {
+		if h == hash {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) < 1 {
+		delete(tp.Sources, source)
+	} else {
+		tp.Sources[source] = queue
+	}
+
+	for i, h := range tp.hashes {
+		if h == hash {
+			tp.hashes = append(tp.hashes[:i], tp.hashes[i+1:]...)
+			break
+		}
+	}
+
+	delete(tp.Pool, hash)
+	delete(tp.added, hash)
+
+	return
+}
+
+func (tp *Pool) updateMetrics() {
+	metrics.TxPoolSize.Set(float64(len(tp.Pool)))
+	metrics.TxPoolSources.Set(float64(len(tp.Sources)))
+}
+
+// AvailableTransactions returns the transactions which can be included in
+// the block of `height`; the heads of source queues are returned by the order
+// of fee per operation.
+func (tp *Pool) AvailableTransactions(transactionLimit int, height uint64) []string {
 	if transactionLimit < 1 {
 		return nil
 	}
@@ -89,21 +268,92 @@ func (tp *Pool) AvailableTransactions(transactionLimit int) []string {
 	defer tp.RUnlock()
 
 	var ret []string
-	// first ouput by order older hash
-	for _, key := range tp.hashes {
+	for _, hash := range tp.hashes {
 		if len(ret) == transactionLimit {
 			return ret
 		}
-		ret = append(ret, key)
+		tx := tp.Pool[hash]
+		if tp.Sources[tx.Source()][0] != hash {
+			continue
+		}
+		if !tx.IsValidHeight(height) {
+			continue
+		}
+		ret = append(ret, hash)
 	}
 	return ret
 }
 
-func (tp *Pool) IsSameSource(source string) (found bool) {
+// RemoveExpired removes the transactions which can not be included in the
+// block of `height` and the later blocks; when it fails to remove, the
+// transactions removed until then are returned with the error.
+func (tp *Pool) RemoveExpired(height uint64) (expired []Transaction, err error) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	var hashes []string
+	for _, hash := range tp.hashes {
+		if tp.Pool[hash].IsExpired(height) {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	for _, hash := range hashes {
+		tx := tp.Pool[hash]
+		if err = tp.remove(hash); err != nil {
+			break
+		}
+		expired = append(expired, tx)
+	}
+
+	tp.updateMetrics()
+
+	return
+}
+
+// QueuedAmount returns the total amount with the fees of the transactions in
+// the source queue.
+func (tp *Pool) QueuedAmount(source string) (amount common.Amount) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	for _, hash := range tp.Sources[source] {
+		amount = amount.MustAdd(tp.Pool[hash].TotalAmount(true))
+	}
+
+	return
+}
+
+// HasSequenceID checks the transaction of `sequenceID` is already in the
+// source queue.
+func (tp *Pool) HasSequenceID(source string, sequenceID uint64) bool {
 	tp.RLock()
 	defer tp.RUnlock()
 
-	_, found = tp.Sources[source]
+	return tp.hasSequenceID(source, sequenceID)
+}
+
+func (tp *Pool) hasSequenceID(source string, sequenceID uint64) bool {
+	for _, hash := range tp.Sources[source] {
+		if tp.Pool[hash].B.SequenceID == sequenceID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextSequenceID returns the `Body.SequenceID` of the next transaction, which
+// can be queued after the transactions of the source queue.
+func (tp *Pool) NextSequenceID(source string) (sequenceID uint64, found bool) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	queue, found := tp.Sources[source]
+	if !found {
+		return
+	}
 
+	sequenceID = tp.Pool[queue[len(queue)-1]].B.SequenceID + 1
 	return
 }
@@ -26,7 +26,7 @@ func TestMakeTransaction(networkID []byte, n int) (kp *keypair.Full, tx Transact
 		ops = append(ops, operation.TestMakeOperation(-1))
 	}
 
-	tx, _ = NewTransaction(kp.Address(), 0, ops...)
+	tx, _ = NewTransaction(kp.Address(), 0, common.BaseFee, ops...)
 	tx.Sign(kp, networkID)
 
 	return
@@ -47,6 +47,7 @@ func TestMakeTransactionWithKeypair(networkID []byte, n int, srcKp *keypair.Full
 	tx, _ = NewTransaction(
 		srcKp.Address(),
 		0,
+		common.BaseFee,
 		ops...,
 	)
 	tx.Sign(srcKp, networkID)
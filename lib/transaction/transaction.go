@@ -2,8 +2,10 @@ package transaction
 
 import (
 	"encoding/json"
+	"io"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stellar/go/keypair"
 
 	"boscoin.io/sebak/lib/common"
@@ -32,6 +34,14 @@ type Header struct {
 	// has to validate it anyway.
 	Hash      string `json:"-"`
 	Signature string `json:"signature"`
+	// Signatures are from the signers of source account, which are set by
+	// `operation.SetSigners`.
+	Signatures []Signature `json:"signatures,omitempty"`
+}
+
+type Signature struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
 }
 
 type Body struct {
@@ -39,6 +49,28 @@ type Body struct {
 	Fee        common.Amount         `json:"fee"`
 	SequenceID uint64                `json:"sequence_id"`
 	Operations []operation.Operation `json:"operations"`
+	// MinHeight and MaxHeight bound the height of block which can include
+	// the transaction; 0 means no bound.
+	MinHeight uint64 `json:"min_height,omitempty"`
+	MaxHeight uint64 `json:"max_height,omitempty"`
+	Memo      *Memo  `json:"memo,omitempty"`
+}
+
+// EncodeRLP encodes the height bounds and memo only when they are set, so the
+// hash of the transaction without them is not changed.
+func (tb Body) EncodeRLP(w io.Writer) error {
+	if tb.Memo != nil {
+		return rlp.Encode(w, []interface{}{
+			tb.Source, tb.Fee, tb.SequenceID, tb.Operations, tb.MinHeight, tb.MaxHeight,
+			string(tb.Memo.Type), tb.Memo.Value,
+		})
+	}
+
+	if tb.MinHeight == 0 && tb.MaxHeight == 0 {
+		return rlp.Encode(w, []interface{}{tb.Source, tb.Fee, tb.SequenceID, tb.Operations})
+	}
+
+	return rlp.Encode(w, []interface{}{tb.Source, tb.Fee, tb.SequenceID, tb.Operations, tb.MinHeight, tb.MaxHeight})
 }
 
 func (tb Body) MakeHash() []byte {
@@ -61,7 +93,10 @@ func (t *Transaction) UnmarshalJSON(b []byte) (err error) {
 	return
 }
 
-func NewTransaction(source string, sequenceID uint64, ops ...operation.Operation) (tx Transaction, err error) {
+// NewTransaction makes the transaction; the fee is `baseFee` by operation,
+// so `baseFee` should be the base fee of the network, which can be different
+// from `common.BaseFee`.
+func NewTransaction(source string, sequenceID uint64, baseFee common.Amount, ops ...operation.Operation) (tx Transaction, err error) {
 	if len(ops) < 1 {
 		err = errors.TransactionEmptyOperations
 		return
@@ -69,7 +104,7 @@ func NewTransaction(source string, sequenceID uint64, ops ...operation.Operation
 
 	txBody := Body{
 		Source:     source,
-		Fee:        common.BaseFee.MustMult(len(ops)),
+		Fee:        baseFee.MustMult(len(ops)),
 		SequenceID: sequenceID,
 		Operations: ops,
 	}
@@ -90,6 +125,8 @@ var TransactionWellFormedCheckerFuncs = []common.CheckerFunc{
 	CheckSequenceID,
 	CheckSource,
 	CheckBaseFee,
+	CheckHeightBounds,
+	CheckMemo,
 	CheckOperationTypes,
 	CheckOperations,
 	CheckVerifySignature,
@@ -126,6 +163,22 @@ func (tx Transaction) IsValidSequenceID(sequenceID uint64) bool {
 	return tx.B.SequenceID == sequenceID
 }
 
+// IsValidHeight checks the transaction can be included in the block of
+// `height`.
+func (tx Transaction) IsValidHeight(height uint64) bool {
+	if tx.B.MinHeight > 0 && height < tx.B.MinHeight {
+		return false
+	}
+
+	return !tx.IsExpired(height)
+}
+
+// IsExpired returns true when the transaction can not be included in the
+// block of `height` or the later blocks.
+func (tx Transaction) IsExpired(height uint64) bool {
+	return tx.B.MaxHeight > 0 && height > tx.B.MaxHeight
+}
+
 func (tx Transaction) GetHash() string {
 	return tx.H.Hash
 }
@@ -165,9 +218,10 @@ func (tx Transaction) TotalAmount(withFee bool) common.Amount {
 	return amount
 }
 
-// TotalBaseFee returns the minimum fee of transaction.
-func (tx Transaction) TotalBaseFee() common.Amount {
-	return common.BaseFee.MustMult(len(tx.B.Operations))
+// TotalBaseFee returns the minimum fee of transaction by the base fee of
+// operation.
+func (tx Transaction) TotalBaseFee(baseFee common.Amount) common.Amount {
+	return baseFee.MustMult(len(tx.B.Operations))
 }
 
 func (tx Transaction) Serialize() (encoded []byte, err error) {
@@ -189,3 +243,51 @@ func (tx *Transaction) Sign(kp keypair.KP, networkID []byte) {
 
 	return
 }
+
+// AddSignature adds the signature of one of the signers of source account.
+// Unlike `Sign()`, `Body.Source` is not changed.
+func (tx *Transaction) AddSignature(kp keypair.KP, networkID []byte) {
+	tx.H.Hash = tx.B.MakeHashString()
+	signature, _ := common.MakeSignature(kp, networkID, tx.H.Hash)
+
+	for i, s := range tx.H.Signatures {
+		if s.Signer == kp.Address() {
+			tx.H.Signatures[i].Signature = base58.Encode(signature)
+			return
+		}
+	}
+
+	tx.H.Signatures = append(tx.H.Signatures, Signature{
+		Signer:    kp.Address(),
+		Signature: base58.Encode(signature),
+	})
+
+	return
+}
+
+// Signers returns the addresses which signed this transaction; the source is
+// included only when `Header.Signature` exists. The signatures are not
+// verified here, see `CheckVerifySignature`.
+func (tx Transaction) Signers() (signers []string) {
+	if len(tx.H.Signature) > 0 {
+		signers = append(signers, tx.B.Source)
+	}
+	for _, s := range tx.H.Signatures {
+		signers = append(signers, s.Signer)
+	}
+
+	return
+}
+
+// ThresholdLevel returns the highest `operation.ThresholdLevel` of it's
+// operations.
+func (tx Transaction) ThresholdLevel() operation.ThresholdLevel {
+	level := operation.ThresholdLow
+	for _, op := range tx.B.Operations {
+		if l := op.ThresholdLevel(); l > level {
+			level = l
+		}
+	}
+
+	return level
+}
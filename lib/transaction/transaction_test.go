@@ -77,6 +77,20 @@ func (suite *TestSuite) TestIsWellFormedTransactionWithLowerFeeSuite() {
 		err = tx.IsWellFormed(networkID, suite.conf)
 		require.Equal(suite.T(), errors.InvalidFee, err, "Transaction shouidn't pass Fee checks")
 	}
+	{ // base fee of network is higher than the default
+		conf := suite.conf
+		conf.NetworkParams.BaseFee = common.BaseFee.MustMult(2)
+
+		kp, tx := TestMakeTransaction(networkID, 3)
+		tx.Sign(kp, networkID)
+		err = tx.IsWellFormed(networkID, conf)
+		require.Equal(suite.T(), errors.InvalidFee, err, "Transaction shouidn't pass Fee checks")
+
+		tx.B.Fee = conf.NetworkParams.BaseFee.MustMult(3)
+		tx.Sign(kp, networkID)
+		err = tx.IsWellFormed(networkID, conf)
+		require.Nil(suite.T(), err)
+	}
 }
 
 func (suite *TestSuite) TestIsWellFormedTransactionWithInvalidSourceAddressSuite() {
@@ -134,3 +148,271 @@ func (suite *TestSuite) TestIsWellFormedTransactionMaxOperationsInTransactionSui
 func TestTransaction(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
+
+func (suite *TestSuite) TestIsWellFormedTransactionWithSignaturesSuite() {
+	kpSigner0, _ := keypair.Random()
+	kpSigner1, _ := keypair.Random()
+
+	{ // signed only by the signers
+		_, tx := TestMakeTransaction(networkID, 1)
+		tx.H.Signature = ""
+		tx.AddSignature(kpSigner0, networkID)
+		tx.AddSignature(kpSigner1, networkID)
+
+		require.Nil(suite.T(), tx.IsWellFormed(networkID, suite.conf))
+		require.Equal(suite.T(), []string{kpSigner0.Address(), kpSigner1.Address()}, tx.Signers())
+	}
+
+	{ // signed by source and signer
+		kp, tx := TestMakeTransaction(networkID, 1)
+		tx.AddSignature(kpSigner0, networkID)
+
+		require.Nil(suite.T(), tx.IsWellFormed(networkID, suite.conf))
+		require.Equal(suite.T(), []string{kp.Address(), kpSigner0.Address()}, tx.Signers())
+	}
+
+	{ // invalid signature of signer
+		_, tx := TestMakeTransaction(networkID, 1)
+		tx.AddSignature(kpSigner0, networkID)
+		tx.H.Signatures[0].Signer = kpSigner1.Address()
+
+		require.NotNil(suite.T(), tx.IsWellFormed(networkID, suite.conf))
+	}
+
+	{ // duplicated signer
+		_, tx := TestMakeTransaction(networkID, 1)
+		tx.AddSignature(kpSigner0, networkID)
+		tx.H.Signatures = append(tx.H.Signatures, tx.H.Signatures[0])
+
+		require.Equal(suite.T(), errors.DuplicatedSigner, tx.IsWellFormed(networkID, suite.conf))
+	}
+
+	{ // without any signature
+		_, tx := TestMakeTransaction(networkID, 1)
+		tx.H.Signature = ""
+
+		require.NotNil(suite.T(), tx.IsWellFormed(networkID, suite.conf))
+	}
+}
+
+func (suite *TestSuite) TestTransactionHeightBounds() {
+	kp, tx := TestMakeTransaction(networkID, 1)
+	hashWithoutBounds := tx.GetHash()
+
+	require.True(suite.T(), tx.IsValidHeight(1))
+	require.False(suite.T(), tx.IsExpired(100))
+
+	tx.B.MinHeight = 3
+	tx.B.MaxHeight = 5
+	tx.Sign(kp, networkID)
+	require.NotEqual(suite.T(), hashWithoutBounds, tx.GetHash())
+	require.Nil(suite.T(), tx.IsWellFormed(networkID, suite.conf))
+
+	require.False(suite.T(), tx.IsValidHeight(2))
+	require.True(suite.T(), tx.IsValidHeight(3))
+	require.True(suite.T(), tx.IsValidHeight(5))
+	require.False(suite.T(), tx.IsValidHeight(6))
+	require.False(suite.T(), tx.IsExpired(5))
+	require.True(suite.T(), tx.IsExpired(6))
+
+	// bounds are kept after serialization
+	b, err := tx.Serialize()
+	require.NoError(suite.T(), err)
+	var decoded Transaction
+	require.NoError(suite.T(), json.Unmarshal(b, &decoded))
+	require.Equal(suite.T(), tx.GetHash(), decoded.GetHash())
+
+	// `min_height` is greater than `max_height`
+	tx.B.MinHeight = 6
+	tx.Sign(kp, networkID)
+	require.Equal(suite.T(), errors.TransactionInvalidHeightBounds, tx.IsWellFormed(networkID, suite.conf))
+}
+
+func (suite *TestSuite) TestTransactionMemo() {
+	kp, tx := TestMakeTransaction(networkID, 1)
+	hashWithoutMemo := tx.GetHash()
+
+	memo, err := NewMemo(MemoTypeText, "customer-1234")
+	require.NoError(suite.T(), err)
+	tx.B.Memo = memo
+	tx.Sign(kp, networkID)
+	require.NotEqual(suite.T(), hashWithoutMemo, tx.GetHash())
+	require.Nil(suite.T(), tx.IsWellFormed(networkID, suite.conf))
+
+	// memo is covered by the signature
+	signed := tx
+	signed.B.Memo = &Memo{Type: MemoTypeText, Value: "customer-5678"}
+	signed.H.Hash = signed.B.MakeHashString()
+	require.Error(suite.T(), signed.IsWellFormed(networkID, suite.conf))
+
+	// memo is kept after serialization
+	b, err := tx.Serialize()
+	require.NoError(suite.T(), err)
+	var decoded Transaction
+	require.NoError(suite.T(), json.Unmarshal(b, &decoded))
+	require.Equal(suite.T(), tx.GetHash(), decoded.GetHash())
+	require.Equal(suite.T(), *memo, *decoded.B.Memo)
+
+	{ // valid memos
+		_, err = NewMemo(MemoTypeID, "18446744073709551615")
+		require.NoError(suite.T(), err)
+		_, err = NewMemo(MemoTypeHash, base58.Encode(make([]byte, MemoHashLength)))
+		require.NoError(suite.T(), err)
+		_, err = NewMemo(MemoTypeText, string(make([]byte, MaxMemoTextLength)))
+		require.NoError(suite.T(), err)
+	}
+
+	{ // invalid memos
+		invalids := []Memo{
+			{Type: MemoTypeText, Value: string(make([]byte, MaxMemoTextLength+1))},
+			{Type: MemoTypeID, Value: "-1"},
+			{Type: MemoTypeID, Value: "abc"},
+			{Type: MemoTypeHash, Value: base58.Encode(make([]byte, MemoHashLength-1))},
+			{Type: MemoType("unknown"), Value: "1"},
+		}
+		for _, invalid := range invalids {
+			_, err = NewMemo(invalid.Type, invalid.Value)
+			require.Equal(suite.T(), errors.TransactionInvalidMemo, err)
+
+			m := invalid
+			tx.B.Memo = &m
+			tx.Sign(kp, networkID)
+			require.Equal(suite.T(), errors.TransactionInvalidMemo, tx.IsWellFormed(networkID, suite.conf))
+		}
+	}
+}
+
+func TestPoolHeightBounds(t *testing.T) {
+	pool := NewPool(common.NewConfig())
+
+	_, tx := TestMakeTransaction(networkID, 1)
+	kpFuture, txFuture := TestMakeTransaction(networkID, 1)
+	txFuture.B.MinHeight = 5
+	txFuture.Sign(kpFuture, networkID)
+	kpExpiring, txExpiring := TestMakeTransaction(networkID, 1)
+	txExpiring.B.MaxHeight = 3
+	txExpiring.Sign(kpExpiring, networkID)
+
+	for _, ptx := range []Transaction{tx, txFuture, txExpiring} {
+		pool.Add(ptx)
+	}
+
+	require.Equal(t, []string{tx.GetHash(), txExpiring.GetHash()}, pool.AvailableTransactions(10, 3))
+	require.Equal(t, []string{tx.GetHash(), txFuture.GetHash()}, pool.AvailableTransactions(10, 5))
+
+	expired, err := pool.RemoveExpired(3)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+	require.Equal(t, 3, pool.Len())
+
+	expired, err = pool.RemoveExpired(4)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(expired))
+	require.Equal(t, txExpiring.GetHash(), expired[0].GetHash())
+	require.Equal(t, 2, pool.Len())
+	require.False(t, pool.Has(txExpiring.GetHash()))
+	_, found := pool.NextSequenceID(kpExpiring.Address())
+	require.False(t, found)
+}
+
+func TestPoolPriority(t *testing.T) {
+	conf := common.NewConfig()
+	conf.TxPoolLimit = 4
+	pool := NewPool(conf)
+
+	makeTx := func(kp *keypair.Full, sequenceID uint64, fee common.Amount) Transaction {
+		tx := TestMakeTransactionWithKeypair(networkID, 1, kp)
+		tx.B.SequenceID = sequenceID
+		tx.B.Fee = fee
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	kpA, _ := keypair.Random()
+	kpB, _ := keypair.Random()
+	kpC, _ := keypair.Random()
+
+	txA0 := makeTx(kpA, 0, common.BaseFee)
+	txA1 := makeTx(kpA, 1, common.BaseFee*10)
+	txB0 := makeTx(kpB, 0, common.BaseFee*2)
+	txC0 := makeTx(kpC, 0, common.BaseFee)
+
+	for _, tx := range []Transaction{txA1, txA0, txB0, txC0} {
+		evicted, err := pool.Add(tx)
+		require.NoError(t, err)
+		require.Empty(t, evicted)
+	}
+
+	// same sequence id of same source
+	_, err := pool.Add(makeTx(kpA, 1, common.BaseFee*20))
+	require.Equal(t, errors.TransactionSameSource, err)
+
+	// only the heads of source queues are available by the order of fee
+	require.Equal(t, []string{txB0.GetHash(), txA0.GetHash(), txC0.GetHash()}, pool.AvailableTransactions(10, 1))
+	require.Equal(t, []string{txB0.GetHash()}, pool.AvailableTransactions(1, 1))
+
+	next, found := pool.NextSequenceID(kpA.Address())
+	require.True(t, found)
+	require.Equal(t, uint64(2), next)
+	require.Equal(t, txA0.TotalAmount(true)+txA1.TotalAmount(true), pool.QueuedAmount(kpA.Address()))
+	require.Equal(t, common.Amount(0), pool.QueuedAmount("unknown"))
+
+	// pool is full and the new transaction has the lowest fee
+	kpD, _ := keypair.Random()
+	_, err = pool.Add(makeTx(kpD, 0, common.BaseFee))
+	require.Equal(t, errors.TransactionPoolFull, err)
+	require.Equal(t, 4, pool.Len())
+
+	// the lowest fee transaction at the tail of queues is evicted
+	txD0 := makeTx(kpD, 0, common.BaseFee*3)
+	evicted, err := pool.Add(txD0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(evicted))
+	require.Equal(t, txC0.GetHash(), evicted[0].GetHash())
+	_, found = pool.NextSequenceID(kpC.Address())
+	require.False(t, found)
+	require.Equal(t, 4, pool.Len())
+
+	// after the head is removed, the next one of same source is available
+	require.NoError(t, pool.Remove(txA0.GetHash()))
+	require.Equal(
+		t,
+		[]string{txA1.GetHash(), txD0.GetHash(), txB0.GetHash()},
+		pool.AvailableTransactions(10, 1),
+	)
+}
+
+type failingPoolJournal struct{}
+
+func (failingPoolJournal) Add(Transaction) error {
+	return errors.TransactionPoolFull
+}
+
+func (failingPoolJournal) Remove(...string) error {
+	return nil
+}
+
+func TestPoolEvictWithFailingJournal(t *testing.T) {
+	conf := common.NewConfig()
+	conf.TxPoolLimit = 1
+	pool := NewPool(conf)
+
+	_, tx := TestMakeTransaction(networkID, 1)
+	_, err := pool.Add(tx)
+	require.NoError(t, err)
+
+	// the journal fails to record the new transaction, so the lowest one is
+	// not evicted
+	pool.SetJournal(failingPoolJournal{})
+	kp, _ := keypair.Random()
+	txHigher := TestMakeTransactionWithKeypair(networkID, 1, kp)
+	txHigher.B.Fee = common.BaseFee * 2
+	txHigher.Sign(kp, networkID)
+
+	evicted, err := pool.Add(txHigher)
+	require.Error(t, err)
+	require.Empty(t, evicted)
+	require.Equal(t, 1, pool.Len())
+	require.True(t, pool.Has(tx.GetHash()))
+	require.False(t, pool.Has(txHigher.GetHash()))
+}
@@ -0,0 +1,26 @@
+// Package proof verifies the merkle proofs of the state trie without the
+// storage, so the clients can check the proofs from the node.
+package proof
+
+import (
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+// VerifyProof checks the proof nodes against `root` and returns the value of
+// `key`; the value is nil if the trie does not contain `key`.
+func VerifyProof(root common.Hash, key []byte, proof [][]byte) (value []byte, err error) {
+	db := ethdb.NewMemDatabase()
+	for _, node := range proof {
+		if err = db.Put(crypto.Keccak256(node), node); err != nil {
+			return
+		}
+	}
+
+	value, _, err = trie.VerifyProof(ethcommon.Hash(root), key, db)
+	return
+}
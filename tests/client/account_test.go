@@ -49,7 +49,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(genesisAddr, uint64(genesisAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(genesisAddr, uint64(genesisAccount.SequenceID), common.BaseFee, o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(genesisSecret)
@@ -108,7 +108,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), common.BaseFee, o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account1Secret)
@@ -168,7 +168,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), common.BaseFee, o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account1Secret)
@@ -228,7 +228,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.Nil(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), common.BaseFee, o)
 		require.Nil(t, err)
 
 		sender, err := keypair.Parse(account1Secret)